@@ -3,8 +3,8 @@ module github.com/xx-labs/sleeve
 go 1.16
 
 require (
+	github.com/btcsuite/btcd v0.20.1-beta
 	github.com/btcsuite/btcutil v1.0.2
-	github.com/ethereum/go-ethereum v1.9.25
 	github.com/fatih/color v1.12.0
 	github.com/spf13/cobra v1.2.1
 	github.com/tyler-smith/go-bip39 v1.1.0
@@ -0,0 +1,217 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/xx-labs/sleeve/wallet"
+	"github.com/xx-labs/sleeve/wots"
+)
+
+var (
+	// Returned by ParseSleeveJson when the data isn't a JSON Sleeve object
+	ErrMalformedSleeveJson = errors.New("malformed Sleeve JSON")
+	// Returned by ParseSleeveJson when the JSON is well-formed but doesn't describe a valid Sleeve
+	ErrInvalidSleeveJson = errors.New("invalid Sleeve JSON")
+)
+
+// Parse and validate a Sleeve JSON object, as emitted with --output-type json
+// Returns an error wrapping ErrMalformedSleeveJson if the data can't be decoded,
+// or ErrInvalidSleeveJson if a required field is missing or a field is invalid
+func ParseSleeveJson(data []byte) (SleeveJson, error) {
+	var s SleeveJson
+	if err := json.Unmarshal(data, &s); err != nil {
+		return SleeveJson{}, fmt.Errorf("%w: %v", ErrMalformedSleeveJson, err)
+	}
+	if err := s.validate(); err != nil {
+		return SleeveJson{}, fmt.Errorf("%w: %v", ErrInvalidSleeveJson, err)
+	}
+	return s, nil
+}
+
+// Parse and validate a JSON array of Sleeve objects, as written to --output files
+// Errors wrap ErrMalformedSleeveJson or ErrInvalidSleeveJson, as with ParseSleeveJson
+func ParseSleeveJsonList(data []byte) ([]SleeveJson, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedSleeveJson, err)
+	}
+	list := make([]SleeveJson, len(raw))
+	for i, r := range raw {
+		s, err := ParseSleeveJson(r)
+		if err != nil {
+			return nil, fmt.Errorf("wallet %d: %w", i, err)
+		}
+		list[i] = s
+	}
+	return list, nil
+}
+
+// Check the fields of a decoded Sleeve JSON object
+func (s SleeveJson) validate() error {
+	if s.Quantum == "" {
+		return errors.New("missing QuantumPhrase")
+	}
+	if s.Address == "" {
+		return errors.New("missing Address")
+	}
+	if s.Path == "" {
+		return errors.New("missing DerivationPath")
+	}
+	if _, err := wallet.ParsePath(s.Path); err != nil {
+		return fmt.Errorf("invalid DerivationPath: %v", err)
+	}
+
+	if !s.SingleSeed {
+		if s.Standard == "" {
+			return errors.New("missing StandardPhrase")
+		}
+		return nil
+	}
+
+	// Single-seed fields
+	if s.WOTSIndex == nil {
+		return errors.New("missing WOTSIndex")
+	}
+	if *s.WOTSIndex >= 1<<31 {
+		return fmt.Errorf("invalid WOTSIndex %d: must be less than 2^31", *s.WOTSIndex)
+	}
+	if !validWOTSParams(s.WOTSParams) {
+		return fmt.Errorf("invalid WOTSParams %q", s.WOTSParams)
+	}
+	pk, err := hex.DecodeString(s.WOTSPublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid WOTSPublicKey: %v", err)
+	}
+	if len(pk) != wots.PKSize {
+		return fmt.Errorf("invalid WOTSPublicKey: %d bytes, expected %d", len(pk), wots.PKSize)
+	}
+	for i, nk := range s.NetworkKeys {
+		if err := nk.validate(); err != nil {
+			return fmt.Errorf("invalid NetworkKeys[%d]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Check the fields of a network key entry
+func (nk NetworkKeyInfo) validate() error {
+	if nk.Network == "" {
+		return errors.New("missing Network")
+	}
+	if nk.CoinType >= 1<<31 {
+		return fmt.Errorf("invalid CoinType %d: must be less than 2^31", nk.CoinType)
+	}
+	if expected := wallet.CurveForCoinType(nk.CoinType).String(); nk.Curve != expected {
+		return fmt.Errorf("invalid Curve %q: coin type %d uses %s", nk.Curve, nk.CoinType, expected)
+	}
+	if _, err := wallet.ParsePath(nk.Path); err != nil {
+		return fmt.Errorf("invalid Path: %v", err)
+	}
+	return nil
+}
+
+// Check a WOTS+ params name, as written by ParamsEncoding.String
+func validWOTSParams(name string) bool {
+	for level := wots.ParamsEncoding(0); level < wots.ParamsEncodingLen; level++ {
+		for h := wots.HashFunc(0); h < wots.HashFuncLen; h++ {
+			if level.WithHashFunc(h).String() == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Verify a file written with --output-type json: every wallet is recovered from its
+// quantum recovery phrase and passphrase, and compared to the file
+// Prints OK or MISMATCH for each wallet and returns an error if any doesn't match
+func verifySleeveFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	list, err := ParseSleeveJsonList(data)
+	if err != nil {
+		return err
+	}
+	mismatches := 0
+	for i, s := range list {
+		if err := verifySleeveJson(s); err != nil {
+			fmt.Printf("wallet %d: MISMATCH: %v\n", i, err)
+			mismatches++
+			continue
+		}
+		fmt.Printf("wallet %d: OK\n", i)
+	}
+	if mismatches > 0 {
+		return fmt.Errorf("%d of %d wallets don't match", mismatches, len(list))
+	}
+	return nil
+}
+
+// Recover a Sleeve from the phrases of a Sleeve JSON object and compare it
+func verifySleeveJson(s SleeveJson) error {
+	// The derivation path is m/44'/1955'/account'/params'/0'
+	path, _ := wallet.ParsePath(s.Path)
+	if len(path) != 5 {
+		return fmt.Errorf("derivation path %s isn't a Sleeve path", s.Path)
+	}
+	account, params := path[2]&^(1<<31), wots.ParamsEncoding(path[3]&^(1<<31))
+	if wots.DecodeParams(params) == nil {
+		return fmt.Errorf("derivation path %s has unknown WOTS+ params", s.Path)
+	}
+	spec := wallet.NewGenSpec(account, params)
+
+	if !s.SingleSeed {
+		sleeve, err := wallet.NewSleeveFromMnemonic(s.Quantum, s.Pass, spec)
+		if err != nil {
+			return err
+		}
+		if sleeve.GetOutputMnemonic() != s.Standard {
+			return errors.New("standard recovery phrase doesn't match")
+		}
+		return nil
+	}
+
+	sleeve, err := wallet.NewSingleSeedSleeveFromMnemonic(s.Quantum, s.Pass, spec)
+	if err != nil {
+		return err
+	}
+	defer sleeve.Wipe()
+	if sleeve.GetWOTSPublicKeyHex() != s.WOTSPublicKey {
+		return errors.New("WOTS+ public key doesn't match")
+	}
+	if sleeve.GetDerivationIndex() != *s.WOTSIndex {
+		return errors.New("WOTS-derived index doesn't match")
+	}
+	if sleeve.GetWOTSParams().String() != s.WOTSParams {
+		return errors.New("WOTS+ params don't match")
+	}
+	seed := sleeve.GetSeedUnsafe()
+	for _, nk := range s.NetworkKeys {
+		if _, exists := sleeve.GetAllNetworkKeys()[nk.Network]; !exists {
+			if err := sleeve.DeriveNetworkKey(nk.Network, nk.CoinType, seed); err != nil {
+				return err
+			}
+		}
+		netKey := sleeve.GetAllNetworkKeys()[nk.Network]
+		addr, err := sleeve.GetAddress(nk.Network)
+		if err != nil {
+			return err
+		}
+		if netKey.CoinType != nk.CoinType || netKey.Path != nk.Path || addr != nk.Address {
+			return fmt.Errorf("network %s doesn't match", nk.Network)
+		}
+	}
+	return nil
+}
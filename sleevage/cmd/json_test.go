@@ -0,0 +1,107 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xx-labs/sleeve/wallet"
+	"github.com/xx-labs/sleeve/wots"
+)
+
+const testMnemonic = "hamster diagram private dutch cause delay private meat slide toddler razor book happy fancy gospel tennis maple dilemma loan word shrug inflict delay length"
+
+// Sleeve JSON of a single-seed wallet, as emitted with --output-type json
+func singleSeedJson(t *testing.T) SleeveJson {
+	spec := wallet.NewGenSpec(0, wots.Level0)
+	path, err := spec.PathFromSpec()
+	if err != nil {
+		t.Fatalf("PathFromSpec() returned error: %v", err)
+	}
+	sleeve, err := wallet.NewSingleSeedSleeveFromMnemonic(testMnemonic, passphrase, spec)
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonic() returned error: %v", err)
+	}
+	s, err := getSingleSeedJson(path.String(), sleeve)
+	if err != nil {
+		t.Fatalf("getSingleSeedJson() returned error: %v", err)
+	}
+	return s
+}
+
+func TestParseSleeveJson_RoundTrip(t *testing.T) {
+	s := singleSeedJson(t)
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("json.Marshal() returned error: %v", err)
+	}
+	parsed, err := ParseSleeveJson(data)
+	if err != nil {
+		t.Fatalf("ParseSleeveJson() returned error: %v", err)
+	}
+	if err := verifySleeveJson(parsed); err != nil {
+		t.Fatalf("verifySleeveJson() returned error for getSingleSeedJson() output: %v", err)
+	}
+
+	// A wallet recovered from another phrase doesn't match
+	other := parsed
+	other.Pass = "other"
+	if err := verifySleeveJson(other); err == nil {
+		t.Fatalf("verifySleeveJson() should return error for a wrong passphrase")
+	}
+}
+
+func TestParseSleeveJson_Malformed(t *testing.T) {
+	data, _ := json.Marshal(singleSeedJson(t))
+	if _, err := ParseSleeveJson(data[:len(data)/2]); !errors.Is(err, ErrMalformedSleeveJson) {
+		t.Fatalf("ParseSleeveJson() should return ErrMalformedSleeveJson for truncated JSON, got %v", err)
+	}
+	if _, err := ParseSleeveJsonList(data); !errors.Is(err, ErrMalformedSleeveJson) {
+		t.Fatalf("ParseSleeveJsonList() should return ErrMalformedSleeveJson for an object, got %v", err)
+	}
+}
+
+func TestParseSleeveJson_Invalid(t *testing.T) {
+	s := singleSeedJson(t)
+	invalid := map[string]func(s *SleeveJson){
+		"bad WOTS+ public key hex": func(s *SleeveJson) { s.WOTSPublicKey = "zz" + s.WOTSPublicKey[2:] },
+		"wrong public key length":  func(s *SleeveJson) { s.WOTSPublicKey = s.WOTSPublicKey[2:] },
+		"missing WOTSIndex":        func(s *SleeveJson) { s.WOTSIndex = nil },
+		"coin type of 2^31":        func(s *SleeveJson) { s.NetworkKeys[0].CoinType = 1 << 31 },
+		"curve mismatch":           func(s *SleeveJson) { s.NetworkKeys[0].Curve = "sr25519" },
+	}
+	for name, modify := range invalid {
+		modified := s
+		modified.NetworkKeys = append([]NetworkKeyInfo(nil), s.NetworkKeys...)
+		modify(&modified)
+		data, _ := json.Marshal(modified)
+		if _, err := ParseSleeveJson(data); !errors.Is(err, ErrInvalidSleeveJson) {
+			t.Fatalf("ParseSleeveJson() should return ErrInvalidSleeveJson for %s, got %v", name, err)
+		}
+	}
+
+	// The original still parses
+	data, _ := json.Marshal(s)
+	if _, err := ParseSleeveJson(data); err != nil {
+		t.Fatalf("ParseSleeveJson() returned error: %v", err)
+	}
+}
+
+func TestSleeveJson_String_MissingWOTSIndex(t *testing.T) {
+	s := singleSeedJson(t)
+	s.WOTSIndex = nil
+	str := s.String()
+	if strings.Contains(str, "WOTS-derived index") || !strings.Contains(str, s.WOTSPublicKey) {
+		t.Fatalf("String() returned unexpected output without a WOTSIndex:\n%s", str)
+	}
+	if !strings.Contains(singleSeedJson(t).String(), "WOTS-derived index") {
+		t.Fatalf("String() should print the WOTS-derived index")
+	}
+}
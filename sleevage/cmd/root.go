@@ -34,6 +34,9 @@ var outputFile string
 var outputType string
 var testnet bool
 
+// Verification flags
+var verifyFile string
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "sleevage",
@@ -50,6 +53,12 @@ standard recovery phrase and respective address.
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	Run: func(cmd *cobra.Command, args []string) {
+		if verifyFile != "" {
+			if err := verifySleeveFile(verifyFile); err != nil {
+				fmt.Printf("Error verifying Sleeve wallets: %s\n", err.Error())
+			}
+			return
+		}
 		if !checkArgs() {
 			return
 		}
@@ -91,6 +100,9 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&outputFile, "output","o", "", "output file. Defaults to stdout. When specified, only address is shown on stdout")
 	rootCmd.PersistentFlags().StringVarP(&outputType, "output-type","t", "text", "output type. One of [text, json]")
 	rootCmd.PersistentFlags().BoolVar(&testnet, "testnet",  false, "generate testnet address")
+
+	// Verification flags
+	rootCmd.PersistentFlags().StringVar(&verifyFile, "verify", "", "verify a file written with --output-type json, recovering each wallet from its phrases")
 }
 
 func checkArgs() bool {
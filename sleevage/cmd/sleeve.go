@@ -8,9 +8,9 @@ package cmd
 
 import (
 	"crypto/rand"
-	"encoding/hex"
 	"errors"
 	"fmt"
+	"os"
 	"github.com/xx-labs/sleeve/wallet"
 	"github.com/xx-labs/sleeve/wots"
 )
@@ -33,7 +33,8 @@ type SleeveJson struct {
 	StandardDeriv []StandardDerivation `json:"StandardDerivations,omitempty"`
 	// Single-seed specific fields
 	SingleSeed    bool                 `json:"SingleSeed,omitempty"`
-	WOTSIndex     uint32               `json:"WOTSIndex,omitempty"`
+	WOTSIndex     *uint32              `json:"WOTSIndex,omitempty"` // Pointer, since 0 is a valid index
+	WOTSParams    string               `json:"WOTSParams,omitempty"`
 	WOTSPublicKey string               `json:"WOTSPublicKey,omitempty"`
 	NetworkKeys   []NetworkKeyInfo     `json:"NetworkKeys,omitempty"`
 }
@@ -41,6 +42,7 @@ type SleeveJson struct {
 type NetworkKeyInfo struct {
 	Network  string `json:"Network"`
 	CoinType uint32 `json:"CoinType"`
+	Curve    string `json:"Curve"`
 	Path     string `json:"Path"`
 	Address  string `json:"Address,omitempty"` // For display purposes
 }
@@ -53,13 +55,16 @@ func (s SleeveJson) String() string {
 	if s.SingleSeed {
 		// Single-seed mode output
 		str += fmt.Sprintf("generation mode: SINGLE-SEED\n")
+		str += fmt.Sprintf("WOTS+ params: %s\n", s.WOTSParams)
 		str += fmt.Sprintf("WOTS+ public key: %s\n", s.WOTSPublicKey)
-		str += fmt.Sprintf("WOTS-derived index: %d\n", s.WOTSIndex)
+		if s.WOTSIndex != nil {
+			str += fmt.Sprintf("WOTS-derived index: %d\n", *s.WOTSIndex)
+		}
 		str += fmt.Sprintf("address (xx network): %s\n", s.Address)
 		if len(s.NetworkKeys) > 0 {
 			str += fmt.Sprintf("\nderived network keys:\n")
 			for _, nk := range s.NetworkKeys {
-				str += fmt.Sprintf("  %s (coin %d): %s\n", nk.Network, nk.CoinType, nk.Path)
+				str += fmt.Sprintf("  %s (coin %d, %s): %s\n", nk.Network, nk.CoinType, nk.Curve, nk.Path)
 				if nk.Address != "" {
 					str += fmt.Sprintf("    address: %s\n", nk.Address)
 				}
@@ -168,8 +173,7 @@ func getSingleSeedSleeve(args args) (SleeveJson, error) {
 			return SleeveJson{}, err
 		}
 	}
-	json := getSingleSeedJson(args.path, sleeve)
-	return json, nil
+	return getSingleSeedJson(args.path, sleeve)
 }
 
 func getAddress(sleeve *wallet.Sleeve) string {
@@ -212,28 +216,33 @@ func getJson(path string, sleeve *wallet.Sleeve) SleeveJson {
 	}
 }
 
-func getSingleSeedJson(path string, sleeve *wallet.SingleSeedSleeve) SleeveJson {
-	// Get all network keys
-	networkKeys := sleeve.GetAllNetworkKeys()
-	
+func getSingleSeedJson(path string, sleeve *wallet.SingleSeedSleeve) (SleeveJson, error) {
+	// Get the wallet descriptor, with networks sorted by name
+	desc, err := sleeve.ExportDescriptor()
+	if err != nil {
+		return SleeveJson{}, err
+	}
+
 	// Build network key info array
 	var netKeyInfos []NetworkKeyInfo
-	for _, nk := range networkKeys {
+	for _, nk := range desc.Networks {
 		netKeyInfos = append(netKeyInfos, NetworkKeyInfo{
 			Network:  nk.Network,
 			CoinType: nk.CoinType,
+			Curve:    nk.Curve,
 			Path:     nk.Path,
-			// Address calculation could be added here if needed
+			Address:  nk.Address,
 		})
 	}
 
 	// Get WOTS public key hex
-	wotsPKHex := hex.EncodeToString(sleeve.GetWOTSPublicKey())
+	wotsPKHex := sleeve.GetWOTSPublicKeyHex()
 
 	// Create address from WOTS public key (using the same method as legacy)
 	// Note: For single-seed, the "address" is based on WOTS PK, not output mnemonic
 	address := fmt.Sprintf("WOTS+:%s", wotsPKHex[:16]) // Shortened for display
 
+	index := sleeve.GetDerivationIndex()
 	return SleeveJson{
 		Quantum:       sleeve.GetMnemonic(),
 		Pass:          passphrase,
@@ -242,10 +251,11 @@ func getSingleSeedJson(path string, sleeve *wallet.SingleSeedSleeve) SleeveJson
 		Address:       address,
 		StandardDeriv: nil,
 		SingleSeed:    true,
-		WOTSIndex:     sleeve.GetDerivationIndex(),
+		WOTSIndex:     &index,
+		WOTSParams:    sleeve.GetWOTSParams().String(),
 		WOTSPublicKey: wotsPKHex,
 		NetworkKeys:   netKeyInfos,
-	}
+	}, nil
 }
 
 func sleeve() ([]SleeveJson, error) {
@@ -256,7 +266,8 @@ func sleeve() ([]SleeveJson, error) {
 	}
 
 	// Sleeve generation
-	wallets := make([]SleeveJson, numWallets*numAccounts)
+	total := numWallets * numAccounts
+	wallets := make([]SleeveJson, total)
 	// Keep start account
 	startAccount := account
 	for i := uint32(0); i < numWallets; i++ {
@@ -277,9 +288,21 @@ func sleeve() ([]SleeveJson, error) {
 			if j == 0 {
 				quantumPhrase = wallets[i*numAccounts+j].Quantum
 			}
+			showProgress(i*numAccounts+j+1, total)
 		}
 		// Reset quantum phrase to generate new wallet on next iteration
 		quantumPhrase = ""
 	}
 	return wallets, nil
 }
+
+// Show generation progress on stderr for batch runs, keeping stdout for the output
+func showProgress(done, total uint32) {
+	if total < 2 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\rGenerating wallets: %d/%d", done, total)
+	if done == total {
+		fmt.Fprintln(os.Stderr)
+	}
+}
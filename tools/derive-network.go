@@ -6,7 +6,9 @@
 //
 // Usage:
 //   go run tools/derive-network.go -mnemonic "your 24 words..." -network "Solana" -cointype 501
-//   go run tools/derive-network.go -mnemonic "your 24 words..." -network "Litecoin" -cointype 2
+//   go run tools/derive-network.go -mnemonic "your 24 words..." -network "Litecoin" -cointype litecoin
+//   go run tools/derive-network.go -mnemonic "your 24 words..." -network "Ethereum" -cointype 60 -account 1
+//   go run tools/derive-network.go -mnemonic "your 24 words..." -network "Ethereum" -cointype 60 -scheme LedgerLive
 //   go run tools/derive-network.go -help
 //
 ////////////////////////////////////////////////////////////////////////////////////////////
@@ -20,9 +22,9 @@ import (
 	"os"
 	"strings"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/tyler-smith/go-bip39"
 	"github.com/xx-labs/sleeve/wallet"
+	"github.com/xx-labs/sleeve/wots"
 )
 
 // Network formats we can export
@@ -33,15 +35,22 @@ type NetworkFormats struct {
 	PrivateKeyHex string
 	WIF           string // Bitcoin-style Wallet Import Format
 	EthAddress    string // Ethereum address (derived from public key)
-	PublicKeyHex  string // Compressed public key
+	Address       string // Native address, for networks supported by the wallet library
+	SegWitAddress string // Native SegWit (bech32) address, for Bitcoin and Litecoin
+	PublicKeyHex  string // Public key, compressed for secp256k1
 }
 
 func main() {
 	// Parse command-line flags
-	mnemonicFlag := flag.String("mnemonic", "", "24-word mnemonic phrase (required)")
+	mnemonicFlag := flag.String("mnemonic", "", "BIP39 mnemonic phrase, 12 to 24 words (required)")
 	passphraseFlag := flag.String("passphrase", "", "Optional passphrase (default: empty)")
 	networkFlag := flag.String("network", "", "Network name (e.g., 'Solana', 'Litecoin')")
-	coinTypeFlag := flag.Uint("cointype", 0, "BIP44 coin type number")
+	coinTypeFlag := flag.String("cointype", "0", "BIP44 coin type number or network name (e.g., 501 or 'Solana')")
+	accountFlag := flag.Uint("account", 0, "Account of the WOTS+ key (default: 0)")
+	indexFlag := flag.Uint("index", 0, "WOTS-derived index, only used with -preview")
+	addressesFlag := flag.Uint("addresses", 1, "Number of receive addresses to list, from address index 0 (default: 1)")
+	schemeFlag := flag.String("scheme", "BIP44", "Path layout: BIP44 (Sleeve path), LedgerLive or MetaMask")
+	previewFlag := flag.Bool("preview", false, "Show the derivation path and curve without a mnemonic (no key material)")
 	listFlag := flag.Bool("list", false, "List common network coin types")
 	helpFlag := flag.Bool("help", false, "Show help message")
 
@@ -59,6 +68,34 @@ func main() {
 		return
 	}
 
+	// Parse the coin type, which must fit in a hardened path element
+	coinType, err := wallet.ParseCoinType(*coinTypeFlag)
+	if err != nil {
+		fmt.Printf("Error: Invalid coin type: %v\n", err)
+		fmt.Println("Use -list for known network names")
+		os.Exit(1)
+	}
+
+	// Parse the derivation scheme, to match the wallet the key is imported into
+	scheme, err := wallet.ParseDerivationScheme(*schemeFlag)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Preview derivation path, without touching any secrets
+	if *previewFlag {
+		if *accountFlag >= 1<<31 || *indexFlag >= 1<<31 {
+			fmt.Println("Error: Account and index must be less than 2^31")
+			os.Exit(1)
+		}
+		account := wallet.NewGenSpec(uint32(*accountFlag), wots.DefaultParams).NetworkAccount()
+		path, curve := wallet.PreviewDerivation(uint32(coinType), account, 0, uint32(*indexFlag))
+		fmt.Printf("Path:  %s\n", path)
+		fmt.Printf("Curve: %s\n", curve)
+		return
+	}
+
 	// Validate required flags
 	if *mnemonicFlag == "" {
 		fmt.Println("Error: -mnemonic flag is required")
@@ -72,10 +109,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Validate mnemonic
+	// Validate mnemonic, accepting any BIP39 length
 	words := strings.Fields(*mnemonicFlag)
-	if len(words) != 24 {
-		fmt.Printf("Error: Mnemonic must be exactly 24 words (got %d)\n", len(words))
+	if n := len(words); n < 12 || n > 24 || n%3 != 0 {
+		fmt.Printf("Error: Mnemonic must be 12, 15, 18, 21 or 24 words (got %d)\n", n)
 		os.Exit(1)
 	}
 
@@ -84,6 +121,27 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Validate the number of addresses to list
+	if *addressesFlag < 1 || *addressesFlag > maxAddresses {
+		fmt.Printf("Error: -addresses must be between 1 and %d (got %d)\n", maxAddresses, *addressesFlag)
+		os.Exit(1)
+	}
+	if *addressesFlag > 1 && scheme != wallet.SchemeBIP44 {
+		fmt.Println("Error: -addresses is only supported with the BIP44 scheme")
+		os.Exit(1)
+	}
+
+	// Validate account, which must fit in a hardened path element
+	if *accountFlag >= 1<<31 {
+		fmt.Printf("Error: Account must be less than 2^31 (got %d)\n", *accountFlag)
+		os.Exit(1)
+	}
+	spec := wallet.NewGenSpec(uint32(*accountFlag), wots.DefaultParams)
+	if _, err := spec.PathFromSpec(); err != nil {
+		fmt.Printf("Error: Invalid account %d: %v\n", *accountFlag, err)
+		os.Exit(1)
+	}
+
 	// Create or recover Sleeve wallet
 	fmt.Println("╔════════════════════════════════════════════════════════════════╗")
 	fmt.Println("║        Sleeve Network Key Derivation Tool                     ║")
@@ -92,7 +150,7 @@ func main() {
 	fmt.Println("Deriving keys from mnemonic...")
 	fmt.Println()
 
-	sleeve, err := wallet.NewSingleSeedSleeveFromMnemonic(*mnemonicFlag, *passphraseFlag, wallet.DefaultGenSpec())
+	sleeve, err := wallet.NewSingleSeedSleeveFromMnemonic(*mnemonicFlag, *passphraseFlag, spec)
 	if err != nil {
 		fmt.Printf("Error creating wallet: %v\n", err)
 		os.Exit(1)
@@ -105,24 +163,77 @@ func main() {
 		os.Exit(1)
 	}
 
-	err = sleeve.DeriveNetworkKey(*networkFlag, uint32(*coinTypeFlag), seed)
-	if err != nil {
-		fmt.Printf("Error deriving network key: %v\n", err)
-		os.Exit(1)
+	// Keys of other schemes are stored as "network@scheme", with "/account" for accounts
+	// other than the one of the sleeve's network keys
+	name := *networkFlag
+	var privateKey []byte
+	if scheme == wallet.SchemeBIP44 {
+		privateKey, err = sleeve.DeriveAndGetKey(name, uint32(coinType), seed)
+	} else {
+		opts := wallet.NetworkKeyOptions{Scheme: scheme}
+		err = sleeve.DeriveNetworkKeyAtWithOptions(name, uint32(coinType), uint32(*accountFlag), opts, seed)
+		if err == nil {
+			name = fmt.Sprintf("%s@%s", name, scheme)
+			if uint32(*accountFlag) != spec.NetworkAccount() {
+				name = fmt.Sprintf("%s/%d", name, *accountFlag)
+			}
+			privateKey, err = sleeve.GetPrivateKey(name)
+		}
 	}
-
-	// Get the private key
-	privateKey, err := sleeve.GetPrivateKey(*networkFlag)
 	if err != nil {
-		fmt.Printf("Error retrieving private key: %v\n", err)
+		fmt.Printf("Error deriving network key: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Format the output
-	formats := formatNetworkKey(*networkFlag, uint32(*coinTypeFlag), sleeve, privateKey)
+	formats := formatNetworkKey(name, uint32(coinType), sleeve, privateKey)
 
 	// Display results
 	printNetworkKey(formats)
+
+	// List more receive addresses, the first one being the key above
+	if *addressesFlag > 1 {
+		if err := printAddresses(sleeve, *networkFlag, uint32(coinType), uint32(*addressesFlag), seed); err != nil {
+			fmt.Printf("Error deriving addresses: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// Maximum number of addresses listed with -addresses
+const maxAddresses = 1000
+
+// Derive and print the first count receive addresses of a network, one "index path address" line each
+// Address index i is at the WOTS-derived index plus i. Networks without an address
+// format are listed with their hex encoded public key instead
+func printAddresses(sleeve *wallet.SingleSeedSleeve, network string, coinType, count uint32, seed []byte) error {
+	fmt.Printf("📒 FIRST %d ADDRESSES\n", count)
+	fmt.Println("────────────────────────────────────────────────────────────────")
+	for i := uint32(0); i < count; i++ {
+		netKey, err := sleeve.DeriveAddressKey(network, coinType, i, false, seed)
+		if err != nil {
+			return err
+		}
+		// Address keys other than index 0 are stored as "network#index"
+		name := network
+		if i > 0 {
+			name = fmt.Sprintf("%s#%d", network, i)
+		}
+		addr, err := sleeve.GetAddress(name)
+		if err != nil {
+			return err
+		}
+		if addr == "" {
+			pub, err := sleeve.GetPublicKey(name)
+			if err != nil {
+				return err
+			}
+			addr = "public key " + hex.EncodeToString(pub)
+		}
+		fmt.Printf("%4d  %s  %s\n", i, netKey.Path, addr)
+	}
+	fmt.Println()
+	return nil
 }
 
 func formatNetworkKey(network string, coinType uint32, sleeve *wallet.SingleSeedSleeve, privateKey []byte) NetworkFormats {
@@ -134,23 +245,34 @@ func formatNetworkKey(network string, coinType uint32, sleeve *wallet.SingleSeed
 
 	// Get network info
 	allKeys := sleeve.GetAllNetworkKeys()
-	if netKey, exists := allKeys[network]; exists {
+	netKey, exists := allKeys[network]
+	if exists {
 		formats.Path = netKey.Path
 	}
+	if addr, err := sleeve.GetAddress(network); err == nil {
+		formats.Address = addr
+	}
+
+	// Public key on the network's curve, compressed (33 bytes) for secp256k1 chains
+	if pubKey, err := sleeve.GetPublicKey(network); err == nil {
+		formats.PublicKeyHex = hex.EncodeToString(pubKey)
+	}
 
-	// Derive public key (works for all ECDSA-based chains)
-	privKey, err := crypto.ToECDSA(privateKey)
-	if err == nil {
-		// Compressed public key (33 bytes) - standard for Bitcoin, etc.
-		compressedPubKey := crypto.CompressPubkey(&privKey.PublicKey)
-		formats.PublicKeyHex = hex.EncodeToString(compressedPubKey)
+	// Ethereum address (useful for ETH and EVM chains), only for secp256k1 chains
+	if exists && netKey.Curve == wallet.CurveSecp256k1 {
+		if ethAddr, err := wallet.EVMAddressFromPrivateKey(privateKey); err == nil {
+			formats.EthAddress = ethAddr
+		}
+	}
 
-		// Ethereum address (useful for ETH and EVM chains)
-		ethAddr := crypto.PubkeyToAddress(privKey.PublicKey)
-		formats.EthAddress = ethAddr.Hex()
+	// Bitcoin WIF format, only for Bitcoin family chains
+	if wif, err := sleeve.GetWIF(network); err == nil {
+		formats.WIF = wif
+	}
 
-		// Bitcoin WIF format (useful for Bitcoin-like chains)
-		formats.WIF = privateKeyToWIF(privateKey, coinType)
+	// Native SegWit address, only for Bitcoin family chains with SegWit
+	if segwit, err := sleeve.GetSegWitAddress(network, ""); err == nil {
+		formats.SegWitAddress = segwit
 	}
 
 	return formats
@@ -172,15 +294,40 @@ func printNetworkKey(f NetworkFormats) {
 	fmt.Println()
 
 	if f.PublicKeyHex != "" {
-		fmt.Println("🔑 PUBLIC KEY (Compressed)")
+		fmt.Println("🔑 PUBLIC KEY")
 		fmt.Println("────────────────────────────────────────────────────────────────")
 		fmt.Println(f.PublicKeyHex)
 		fmt.Println()
 	}
 
+	// Native address, Ethereum and Ethereum Classic are shown with their import steps below
+	isEthereum := f.CoinType == wallet.CoinTypeEthereum || f.CoinType == wallet.CoinTypeEthereumClassic
+	if f.Address != "" && !isEthereum {
+		fmt.Println("📬 ADDRESS")
+		fmt.Println("────────────────────────────────────────────────────────────────")
+		fmt.Println(f.Address)
+		fmt.Println()
+	}
+
+	if f.SegWitAddress != "" {
+		fmt.Println("📬 NATIVE SEGWIT ADDRESS (BECH32)")
+		fmt.Println("────────────────────────────────────────────────────────────────")
+		fmt.Println(f.SegWitAddress)
+		fmt.Println()
+	}
+
 	// Chain-specific formats
-	if f.WIF != "" && (f.CoinType == 0 || f.CoinType == 2 || f.CoinType == 3) {
-		// Bitcoin, Litecoin, Dogecoin
+	if f.WIF != "" && f.CoinType == wallet.CoinTypeEOS {
+		// EOS legacy private key
+		fmt.Println("💰 WALLET IMPORT FORMAT (WIF)")
+		fmt.Println("────────────────────────────────────────────────────────────────")
+		fmt.Println(f.WIF)
+		fmt.Println()
+		fmt.Println("Import to: Anchor, cleos and other EOS wallets")
+		fmt.Println("Command:   cleos wallet import --private-key " + f.WIF)
+		fmt.Println()
+	} else if f.WIF != "" {
+		// Bitcoin, Litecoin, Dogecoin, Dash
 		fmt.Println("💰 WALLET IMPORT FORMAT (WIF)")
 		fmt.Println("────────────────────────────────────────────────────────────────")
 		fmt.Println(f.WIF)
@@ -190,12 +337,15 @@ func printNetworkKey(f NetworkFormats) {
 		fmt.Println()
 	}
 
-	if f.EthAddress != "" && (f.CoinType == 60 || f.CoinType == 61) {
+	if f.EthAddress != "" && isEthereum {
 		// Ethereum, Ethereum Classic
 		fmt.Println("🦊 ETHEREUM ADDRESS")
 		fmt.Println("────────────────────────────────────────────────────────────────")
 		fmt.Println(f.EthAddress)
 		fmt.Println()
+		if chainID, ok := wallet.EVMChainID(f.CoinType); ok {
+			fmt.Printf("Chain ID:  %d (transactions must be signed for this chain)\n", chainID)
+		}
 		fmt.Println("Import to: MetaMask, MyEtherWallet, MyCrypto")
 		fmt.Println("Steps:")
 		fmt.Println("  1. Open MetaMask")
@@ -210,23 +360,40 @@ func printNetworkKey(f NetworkFormats) {
 	fmt.Println("────────────────────────────────────────────────────────────────")
 
 	switch f.CoinType {
-	case 354: // Polkadot
+	case wallet.CoinTypePolkadot:
 		fmt.Println("Polkadot/Substrate chains:")
 		fmt.Println("  • Use Polkadot.js extension")
 		fmt.Println("  • Import → Private Key → Paste hex above")
 		fmt.Println("  • Note: May need to convert to SS58 format")
-	case 501: // Solana
+	case wallet.CoinTypeSolana:
 		fmt.Println("Solana:")
 		fmt.Println("  • Use Phantom wallet or Solflare")
 		fmt.Println("  • Import → Private Key")
 		fmt.Println("  • Note: Some wallets expect base58 encoding")
 		fmt.Println("  • Command: solana-keygen recover 'prompt:?key=0/' --outfile wallet.json")
-	case 118: // Cosmos
+	case wallet.CoinTypeTezos:
+		fmt.Println("Tezos:")
+		fmt.Println("  • The tz1 address above is for this ed25519 key")
+		fmt.Println("  • Import the hex private key as an ed25519 seed, e.g., with Temple")
+		fmt.Println("  • Note: the key is derived with SLIP-0010 like Tezos wallets, but at the sleeve's path,")
+		fmt.Println("    not m/44'/1729'/0'/0', so import the key, not the mnemonic")
+	case wallet.CoinTypeNear:
+		fmt.Println("Near:")
+		fmt.Println("  • The address above is the implicit account of this ed25519 key")
+		fmt.Println("  • Fund it to create the account, then import the key into a Near wallet")
+		fmt.Println("  • Note: the key is derived with SLIP-0010 like Near wallets, but at the sleeve's path,")
+		fmt.Println("    not m/44'/397'/0', so import the key, not the mnemonic")
+	case wallet.CoinTypeZcash:
+		fmt.Println("Zcash:")
+		fmt.Println("  • The t1 address above is a transparent address for this key")
+		fmt.Println("  • Import the private key with zcash-cli importprivkey, after converting it to WIF")
+		fmt.Println("  • Note: shielded (z-addr) addresses aren't derived by this tool")
+	case wallet.CoinTypeCosmos:
 		fmt.Println("Cosmos:")
 		fmt.Println("  • Use Keplr wallet")
 		fmt.Println("  • Import → Private Key")
 		fmt.Println("  • Paste hex private key")
-	case 1815: // Cardano
+	case wallet.CoinTypeCardano:
 		fmt.Println("Cardano:")
 		fmt.Println("  • Use Daedalus or Yoroi")
 		fmt.Println("  • Note: Cardano uses different derivation, may need conversion")
@@ -245,42 +412,6 @@ func printNetworkKey(f NetworkFormats) {
 	fmt.Println()
 }
 
-func privateKeyToWIF(privateKey []byte, coinType uint32) string {
-	// Simple WIF encoding for Bitcoin-like chains
-	// This is a basic implementation - production use should use btcutil
-
-	// Version byte depends on network
-	version := byte(0x80) // Bitcoin mainnet
-	switch coinType {
-	case 0: // Bitcoin
-		version = 0x80
-	case 2: // Litecoin
-		version = 0xB0
-	case 3: // Dogecoin
-		version = 0x9E
-	default:
-		return "" // Not a Bitcoin-like chain
-	}
-
-	// Build extended key: version + private key + compressed flag
-	extended := make([]byte, 34)
-	extended[0] = version
-	copy(extended[1:33], privateKey)
-	extended[33] = 0x01 // Compressed public key flag
-
-	// Double SHA256 for checksum
-	hash1 := crypto.Keccak256(extended)
-	hash2 := crypto.Keccak256(hash1)
-	checksum := hash2[:4]
-
-	// Append checksum
-	wifData := append(extended, checksum...)
-
-	// Base58 encode (simplified - production should use btcutil)
-	// For now, return hex with note
-	return fmt.Sprintf("(Use btcutil for proper WIF: %x)", wifData)
-}
-
 func printHelp() {
 	fmt.Println("Sleeve Network Key Derivation Tool")
 	fmt.Println("===================================")
@@ -292,13 +423,27 @@ func printHelp() {
 	fmt.Println()
 	fmt.Println("FLAGS:")
 	fmt.Println("  -mnemonic string")
-	fmt.Println("        Your 24-word mnemonic phrase (required)")
+	fmt.Println("        Your BIP39 mnemonic phrase, 12 to 24 words (required)")
 	fmt.Println("  -network string")
 	fmt.Println("        Network name, e.g., 'Solana', 'Litecoin' (required)")
-	fmt.Println("  -cointype uint")
-	fmt.Println("        BIP44 coin type number (required)")
+	fmt.Println("  -cointype string")
+	fmt.Println("        BIP44 coin type number, or a network name listed by -list (required)")
+	fmt.Println("  -account uint")
+	fmt.Println("        Account of the WOTS+ key, must be less than 2^31 (default: 0)")
+	fmt.Println("        BIP44 network keys stay under account 0', as in wallets of any account,")
+	fmt.Println("        while keys of other schemes are derived under this account")
 	fmt.Println("  -passphrase string")
 	fmt.Println("        Optional BIP39 passphrase (default: empty)")
+	fmt.Println("  -preview")
+	fmt.Println("        Show the derivation path and curve only, no mnemonic needed")
+	fmt.Println("  -index uint")
+	fmt.Println("        WOTS-derived index to use with -preview (default: 0)")
+	fmt.Println("  -addresses uint")
+	fmt.Println("        Number of receive addresses to list, with their index and path (default: 1)")
+	fmt.Println("  -scheme string")
+	fmt.Println("        Path layout: BIP44 (Sleeve path), LedgerLive or MetaMask (default: BIP44)")
+	fmt.Println("        LedgerLive and MetaMask keys use the fixed paths of those wallets, and aren't")
+	fmt.Println("        bound to the WOTS+ key; -addresses is only supported with BIP44")
 	fmt.Println("  -list")
 	fmt.Println("        List common network coin types")
 	fmt.Println("  -help")
@@ -315,7 +460,27 @@ func printHelp() {
 	fmt.Println("  go run tools/derive-network.go \\")
 	fmt.Println("    -mnemonic \"word1 word2 ... word24\" \\")
 	fmt.Println("    -network \"Litecoin\" \\")
-	fmt.Println("    -cointype 2")
+	fmt.Println("    -cointype litecoin")
+	fmt.Println()
+	fmt.Println("  # Derive Ethereum key for account 1")
+	fmt.Println("  go run tools/derive-network.go \\")
+	fmt.Println("    -mnemonic \"word1 word2 ... word24\" \\")
+	fmt.Println("    -network \"Ethereum\" \\")
+	fmt.Println("    -cointype 60 \\")
+	fmt.Println("    -account 1")
+	fmt.Println()
+	fmt.Println("  # Derive the Ethereum key of Ledger Live account 1, to import into Ledger Live")
+	fmt.Println("  go run tools/derive-network.go \\")
+	fmt.Println("    -mnemonic \"word1 word2 ... word24\" \\")
+	fmt.Println("    -network \"Ethereum\" -cointype 60 -scheme LedgerLive -account 1")
+	fmt.Println()
+	fmt.Println("  # List the first 5 Dogecoin receive addresses")
+	fmt.Println("  go run tools/derive-network.go \\")
+	fmt.Println("    -mnemonic \"word1 word2 ... word24\" \\")
+	fmt.Println("    -network \"Dogecoin\" -cointype dogecoin -addresses 5")
+	fmt.Println()
+	fmt.Println("  # Preview the path for Solana account 0 and WOTS index 12345")
+	fmt.Println("  go run tools/derive-network.go -preview -cointype 501 -index 12345")
 	fmt.Println()
 	fmt.Println("  # List common networks")
 	fmt.Println("  go run tools/derive-network.go -list")
@@ -358,6 +523,7 @@ func listCommonNetworks() {
 	fmt.Println("  • Cosmos        118")
 	fmt.Println("  • Cardano       1815")
 	fmt.Println("  • Tezos         1729")
+	fmt.Println("  • Near          397")
 	fmt.Println()
 	fmt.Println("Privacy Coins:")
 	fmt.Println("  • Monero        128")
@@ -375,6 +541,11 @@ func listCommonNetworks() {
 	fmt.Println("    -network \"Solana\" \\")
 	fmt.Println("    -cointype 501")
 	fmt.Println()
+	fmt.Println("Names accepted by -cointype:")
+	for _, coinType := range wallet.CoinTypes() {
+		fmt.Printf("  • %-13s %d\n", coinType, uint32(coinType))
+	}
+	fmt.Println()
 	fmt.Println("For complete list: https://github.com/satoshilabs/slips/blob/master/slip-0044.md")
 }
 
@@ -7,13 +7,21 @@
 package main
 
 import (
+	"bytes"
 	"crypto/rand"
+	"encoding/binary"
+	"encoding/csv"
 	"encoding/hex"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/xx-labs/sleeve/hasher"
 	"github.com/xx-labs/sleeve/wallet"
 	"github.com/xx-labs/sleeve/wots"
 )
@@ -31,7 +39,21 @@ type Config struct {
 	Passphrase string // Optional BIP39 passphrase
 	Account    uint32 // Account number
 	Security   string // WOTS+ security level
+	Bits       int    // Target classical security bits, overrides Security if set
 	Export     bool   // Export private keys
+	ExportFile bool   // Write private keys to the Out file, only when -export is passed explicitly
+	Out        string // File to write derived networks to (.json or .csv)
+	Force      bool   // Overwrite output file if it exists
+	Verify     string // Descriptor file to verify the recovered wallet against
+	SelfTest   bool   // Check the library against the embedded test vectors, then exit
+}
+
+// Wallet descriptor written to a JSON output file
+// Private keys are keyed by the name each key is stored under, e.g., "Ethereum#3" or
+// "Ethereum/1", and only included when -export is passed explicitly
+type DescriptorFile struct {
+	wallet.WalletDescriptor
+	PrivateKeys map[string]string `json:"PrivateKeys,omitempty"`
 }
 
 func main() {
@@ -41,6 +63,14 @@ func main() {
 	// Display banner
 	fmt.Print(banner)
 
+	// Check the derivation before trusting this binary with a real wallet
+	if cfg.SelfTest {
+		if !runSelfTest() {
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Generate or recover wallet
 	if cfg.Mnemonic == "" {
 		fmt.Println("🔐 Generating NEW wallet...")
@@ -48,6 +78,16 @@ func main() {
 	} else {
 		fmt.Println("🔄 Recovering wallet from mnemonic...")
 		fmt.Println()
+
+		// Validate mnemonic, accepting any BIP39 length
+		if n := len(strings.Fields(cfg.Mnemonic)); n < 12 || n > 24 || n%3 != 0 {
+			fmt.Printf("❌ Error: Mnemonic must be 12, 15, 18, 21 or 24 words (got %d)\n", n)
+			os.Exit(1)
+		}
+		if !bip39.IsMnemonicValid(cfg.Mnemonic) {
+			fmt.Println("❌ Error: Invalid mnemonic (checksum failed)")
+			os.Exit(1)
+		}
 	}
 
 	// Generate based on mode
@@ -64,7 +104,12 @@ func parseFlags() Config {
 	passphrase := flag.String("passphrase", "", "BIP39 passphrase (optional)")
 	account := flag.Uint("account", 0, "Account number")
 	security := flag.String("security", "level0", "WOTS+ security: level0-3")
+	bits := flag.Int("bits", 0, "Target classical security bits (e.g. 128, 192), picks the smallest WOTS+ level that reaches it. Overrides -security")
 	export := flag.Bool("export", true, "Export private keys for other chains")
+	out := flag.String("out", "", "Write derived networks to a .json or .csv file (single mode only), with private keys only if -export is passed explicitly")
+	force := flag.Bool("force", false, "Overwrite the -out file if it already exists")
+	verify := flag.String("verify-descriptor", "", "Verify the recovered wallet against a .json descriptor written with -out")
+	selfTest := flag.Bool("selftest", false, "Check the derivation against the embedded test vectors, print PASS/FAIL and exit")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Sleeve Wallet Generator\n\n")
@@ -81,23 +126,47 @@ func parseFlags() Config {
 		fmt.Fprintf(os.Stderr, "  %s -mode single -mnemonic \"your 24 words\"\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  # With passphrase:\n")
 		fmt.Fprintf(os.Stderr, "  %s -mode single -passphrase \"secret\"\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # Pick WOTS+ level for 192 bits of classical security:\n")
+		fmt.Fprintf(os.Stderr, "  %s -mode single -bits 192\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # Write derived networks to a file:\n")
+		fmt.Fprintf(os.Stderr, "  %s -mode single -out networks.json\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # Write derived networks with their private keys to a file:\n")
+		fmt.Fprintf(os.Stderr, "  %s -mode single -out networks.json -export\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # Verify a recovered wallet against a descriptor:\n")
+		fmt.Fprintf(os.Stderr, "  %s -mode single -mnemonic \"your 24 words\" -verify-descriptor networks.json\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # Check this binary derives wallets correctly, before generating one:\n")
+		fmt.Fprintf(os.Stderr, "  %s -selftest\n\n", os.Args[0])
 	}
 
 	flag.Parse()
 
+	// -export defaults to true for the screen, but private keys are only written to a file on request
+	exportFile := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "export" {
+			exportFile = *export
+		}
+	})
+
 	return Config{
 		Mode:       *mode,
 		Mnemonic:   *mnemonic,
 		Passphrase: *passphrase,
 		Account:    uint32(*account),
 		Security:   *security,
+		Bits:       *bits,
 		Export:     *export,
+		ExportFile: exportFile,
+		Out:        *out,
+		Force:      *force,
+		Verify:     *verify,
+		SelfTest:   *selfTest,
 	}
 }
 
 func generateSingleSeed(cfg Config) {
 	// Parse security level
-	secLevel := parseSecurityLevel(cfg.Security)
+	secLevel := parseSecurityLevel(cfg)
 	spec := wallet.NewGenSpec(cfg.Account, secLevel)
 
 	// Generate or recover
@@ -115,6 +184,34 @@ func generateSingleSeed(cfg Config) {
 		os.Exit(1)
 	}
 
+	// Verify recovered wallet against descriptor
+	if cfg.Verify != "" {
+		if cfg.Mnemonic == "" {
+			fmt.Println("❌ Error: -verify-descriptor requires -mnemonic")
+			os.Exit(1)
+		}
+		ok, err := verifyDescriptor(cfg.Verify, cfg, spec)
+		if err != nil {
+			fmt.Printf("❌ Error verifying %s: %v\n", cfg.Verify, err)
+			os.Exit(1)
+		}
+		if !ok {
+			fmt.Println("❌ Recovered wallet does NOT match the descriptor")
+			fmt.Println("   Check your mnemonic, passphrase, account and security level")
+			os.Exit(1)
+		}
+		fmt.Println("✅ Recovered wallet matches the descriptor")
+		fmt.Println()
+	}
+
+	// Write derived networks to file
+	if cfg.Out != "" {
+		if err := writeNetworksFile(cfg.Out, cfg.Force, sleeve, cfg.ExportFile); err != nil {
+			fmt.Printf("❌ Error writing %s: %v\n", cfg.Out, err)
+			os.Exit(1)
+		}
+	}
+
 	// Display wallet info
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println("                    SINGLE-SEED WALLET")
@@ -122,7 +219,7 @@ func generateSingleSeed(cfg Config) {
 	fmt.Println()
 
 	// Recovery phrase
-	fmt.Println("🔑 RECOVERY PHRASE (24 words):")
+	fmt.Printf("🔑 RECOVERY PHRASE (%d words):\n", len(strings.Fields(sleeve.GetMnemonic())))
 	fmt.Println("   ⚠️  BACKUP THIS SECURELY - This is your ONLY backup!")
 	fmt.Println()
 	fmt.Printf("   %s\n", sleeve.GetMnemonic())
@@ -131,6 +228,7 @@ func generateSingleSeed(cfg Config) {
 	if cfg.Passphrase != "" {
 		fmt.Printf("🔐 Passphrase: %s\n", cfg.Passphrase)
 		fmt.Println("   ⚠️  You need BOTH the phrase AND passphrase to recover!")
+		printPassphraseStrength(cfg.Passphrase)
 		fmt.Println()
 	}
 
@@ -138,8 +236,10 @@ func generateSingleSeed(cfg Config) {
 	fmt.Println("───────────────────────────────────────────────────────────────")
 	fmt.Println("🛡️  QUANTUM SECURITY (WOTS+)")
 	fmt.Println("───────────────────────────────────────────────────────────────")
-	fmt.Printf("   Public Key: %s\n", hex.EncodeToString(sleeve.GetWOTSPublicKey()))
+	fmt.Printf("   Public Key: %s\n", sleeve.GetWOTSPublicKeyHex())
 	fmt.Printf("   Index:      %d\n", sleeve.GetDerivationIndex())
+	fmt.Printf("   Params:     %s\n", sleeve.GetWOTSParams())
+	fmt.Printf("   Checksum:   %s (write this down with your mnemonic)\n", sleeve.DescriptorChecksum())
 	fmt.Println()
 
 	// Network keys
@@ -155,13 +255,28 @@ func generateSingleSeed(cfg Config) {
 		fmt.Println()
 	}
 
+	if cfg.Out != "" {
+		fmt.Printf("💾 Derived networks written to: %s\n", cfg.Out)
+		if cfg.ExportFile {
+			fmt.Println("   ⚠️  This file contains private keys - store it securely!")
+		} else {
+			fmt.Println("   Private keys are not included, pass -export to write them too")
+		}
+		fmt.Println()
+	}
+
 	// Instructions
 	printInstructions(true)
 }
 
 func generateDualSeed(cfg Config) {
+	if cfg.Out != "" || cfg.Verify != "" {
+		fmt.Println("❌ Error: -out and -verify-descriptor are only supported in single mode")
+		os.Exit(1)
+	}
+
 	// Parse security level
-	secLevel := parseSecurityLevel(cfg.Security)
+	secLevel := parseSecurityLevel(cfg)
 	spec := wallet.NewGenSpec(cfg.Account, secLevel)
 
 	// Generate or recover
@@ -186,13 +301,13 @@ func generateDualSeed(cfg Config) {
 	fmt.Println()
 
 	// Recovery phrases
-	fmt.Println("🔑 QUANTUM RECOVERY PHRASE (24 words):")
+	fmt.Printf("🔑 QUANTUM RECOVERY PHRASE (%d words):\n", len(strings.Fields(sleeve.GetMnemonic())))
 	fmt.Println("   ⚠️  BACKUP THIS SECURELY!")
 	fmt.Println()
 	fmt.Printf("   %s\n", sleeve.GetMnemonic())
 	fmt.Println()
 
-	fmt.Println("🔑 STANDARD RECOVERY PHRASE (24 words):")
+	fmt.Printf("🔑 STANDARD RECOVERY PHRASE (%d words):\n", len(strings.Fields(sleeve.GetOutputMnemonic())))
 	fmt.Println("   ⚠️  BACKUP THIS TOO!")
 	fmt.Println()
 	fmt.Printf("   %s\n", sleeve.GetOutputMnemonic())
@@ -224,10 +339,9 @@ func exportNetworkKeys(sleeve *wallet.SingleSeedSleeve) {
 		fmt.Printf("   Error: %v\n", err)
 	} else {
 		// Get Ethereum address
-		privKey, _ := crypto.ToECDSA(ethKey)
-		address := crypto.PubkeyToAddress(privKey.PublicKey)
+		address, _ := sleeve.GetEthereumAddress("Ethereum")
 
-		fmt.Printf("   Address:     %s\n", address.Hex())
+		fmt.Printf("   Address:     %s\n", address)
 		fmt.Printf("   Private Key: 0x%s\n", hex.EncodeToString(ethKey))
 		networkKeys := sleeve.GetAllNetworkKeys()
 		if netKey, ok := networkKeys["Ethereum"]; ok {
@@ -248,6 +362,9 @@ func exportNetworkKeys(sleeve *wallet.SingleSeedSleeve) {
 	if err != nil {
 		fmt.Printf("   Error: %v\n", err)
 	} else {
+		address, _ := sleeve.GetAddress("Bitcoin")
+
+		fmt.Printf("   Address:     %s\n", address)
 		fmt.Printf("   Private Key: %s\n", hex.EncodeToString(btcKey))
 		networkKeys := sleeve.GetAllNetworkKeys()
 		if netKey, ok := networkKeys["Bitcoin"]; ok {
@@ -279,10 +396,205 @@ func exportNetworkKeys(sleeve *wallet.SingleSeedSleeve) {
 		fmt.Println()
 	}
 
+	// Overview of every derived network
+	fmt.Println("📋 ALL ADDRESSES")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	addresses, err := sleeve.GetAllAddresses()
+	if err != nil {
+		fmt.Printf("   Error: %v\n", err)
+	} else {
+		for _, name := range sleeve.GetNetworkNames() {
+			fmt.Printf("   %-10s %s\n", name+":", addresses[name])
+		}
+	}
+	fmt.Println()
+
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
 }
 
+// Print the estimated strength of a passphrase and any warnings about it
+func printPassphraseStrength(passphrase string) {
+	bits, warnings := wallet.PassphraseStrength(passphrase)
+	fmt.Printf("   Strength: ~%.0f bits\n", bits)
+	for _, w := range warnings {
+		fmt.Printf("   ⚠️  %s\n", w)
+	}
+}
+
+// Run the self-test, printing PASS or FAIL for each check
+// Returns true if every check passed
+func runSelfTest() bool {
+	fmt.Println("🧪 Running self-test...")
+	fmt.Println()
+
+	passed := true
+	check := func(name string, err error) {
+		if err != nil {
+			fmt.Printf("   FAIL  %s: %v\n", name, err)
+			passed = false
+			return
+		}
+		fmt.Printf("   PASS  %s\n", name)
+	}
+
+	// Canonical vectors: WOTS+ keys, derivation indexes, network keys and addresses
+	check("Test vectors", wallet.VerifyTestVectors())
+
+	// Derivation index of a fresh wallet, recomputed independently from its WOTS+ key
+	check("Derivation index", checkDerivationIndex())
+
+	fmt.Println()
+	if !passed {
+		fmt.Println("❌ Self-test FAILED: do NOT use this binary to generate wallets")
+		return false
+	}
+	fmt.Println("✅ Self-test PASSED")
+	return true
+}
+
+// Check the derivation index of a new wallet is the first 31 bits of SHA3-256 of its
+// WOTS+ public key, that the public key is generated from the quantum path node,
+// and that network keys are derived at the index
+func checkDerivationIndex() error {
+	sleeve, err := wallet.NewSingleSeedSleeve(rand.Reader, "", wallet.DefaultGenSpec())
+	if err != nil {
+		return err
+	}
+	defer sleeve.Wipe()
+
+	seed, code := sleeve.GetWOTSMaterial()
+	pk := wots.NewKeyFromSeed(wots.DecodeParams(sleeve.GetWOTSParams()), seed, code).ComputePK()
+	if !bytes.Equal(pk, sleeve.GetWOTSPublicKey()) {
+		return fmt.Errorf("WOTS+ public key doesn't match the quantum path node")
+	}
+
+	expected := binary.BigEndian.Uint32(hasher.SHA3_256.Hash(pk)[:4]) & 0x7FFFFFFF
+	if index := sleeve.GetDerivationIndex(); index != expected {
+		return fmt.Errorf("got index %d, expected %d", index, expected)
+	}
+
+	netKey, ok := sleeve.GetAllNetworkKeys()["Ethereum"]
+	if !ok {
+		return fmt.Errorf("Ethereum key wasn't derived")
+	}
+	if path := fmt.Sprintf("m/44'/60'/0'/0'/%d", expected); netKey.Path != path {
+		return fmt.Errorf("Ethereum path is %s, expected %s", netKey.Path, path)
+	}
+	return nil
+}
+
+// Write all derived networks to a JSON or CSV file, chosen by the file extension
+// The JSON file is a full wallet descriptor, which can later be used with -verify-descriptor
+// Private keys are only included when export is true, keyed by the name they are stored under
+// The file is created with 0600 permissions and is never overwritten unless force is true
+func writeNetworksFile(path string, force bool, sleeve *wallet.SingleSeedSleeve, export bool) error {
+	desc, err := sleeve.ExportDescriptor()
+	if err != nil {
+		return err
+	}
+	// Networks of the descriptor are in the order of the names their keys are stored under
+	names := sleeve.GetNetworkNames()
+	var privateKeys map[string]string
+	if export {
+		privateKeys = make(map[string]string, len(names))
+		netKeys := sleeve.GetAllNetworkKeys()
+		for _, name := range names {
+			privateKeys[name] = hex.EncodeToString(netKeys[name].Key)
+		}
+	}
+
+	// Encode according to extension
+	var data []byte
+	switch filepath.Ext(path) {
+	case ".json":
+		data, err = json.MarshalIndent(DescriptorFile{desc, privateKeys}, "", "  ")
+		if err != nil {
+			return err
+		}
+	case ".csv":
+		rows := [][]string{{"network", "cointype", "curve", "path", "address", "publickey"}}
+		if export {
+			rows[0] = append(rows[0], "privatekey")
+		}
+		for i, net := range desc.Networks {
+			row := []string{net.Network, strconv.FormatUint(uint64(net.CoinType), 10), net.Curve, net.Path, net.Address, net.PublicKey}
+			if export {
+				row = append(row, privateKeys[names[i]])
+			}
+			rows = append(rows, row)
+		}
+		buf := &bytes.Buffer{}
+		w := csv.NewWriter(buf)
+		if err := w.WriteAll(rows); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	default:
+		return fmt.Errorf("unsupported file extension %q: use .json or .csv", filepath.Ext(path))
+	}
+
+	// Refuse to overwrite an existing file unless forced
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("file already exists, use -force to overwrite")
+		}
+		return err
+	}
+	defer f.Close()
+
+	// Make sure permissions are restricted even when overwriting
+	if err := f.Chmod(0600); err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// Compare the recovered wallet against a descriptor file, printing PASS/FAIL per field
+// The wallet is recovered with wallet.ConfirmRecoveryFields, which derives every network of
+// the descriptor with its account, address index, scheme and address params
+func verifyDescriptor(path string, cfg Config, spec wallet.GenSpec) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	var desc DescriptorFile
+	if err := json.Unmarshal(data, &desc); err != nil {
+		return false, err
+	}
+	fields, err := wallet.ConfirmRecoveryFields(cfg.Mnemonic, cfg.Passphrase, spec, desc.WalletDescriptor)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	fmt.Println("🔍 DESCRIPTOR VERIFICATION")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+
+	ok := true
+	for _, f := range fields {
+		name := f.Name
+		if f.Network != "" {
+			name = f.Network + " " + f.Name
+		}
+		if f.Match() {
+			fmt.Printf("   PASS  %s\n", name)
+		} else {
+			fmt.Printf("   FAIL  %s\n         expected: %s\n         got:      %s\n", name, f.Expected, f.Got)
+			ok = false
+		}
+	}
+	fmt.Println()
+
+	return ok, nil
+}
+
 func printInstructions(singleSeed bool) {
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println("                    IMPORTANT NOTES")
@@ -325,8 +637,16 @@ func printInstructions(singleSeed bool) {
 	fmt.Println()
 }
 
-func parseSecurityLevel(level string) wots.ParamsEncoding {
-	switch level {
+func parseSecurityLevel(cfg Config) wots.ParamsEncoding {
+	if cfg.Bits != 0 {
+		level, err := wots.ParamsForSecurityBits(cfg.Bits)
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+		return level
+	}
+	switch cfg.Security {
 	case "level0":
 		return wots.Level0
 	case "level1":
@@ -2,18 +2,37 @@ package wallet
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/btcsuite/btcutil/bech32"
 	"github.com/vedhavyas/go-subkey"
 	sr "github.com/vedhavyas/go-subkey/sr25519"
 	"github.com/xx-labs/sleeve/hasher"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ripemd160"
 	"sort"
+	"strings"
+	"sync"
 )
 
 const testnetPrefix = 42
 const xxNetworkPrefix = 55
+const polkadotPrefix = 0
+
+// Base58Check version bytes of P2PKH addresses
+const bitcoinP2PKHVersion = 0x00
+const litecoinP2PKHVersion = 0x30
+const dogecoinP2PKHVersion = 0x1E
+const dashP2PKHVersion = 0x4C
+
+// Bech32 human readable part of Avalanche mainnet X/P-Chain addresses
+const avalancheHRP = "avax"
 
 //////////////////////////////////////////////////
 //-------------- SR25519 ACCOUNTS --------------//
@@ -51,6 +70,585 @@ func sr25519WalletFromMnemonic(mnemonic string) (subkey.KeyPair, error) {
 
 }
 
+//////////////////////////////////////////////////
+//------------- NETWORK KEY ADDRESSES ----------//
+//////////////////////////////////////////////////
+
+// Get the public key of a derived network key, according to its curve
+// secp256k1 public keys are returned compressed (33 bytes)
+func (s *SingleSeedSleeve) GetPublicKey(network string) ([]byte, error) {
+	netKey, exists := s.networkKeys[network]
+	if !exists {
+		return nil, fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	return networkPublicKey(netKey)
+}
+
+// Get the address of a derived network key
+// Returns an empty string for networks whose address format isn't supported
+func (s *SingleSeedSleeve) GetAddress(network string) (string, error) {
+	netKey, exists := s.networkKeys[network]
+	if !exists {
+		return "", fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	return networkAddress(netKey)
+}
+
+// Placeholder returned by GetAllAddresses for networks without a supported address format
+const unsupportedAddressFormat = "unsupported: no address format for coin type %d (%s curve)"
+
+// Get the addresses of all derived networks, by network name
+// Networks whose address format isn't supported are included with a placeholder
+// explaining why, so every derived network appears in the result
+func (s *SingleSeedSleeve) GetAllAddresses() (map[string]string, error) {
+	addresses := make(map[string]string, len(s.networkKeys))
+	for name, netKey := range s.networkKeys {
+		addr, err := networkAddress(netKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get address of network %s: %w", name, err)
+		}
+		if addr == "" {
+			addr = fmt.Sprintf(unsupportedAddressFormat, netKey.CoinType, netKey.Curve)
+		}
+		addresses[name] = addr
+	}
+	return addresses, nil
+}
+
+// Get a summary of a network key's derivation as hardware wallets display it,
+// to compare against the device before importing: a "Path: 44'/60'/0'/0'/index" line
+// and an "Address: ..." line. The path has no "m/" prefix, as shown by Ledger and Trezor.
+// For networks without a supported address format the public key is shown instead
+func (s *SingleSeedSleeve) DerivationSummary(network string) (string, error) {
+	netKey, exists := s.networkKeys[network]
+	if !exists {
+		return "", fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	summary := fmt.Sprintf("Path: %s\n", strings.TrimPrefix(netKey.path.String(), "m/"))
+
+	addr, err := networkAddress(netKey)
+	if err != nil {
+		return "", err
+	}
+	if addr != "" {
+		return summary + fmt.Sprintf("Address: %s\n", addr), nil
+	}
+	pub, err := networkPublicKey(netKey)
+	if err != nil {
+		return "", err
+	}
+	return summary + fmt.Sprintf("Public key: %x\n", pub), nil
+}
+
+// Get the first countPerCoin addresses of each coin type, under the sleeve's account
+// Addresses are those of DeriveAddressKey with address indexes 0 to countPerCoin-1,
+// computed from one change level node per coin type. Nothing is stored in the sleeve
+func (s *SingleSeedSleeve) FirstAddresses(seed []byte, coinTypes []uint32, countPerCoin uint32) (map[uint32][]string, error) {
+	if !s.indexComputed {
+		return nil, errNoDerivationIndex
+	}
+	if err := s.checkSeed(seed); err != nil {
+		return nil, err
+	}
+	addresses := make(map[uint32][]string, len(coinTypes))
+	for _, coinType := range coinTypes {
+		if coinType >= firstHardened {
+			return nil, &InvalidCoinTypeError{CoinType: coinType}
+		}
+		if _, done := addresses[coinType]; done {
+			continue
+		}
+
+		// Derive m/44'/{coinType}'/{account}'/0' once for all addresses of the coin type
+		path := networkPath(coinType, s.networkAccount(), 0, 0)
+		changeNode, err := deriveNetworkNode(seed, coinType, path[:len(path)-1])
+		if err != nil {
+			return nil, err
+		}
+
+		list := make([]string, countPerCoin)
+		for i := uint32(0); i < countPerCoin; i++ {
+			child, err := s.addressChild(changeNode, coinType, i)
+			if err != nil {
+				return nil, fmt.Errorf("failed to derive address %d for coin type %d: %v", i, coinType, err)
+			}
+			netKey := &NetworkKey{CoinType: coinType, Curve: CurveForCoinType(coinType), Key: child.Key}
+			if list[i], err = networkAddress(netKey); err != nil {
+				return nil, err
+			}
+			if list[i] == "" {
+				return nil, fmt.Errorf("address format for coin type %d isn't supported", coinType)
+			}
+		}
+		addresses[coinType] = list
+	}
+	return addresses, nil
+}
+
+// Derive the child of a change level node at the address level of an address index, as DeriveAddressKey does
+// The address level is hardened for IndexHardened sleeves and SLIP-0010 coin types
+func (s *SingleSeedSleeve) addressChild(changeNode *Node, coinType, addressIndex uint32) (*Node, error) {
+	index := (s.derivationIndex + addressIndex) &^ firstHardened
+	switch {
+	case usesSLIP10(coinType):
+		return changeNode.Ed25519HardenedChild(index | firstHardened)
+	case s.spec.IndexHardened:
+		return changeNode.HardenedChild(index | firstHardened)
+	default:
+		return changeNode.Child(index)
+	}
+}
+
+// Returned by FindVanityAddress when no address within the search budget has the prefix
+var ErrVanityNotFound = errors.New("no address with the prefix found within the search budget")
+
+// Search the address indexes of a network, under the sleeve's account, for an address starting with prefix
+// Address indexes 0 to maxTries-1 are tried in order, as derived by DeriveAddressKey, so the returned
+// index re-derives the address with DeriveAddressKey(network, coinType, index, false, seed).
+// The prefix is matched exactly, including the case of EIP-55 checksummed addresses.
+// Returns an error wrapping ErrVanityNotFound if no address within maxTries has the prefix
+func (s *SingleSeedSleeve) FindVanityAddress(network string, coinType uint32, seed []byte, prefix string,
+	maxTries uint32) (index uint32, address string, err error) {
+	if prefix == "" {
+		return 0, "", errors.New("vanity prefix can't be empty")
+	}
+	if maxTries == 0 || maxTries > firstHardened {
+		return 0, "", fmt.Errorf("invalid maxTries %d: must be between 1 and 2^31", maxTries)
+	}
+	if expected, ok := s.standardCoinType(network); ok && expected != coinType {
+		return 0, "", fmt.Errorf("network %s is a standard network with coin type %d, got coin type %d",
+			network, expected, coinType)
+	}
+	if coinType >= firstHardened {
+		return 0, "", &InvalidCoinTypeError{CoinType: coinType}
+	}
+	if !s.indexComputed {
+		return 0, "", errNoDerivationIndex
+	}
+	if err := s.checkSeed(seed); err != nil {
+		return 0, "", err
+	}
+
+	// Derive m/44'/{coinType}'/{account}'/0' once for all tries
+	path := networkPath(coinType, s.networkAccount(), 0, 0)
+	changeNode, err := deriveNetworkNode(seed, coinType, path[:len(path)-1])
+	if err != nil {
+		return 0, "", err
+	}
+	for i := uint32(0); i < maxTries; i++ {
+		child, err := s.addressChild(changeNode, coinType, i)
+		if err != nil {
+			return 0, "", fmt.Errorf("failed to derive address %d of network %s: %v", i, network, err)
+		}
+		netKey := &NetworkKey{CoinType: coinType, Curve: CurveForCoinType(coinType), Key: child.Key}
+		addr, err := networkAddress(netKey)
+		zero(child.Key)
+		if err != nil {
+			return 0, "", err
+		}
+		if addr == "" {
+			return 0, "", fmt.Errorf("address format for coin type %d isn't supported", coinType)
+		}
+		if strings.HasPrefix(addr, prefix) {
+			return i, addr, nil
+		}
+	}
+	return 0, "", fmt.Errorf("%w: prefix %q, %d addresses of network %s", ErrVanityNotFound, prefix, maxTries, network)
+}
+
+// Compute the public key of a network key, dispatching on its curve
+func networkPublicKey(netKey *NetworkKey) ([]byte, error) {
+	if netKey.Key == nil && netKey.publicKey != nil {
+		return append([]byte{}, netKey.publicKey...), nil
+	}
+	switch netKey.Curve {
+	case CurveSecp256k1:
+		return compressedPublicKey(netKey.Key)
+	case CurveEd25519:
+		return ed25519.NewKeyFromSeed(netKey.Key).Public().(ed25519.PublicKey), nil
+	case CurveSr25519:
+		kp, err := sr.Scheme{}.FromSeed(netKey.Key)
+		if err != nil {
+			return nil, err
+		}
+		return kp.Public(), nil
+	default:
+		return nil, fmt.Errorf("unknown curve for network %s", netKey.Network)
+	}
+}
+
+// AddressEncoder encodes a public key into an address for a chain
+// Public keys are given as returned by GetPublicKey for the coin type's curve
+type AddressEncoder interface {
+	Encode(pubkey []byte) (string, error)
+}
+
+// Address encoders registered by users, by coin type
+var (
+	addressEncodersMux sync.RWMutex
+	addressEncoders    = make(map[uint32]AddressEncoder)
+)
+
+// Register an address encoder for a coin type, used by GetAddress instead of
+// any built-in encoding, so chains the library doesn't support can be added
+// Registering a nil encoder removes the registration for the coin type
+func RegisterAddressEncoder(coinType uint32, enc AddressEncoder) {
+	addressEncodersMux.Lock()
+	defer addressEncodersMux.Unlock()
+	if enc == nil {
+		delete(addressEncoders, coinType)
+		return
+	}
+	addressEncoders[coinType] = enc
+}
+
+// Get the registered address encoder for a coin type, if any
+func registeredAddressEncoder(coinType uint32) (AddressEncoder, bool) {
+	addressEncodersMux.RLock()
+	defer addressEncodersMux.RUnlock()
+	enc, ok := addressEncoders[coinType]
+	return enc, ok
+}
+
+// Compute the address of a network key, using the registered encoder
+// for its coin type if any, or else dispatching on its coin type
+func networkAddress(netKey *NetworkKey) (string, error) {
+	// Address params set on the key take precedence over any coin type encoding
+	if netKey.AddressParams != nil {
+		return netKey.AddressParams.encodeAddress(netKey)
+	}
+	if enc, ok := registeredAddressEncoder(netKey.CoinType); ok {
+		pub, err := networkPublicKey(netKey)
+		if err != nil {
+			return "", err
+		}
+		return enc.Encode(pub)
+	}
+
+	// EVM chains share Ethereum's EIP-55 address format, whatever their coin type
+	if _, ok := EVMChainID(netKey.CoinType); ok {
+		pub, err := networkPublicKey(netKey)
+		if err != nil {
+			return "", err
+		}
+		return EthereumAddress(pub)
+	}
+
+	switch netKey.CoinType {
+	case CoinTypePolkadot:
+		pub, err := networkPublicKey(netKey)
+		if err != nil {
+			return "", err
+		}
+		return generateSS58Address(polkadotPrefix, pub), nil
+	case CoinTypeSolana:
+		pub, err := networkPublicKey(netKey)
+		if err != nil {
+			return "", err
+		}
+		return base58.Encode(pub), nil
+	case CoinTypeAvalanche:
+		pub, err := networkPublicKey(netKey)
+		if err != nil {
+			return "", err
+		}
+		return AvalancheCAddress(pub)
+	case CoinTypeTezos:
+		pub, err := networkPublicKey(netKey)
+		if err != nil {
+			return "", err
+		}
+		return TezosAddress(pub), nil
+	case CoinTypeNear:
+		pub, err := networkPublicKey(netKey)
+		if err != nil {
+			return "", err
+		}
+		return NearImplicitAddress(pub), nil
+	case CoinTypeEOS:
+		pub, err := networkPublicKey(netKey)
+		if err != nil {
+			return "", err
+		}
+		return EOSPublicKey(pub), nil
+	case CoinTypeZcash:
+		pub, err := networkPublicKey(netKey)
+		if err != nil {
+			return "", err
+		}
+		return ZcashTransparentAddress(pub), nil
+	default:
+		// Bitcoin family coin types are encoded from their default address params
+		if params, ok := defaultAddressParams(netKey.CoinType); ok {
+			return params.encodeAddress(netKey)
+		}
+		return "", nil
+	}
+}
+
+// Compute a Base58Check P2PKH address: version || HASH160(compressed public key)
+func p2pkhAddress(netKey *NetworkKey, version byte) (string, error) {
+	pub, err := networkPublicKey(netKey)
+	if err != nil {
+		return "", err
+	}
+	return base58.CheckEncode(hash160(pub), version), nil
+}
+
+// Compute a Bech32 P2WPKH address: witness version 0 || HASH160(compressed public key)
+func p2wpkhAddress(netKey *NetworkKey, hrp string) (string, error) {
+	pub, err := networkPublicKey(netKey)
+	if err != nil {
+		return "", err
+	}
+	data, err := bech32.ConvertBits(hash160(pub), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32.Encode(hrp, append([]byte{0}, data...))
+}
+
+// AddressFormat is the address encoding of a Bitcoin family network
+type AddressFormat int
+
+const (
+	// No address is encoded, only WIF export is supported
+	AddressFormatNone AddressFormat = iota
+	// Base58Check P2PKH addresses, using the P2PKH version byte
+	AddressFormatP2PKH
+	// Bech32 P2WPKH addresses, using the HRP
+	AddressFormatP2WPKH
+)
+
+// AddressParams holds the encoding parameters of a Bitcoin family network
+// Forks reuse Bitcoin's derivation and differ only in these, so setting them on a
+// network key with DeriveNetworkKeyWithParams supports a fork without a new encoder
+type AddressParams struct {
+	Format       AddressFormat // Address encoding
+	P2PKHVersion byte          // Base58Check version byte of P2PKH addresses
+	HRP          string        // Bech32 human readable part of P2WPKH addresses
+	WIFVersion   byte          // Version byte of WIF private keys, 0 if WIF isn't supported
+}
+
+// Default address params of Bitcoin family coin types
+// Network keys are at BIP44 paths, so addresses default to P2PKH as BIP44 wallets expect.
+// Bitcoin and Litecoin also have their native SegWit HRPs for GetSegWitAddress,
+// Dogecoin and Dash never activated SegWit
+var defaultAddressParamsByCoinType = map[uint32]AddressParams{
+	CoinTypeBitcoin:  {Format: AddressFormatP2PKH, P2PKHVersion: bitcoinP2PKHVersion, HRP: "bc", WIFVersion: 0x80},
+	CoinTypeLitecoin: {Format: AddressFormatP2PKH, P2PKHVersion: litecoinP2PKHVersion, HRP: "ltc", WIFVersion: 0xB0},
+	CoinTypeDogecoin: {Format: AddressFormatP2PKH, P2PKHVersion: dogecoinP2PKHVersion, WIFVersion: 0x9E},
+	CoinTypeDash:     {Format: AddressFormatP2PKH, P2PKHVersion: dashP2PKHVersion, WIFVersion: 0xCC},
+}
+
+// Get the default address params of a coin type, if it's a Bitcoin family coin type
+func defaultAddressParams(coinType uint32) (AddressParams, bool) {
+	params, ok := defaultAddressParamsByCoinType[coinType]
+	return params, ok
+}
+
+// Check the address params are usable for encoding
+func (p AddressParams) validate() error {
+	switch p.Format {
+	case AddressFormatNone, AddressFormatP2PKH:
+		return nil
+	case AddressFormatP2WPKH:
+		if p.HRP == "" || strings.ToLower(p.HRP) != p.HRP {
+			return fmt.Errorf("invalid HRP %q: P2WPKH addresses need a non-empty lowercase HRP", p.HRP)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown address format %d", p.Format)
+	}
+}
+
+// Encode the address of a network key with the address params
+func (p AddressParams) encodeAddress(netKey *NetworkKey) (string, error) {
+	switch p.Format {
+	case AddressFormatP2PKH:
+		return p2pkhAddress(netKey, p.P2PKHVersion)
+	case AddressFormatP2WPKH:
+		return p2wpkhAddress(netKey, p.HRP)
+	default:
+		return "", nil
+	}
+}
+
+// Get the address params of a network key, set on the key or else the defaults of its coin type
+func (k *NetworkKey) addressParams() (AddressParams, bool) {
+	if k.AddressParams != nil {
+		return *k.AddressParams, true
+	}
+	return defaultAddressParams(k.CoinType)
+}
+
+// Get the private key of a Bitcoin family network in Wallet Import Format
+// WIF = Base58Check(version || key || 0x01), the 0x01 suffix marking a compressed public key
+// The version byte comes from the key's address params, or else the defaults of its coin type
+func (s *SingleSeedSleeve) GetWIF(network string) (string, error) {
+	if s.watchOnly {
+		return "", ErrWatchOnly
+	}
+	netKey, exists := s.networkKeys[network]
+	if !exists {
+		return "", fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	if netKey.CoinType == CoinTypeEOS && netKey.AddressParams == nil {
+		return EOSPrivateKeyWIF(netKey.Key), nil
+	}
+	params, ok := netKey.addressParams()
+	if !ok || params.WIFVersion == 0 {
+		return "", fmt.Errorf("network %s with coin type %d isn't a Bitcoin family network, WIF isn't supported",
+			network, netKey.CoinType)
+	}
+	payload := make([]byte, 0, len(netKey.Key)+1)
+	payload = append(payload, netKey.Key...)
+	payload = append(payload, 0x01)
+	wif := base58.CheckEncode(payload, params.WIFVersion)
+	zero(payload)
+	return wif, nil
+}
+
+// Get the native SegWit (Bech32 P2WPKH, witness version 0) address of a Bitcoin family network
+// The HRP is e.g. "bc" for Bitcoin or "ltc" for Litecoin. An empty HRP uses the HRP of the key's
+// address params, or else the default of its coin type, and fails for networks without SegWit
+func (s *SingleSeedSleeve) GetSegWitAddress(network, hrp string) (string, error) {
+	netKey, exists := s.networkKeys[network]
+	if !exists {
+		return "", fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	params, ok := netKey.addressParams()
+	if !ok {
+		return "", fmt.Errorf("network %s with coin type %d isn't a Bitcoin family network, SegWit isn't supported",
+			network, netKey.CoinType)
+	}
+	if hrp == "" {
+		if params.HRP == "" {
+			return "", fmt.Errorf("network %s with coin type %d has no SegWit HRP", network, netKey.CoinType)
+		}
+		hrp = params.HRP
+	}
+	if err := (AddressParams{Format: AddressFormatP2WPKH, HRP: hrp}).validate(); err != nil {
+		return "", err
+	}
+	return p2wpkhAddress(netKey, hrp)
+}
+
+// Compute RIPEMD160(SHA256(data))
+func hash160(data []byte) []byte {
+	sha := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(sha[:])
+	return h.Sum(nil)
+}
+
+//////////////////////////////////////////////////
+//------------- AVALANCHE ADDRESSES ------------//
+//////////////////////////////////////////////////
+
+// Get the Avalanche C-Chain address of a compressed secp256k1 public key
+// C-Chain is EVM compatible, so this is the EIP-55 checksummed Ethereum address
+func AvalancheCAddress(pubkey []byte) (string, error) {
+	if len(pubkey) != 33 {
+		return "", fmt.Errorf("invalid compressed public key size %d, expected 33", len(pubkey))
+	}
+	return EthereumAddress(pubkey)
+}
+
+// Get the Avalanche X-Chain or P-Chain address of a compressed secp256k1 public key,
+// e.g., X-avax1..., where chainPrefix is the chain alias ("X" or "P")
+func AvalancheBech32Address(pubkey []byte, chainPrefix string) (string, error) {
+	return avalancheBech32Address(pubkey, chainPrefix, avalancheHRP)
+}
+
+// Avalanche X/P-Chain address: chain alias, dash, and bech32 encoded HASH160 of the public key
+func avalancheBech32Address(pubkey []byte, chainPrefix, hrp string) (string, error) {
+	if len(pubkey) != 33 {
+		return "", fmt.Errorf("invalid compressed public key size %d, expected 33", len(pubkey))
+	}
+	if _, err := btcec.ParsePubKey(pubkey, btcec.S256()); err != nil {
+		return "", err
+	}
+	data, err := bech32.ConvertBits(hash160(pubkey), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	addr, err := bech32.Encode(hrp, data)
+	if err != nil {
+		return "", err
+	}
+	return chainPrefix + "-" + addr, nil
+}
+
+//////////////////////////////////////////////////
+//------------- TEZOS ADDRESSES ----------------//
+//////////////////////////////////////////////////
+
+// Base58Check prefix of Tezos ed25519 public key hashes, encoding to "tz1"
+var tezosTz1Prefix = []byte{6, 161, 159}
+
+// Get the Tezos tz1 address of an ed25519 public key
+// tz1... = Base58Check(tz1 prefix || BLAKE2B_160(pubkey))
+func TezosAddress(pubkey []byte) string {
+	h, _ := blake2b.New(20, nil)
+	h.Write(pubkey)
+	// Base58Check covers the whole prefix, so its first byte can be passed as the version
+	payload := append(append([]byte{}, tezosTz1Prefix[1:]...), h.Sum(nil)...)
+	return base58.CheckEncode(payload, tezosTz1Prefix[0])
+}
+
+//////////////////////////////////////////////////
+//------------- NEAR ADDRESSES -----------------//
+//////////////////////////////////////////////////
+
+// Get the Near implicit account ID of an ed25519 public key
+// Implicit accounts are the 64 character lowercase hex encoding of the public key
+func NearImplicitAddress(pubkey []byte) string {
+	return hex.EncodeToString(pubkey)
+}
+
+//////////////////////////////////////////////////
+//-------------- ZCASH ADDRESSES ---------------//
+//////////////////////////////////////////////////
+
+// Two byte version of Zcash mainnet transparent P2PKH addresses, encoding to "t1"
+var zcashP2PKHPrefix = []byte{0x1C, 0xB8}
+
+// Get the Zcash transparent (t-addr) address of a compressed secp256k1 public key
+// t1... = Base58Check(0x1CB8 || HASH160(pubkey))
+// Shielded addresses aren't derived from BIP32 keys, so they aren't supported
+func ZcashTransparentAddress(pubkey []byte) string {
+	// Base58Check covers the whole prefix, so its first byte can be passed as the version
+	payload := append(append([]byte{}, zcashP2PKHPrefix[1:]...), hash160(pubkey)...)
+	return base58.CheckEncode(payload, zcashP2PKHPrefix[0])
+}
+
+//////////////////////////////////////////////////
+//---------------- EOS KEYS --------------------//
+//////////////////////////////////////////////////
+
+// Prefix of legacy EOS public keys
+const eosPublicKeyPrefix = "EOS"
+
+// Get the legacy EOS public key format of a compressed secp256k1 public key
+// EOS... = "EOS" || Base58(pubkey || RIPEMD160(pubkey)[0:4])
+// Unlike Base58Check, the checksum is RIPEMD-160, not double SHA-256
+func EOSPublicKey(pubkey []byte) string {
+	h := ripemd160.New()
+	h.Write(pubkey)
+	checksum := h.Sum(nil)[:4]
+	data := make([]byte, 0, len(pubkey)+len(checksum))
+	data = append(data, pubkey...)
+	data = append(data, checksum...)
+	return eosPublicKeyPrefix + base58.Encode(data)
+}
+
+// Get an EOS private key in its legacy WIF format
+// EOS uses Bitcoin's uncompressed WIF: Base58Check(0x80 || key), without the 0x01 suffix
+func EOSPrivateKeyWIF(privKey []byte) string {
+	return base58.CheckEncode(privKey, 0x80)
+}
+
 //////////////////////////////////////////////////
 //------------- MULTISIG ACCOUNTS --------------//
 //////////////////////////////////////////////////
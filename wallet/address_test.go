@@ -1,6 +1,14 @@
 package wallet
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/xx-labs/sleeve/wots"
+	"strings"
 	"testing"
 )
 
@@ -217,3 +225,694 @@ func TestDeriveMultisigAddressConsistency(t *testing.T) {
 			msig, multisigAddress)
 	}
 }
+
+// Test public keys and addresses of derived network keys
+func TestSingleSeedSleeve_GetPublicKeyAndAddress(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, mustSeed(testVectorMnemonic))
+
+	expectedLen := map[string]int{"Bitcoin": 33, "Ethereum": 33, "Polkadot": 32, "Solana": 32}
+	for name, size := range expectedLen {
+		pub, err := sleeve.GetPublicKey(name)
+		if err != nil {
+			t.Fatalf("GetPublicKey(%s) returned error: %v", name, err)
+		}
+		if len(pub) != size {
+			t.Fatalf("GetPublicKey(%s) returned wrong size. Got %d, expected %d", name, len(pub), size)
+		}
+	}
+
+	// Ethereum address is a checksummed hex address
+	ethAddr, err := sleeve.GetAddress("Ethereum")
+	if err != nil || len(ethAddr) != 42 || ethAddr[:2] != "0x" {
+		t.Fatalf("GetAddress(Ethereum) returned invalid address %q: %v", ethAddr, err)
+	}
+
+	// Polkadot address is SS58 with the sr25519 public key
+	dotAddr, _ := sleeve.GetAddress("Polkadot")
+	if ok, err := validateSS58Address(polkadotPrefix, dotAddr); !ok {
+		t.Fatalf("GetAddress(Polkadot) returned invalid SS58 address %q: %v", dotAddr, err)
+	}
+	dotPub, _ := sleeve.GetPublicKey("Polkadot")
+	if !bytes.Equal(extractPublicKey(dotAddr), dotPub) {
+		t.Fatalf("GetAddress(Polkadot) doesn't encode the Polkadot public key")
+	}
+
+	// Solana address is the base58 encoded ed25519 public key
+	solAddr, _ := sleeve.GetAddress("Solana")
+	solPub, _ := sleeve.GetPublicKey("Solana")
+	if solAddr != base58.Encode(solPub) {
+		t.Fatalf("GetAddress(Solana) doesn't encode the Solana public key")
+	}
+
+	// Bitcoin address is a P2PKH address of the Bitcoin public key
+	btcAddr, _ := sleeve.GetAddress("Bitcoin")
+	btcPub, _ := sleeve.GetPublicKey("Bitcoin")
+	if hash, version, err := base58.CheckDecode(btcAddr); err != nil || version != bitcoinP2PKHVersion ||
+		!bytes.Equal(hash, hash160(btcPub)) || !strings.HasPrefix(btcAddr, "1") {
+		t.Fatalf("GetAddress(Bitcoin) returned invalid address %q: %v", btcAddr, err)
+	}
+
+	// Unsupported address format
+	_ = sleeve.DeriveNetworkKey("Cardano", CoinTypeCardano, mustSeed(testVectorMnemonic))
+	if addr, err := sleeve.GetAddress("Cardano"); addr != "" || err != nil {
+		t.Fatalf("GetAddress(Cardano) should return empty address, got %q: %v", addr, err)
+	}
+
+	// Unknown network
+	if _, err := sleeve.GetPublicKey("Unknown"); err == nil {
+		t.Fatalf("GetPublicKey() should return error for unknown network")
+	}
+	if _, err := sleeve.GetAddress("Unknown"); err == nil {
+		t.Fatalf("GetAddress() should return error for unknown network")
+	}
+}
+
+func TestNetworkAddress_P2PKH(t *testing.T) {
+	// Private key 1, whose compressed public key hashes to 751e76e8199196d454941c45d1b3a323f1433bd6
+	key := make([]byte, 32)
+	key[31] = 1
+
+	expected := map[uint32]string{
+		CoinTypeBitcoin:  "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+		CoinTypeLitecoin: "LVuDpNCSSj6pQ7t9Pv6d6sUkLKoqDEVUnJ",
+		CoinTypeDogecoin: "DFpN6QqFfUm3gKNaxN6tNcab1FArL9cZLE",
+		CoinTypeDash:     "XmN7PQYWKn5MJFna5fRYgP6mxT2F7xpekE",
+	}
+	for coinType, addr := range expected {
+		netKey := &NetworkKey{CoinType: coinType, Curve: CurveForCoinType(coinType), Key: key}
+		got, err := networkAddress(netKey)
+		if err != nil {
+			t.Fatalf("networkAddress(%d) returned error: %v", coinType, err)
+		}
+		if got != addr {
+			t.Fatalf("networkAddress(%d) returned wrong address. Got %s, expected %s", coinType, got, addr)
+		}
+	}
+
+	// Derived keys produce addresses with the network's version byte
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Dogecoin", CoinTypeDogecoin, seed)
+	addr, _ := sleeve.GetAddress("Dogecoin")
+	if _, version, err := base58.CheckDecode(addr); err != nil || version != dogecoinP2PKHVersion {
+		t.Fatalf("GetAddress(Dogecoin) returned invalid address %q: %v", addr, err)
+	}
+}
+
+func TestSingleSeedSleeve_FirstAddresses(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, NewGenSpec(1, wots.DefaultParams))
+
+	coinTypes := []uint32{CoinTypeEthereum, CoinTypePolkadot, CoinTypeSolana, CoinTypeDogecoin, CoinTypeTezos}
+	addresses, err := sleeve.FirstAddresses(seed, coinTypes, 3)
+	if err != nil {
+		t.Fatalf("FirstAddresses() returned error: %v", err)
+	}
+	if len(addresses) != len(coinTypes) {
+		t.Fatalf("FirstAddresses() returned %d coin types, expected %d", len(addresses), len(coinTypes))
+	}
+
+	// Each address matches the one from DeriveAddressKey
+	for _, coinType := range coinTypes {
+		if len(addresses[coinType]) != 3 {
+			t.Fatalf("FirstAddresses() returned %d addresses for coin type %d, expected 3",
+				len(addresses[coinType]), coinType)
+		}
+		for i, addr := range addresses[coinType] {
+			name := fmt.Sprintf("coin%d", coinType)
+			if _, err := sleeve.DeriveAddressKey(name, coinType, uint32(i), false, seed); err != nil {
+				t.Fatalf("DeriveAddressKey() returned error: %v", err)
+			}
+			expected, _ := sleeve.GetAddress(addressKeyName(name, uint32(i), false))
+			if addr != expected {
+				t.Fatalf("FirstAddresses() address %d for coin type %d doesn't match DeriveAddressKey(). "+
+					"Got %s, expected %s", i, coinType, addr, expected)
+			}
+		}
+	}
+
+	// Deterministic
+	again, _ := sleeve.FirstAddresses(seed, coinTypes, 3)
+	for _, coinType := range coinTypes {
+		if strings.Join(again[coinType], ",") != strings.Join(addresses[coinType], ",") {
+			t.Fatalf("FirstAddresses() isn't deterministic for coin type %d", coinType)
+		}
+	}
+
+	// Unsupported address format and invalid coin type
+	if _, err := sleeve.FirstAddresses(seed, []uint32{CoinTypeCardano}, 1); err == nil {
+		t.Fatalf("FirstAddresses() should return error for coin type without address format")
+	}
+	var coinErr *InvalidCoinTypeError
+	if _, err := sleeve.FirstAddresses(seed, []uint32{1 << 31}, 1); !errors.As(err, &coinErr) {
+		t.Fatalf("FirstAddresses() should return InvalidCoinTypeError for coin type 2^31, got %v", err)
+	}
+}
+
+// Example encoder for a chain the library doesn't ship: hex encoded public key with a prefix
+type testAddressEncoder struct {
+	prefix string
+}
+
+func (e testAddressEncoder) Encode(pubkey []byte) (string, error) {
+	if len(pubkey) != 33 {
+		return "", fmt.Errorf("unexpected public key size %d", len(pubkey))
+	}
+	return e.prefix + hex.EncodeToString(pubkey), nil
+}
+
+func TestSingleSeedSleeve_FindVanityAddress(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, NewGenSpec(1, wots.DefaultParams))
+
+	// Find the first address with the prefix of the third address
+	addresses, err := sleeve.FirstAddresses(seed, []uint32{CoinTypeEthereum}, 3)
+	if err != nil {
+		t.Fatalf("FirstAddresses() returned error: %v", err)
+	}
+	keys := len(sleeve.GetAllNetworkKeys())
+	prefix := addresses[CoinTypeEthereum][2][:4]
+	expectedIndex := uint32(2)
+	for i, addr := range addresses[CoinTypeEthereum] {
+		if strings.HasPrefix(addr, prefix) {
+			expectedIndex = uint32(i)
+			break
+		}
+	}
+	index, addr, err := sleeve.FindVanityAddress("eth", CoinTypeEthereum, seed, prefix, 3)
+	if err != nil {
+		t.Fatalf("FindVanityAddress() returned error: %v", err)
+	}
+	if index != expectedIndex || !strings.HasPrefix(addr, prefix) {
+		t.Fatalf("FindVanityAddress() returned index %d address %s, expected index %d with prefix %s",
+			index, addr, expectedIndex, prefix)
+	}
+	if len(sleeve.GetAllNetworkKeys()) != keys {
+		t.Fatalf("FindVanityAddress() shouldn't store network keys")
+	}
+
+	// The index re-derives the address
+	if _, err := sleeve.DeriveAddressKey("eth", CoinTypeEthereum, index, false, seed); err != nil {
+		t.Fatalf("DeriveAddressKey() returned error: %v", err)
+	}
+	if expected, _ := sleeve.GetAddress(addressKeyName("eth", index, false)); addr != expected {
+		t.Fatalf("FindVanityAddress() address doesn't match DeriveAddressKey(). Got %s, expected %s", addr, expected)
+	}
+
+	// Budget exhausted
+	if _, _, err := sleeve.FindVanityAddress("eth", CoinTypeEthereum, seed, "0xzz", 5); !errors.Is(err, ErrVanityNotFound) {
+		t.Fatalf("FindVanityAddress() should return ErrVanityNotFound when the budget is exhausted, got %v", err)
+	}
+	// Invalid arguments
+	if _, _, err := sleeve.FindVanityAddress("eth", CoinTypeEthereum, seed, "", 5); err == nil {
+		t.Fatalf("FindVanityAddress() should return error for an empty prefix")
+	}
+	if _, _, err := sleeve.FindVanityAddress("eth", CoinTypeEthereum, seed, "0x", 0); err == nil {
+		t.Fatalf("FindVanityAddress() should return error for a zero budget")
+	}
+	if _, _, err := sleeve.FindVanityAddress("Bitcoin", CoinTypeEthereum, seed, "0x", 5); err == nil {
+		t.Fatalf("FindVanityAddress() should return error for a standard network with another coin type")
+	}
+	if _, _, err := sleeve.FindVanityAddress("eth", CoinTypeEthereum, mustSeed("abandon abandon abandon abandon "+
+		"abandon abandon abandon abandon abandon abandon abandon about"), "0x", 5); err == nil {
+		t.Fatalf("FindVanityAddress() should return error for another seed")
+	}
+}
+
+func TestRegisterAddressEncoder(t *testing.T) {
+	const coinTypeCustom uint32 = 999999
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Custom", coinTypeCustom, seed)
+
+	// No encoding before registration
+	if addr, _ := sleeve.GetAddress("Custom"); addr != "" {
+		t.Fatalf("GetAddress() should return empty address for unregistered coin type, got %s", addr)
+	}
+
+	RegisterAddressEncoder(coinTypeCustom, testAddressEncoder{prefix: "cx"})
+	defer RegisterAddressEncoder(coinTypeCustom, nil)
+
+	pub, _ := sleeve.GetPublicKey("Custom")
+	addr, err := sleeve.GetAddress("Custom")
+	if err != nil {
+		t.Fatalf("GetAddress() returned error: %v", err)
+	}
+	if addr != "cx"+hex.EncodeToString(pub) {
+		t.Fatalf("GetAddress() didn't use the registered encoder, got %s", addr)
+	}
+
+	// Registered encoders take precedence over built-ins
+	RegisterAddressEncoder(CoinTypeEthereum, testAddressEncoder{prefix: "eth:"})
+	addr, _ = sleeve.GetAddress("Ethereum")
+	RegisterAddressEncoder(CoinTypeEthereum, nil)
+	if !strings.HasPrefix(addr, "eth:") {
+		t.Fatalf("GetAddress() should prefer the registered encoder over the built-in, got %s", addr)
+	}
+	if addr, _ = sleeve.GetAddress("Ethereum"); !strings.HasPrefix(addr, "0x") {
+		t.Fatalf("GetAddress() should use the built-in encoder after unregistering, got %s", addr)
+	}
+}
+
+func TestSingleSeedSleeve_DerivationSummary(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	index := sleeve.GetDerivationIndex()
+
+	summary, err := sleeve.DerivationSummary("Ethereum")
+	if err != nil {
+		t.Fatalf("DerivationSummary() returned error: %v", err)
+	}
+	addr, _ := sleeve.GetAddress("Ethereum")
+	expected := fmt.Sprintf("Path: 44'/60'/0'/0'/%d\nAddress: %s\n", index, addr)
+	if summary != expected {
+		t.Fatalf("DerivationSummary() returned wrong summary. Got %q, expected %q", summary, expected)
+	}
+
+	// Public key is shown for networks without a supported address format
+	_ = sleeve.DeriveNetworkKey("Cardano", CoinTypeCardano, mustSeed(testVectorMnemonic))
+	summary, _ = sleeve.DerivationSummary("Cardano")
+	pub, _ := sleeve.GetPublicKey("Cardano")
+	expected = fmt.Sprintf("Path: 44'/1815'/0'/0'/%d\nPublic key: %x\n", index, pub)
+	if summary != expected {
+		t.Fatalf("DerivationSummary() returned wrong summary. Got %q, expected %q", summary, expected)
+	}
+
+	if _, err := sleeve.DerivationSummary("Unknown"); err == nil {
+		t.Fatalf("DerivationSummary() should return error for unknown network")
+	}
+}
+
+func TestSingleSeedSleeve_GetAllAddresses(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Cardano", CoinTypeCardano, mustSeed(testVectorMnemonic))
+
+	addresses, err := sleeve.GetAllAddresses()
+	if err != nil {
+		t.Fatalf("GetAllAddresses() returned error: %v", err)
+	}
+	names := sleeve.GetNetworkNames()
+	if len(addresses) != len(names) {
+		t.Fatalf("GetAllAddresses() returned %d addresses, expected %d", len(addresses), len(names))
+	}
+	for _, name := range names {
+		addr, _ := sleeve.GetAddress(name)
+		if addr == "" {
+			// Networks without a supported address format get a placeholder
+			if !strings.HasPrefix(addresses[name], "unsupported: ") {
+				t.Fatalf("GetAllAddresses() returned %q for %s, expected a placeholder", addresses[name], name)
+			}
+			continue
+		}
+		if addresses[name] != addr {
+			t.Fatalf("GetAllAddresses() returned %s for %s, expected %s", addresses[name], name, addr)
+		}
+	}
+	if !strings.Contains(addresses["Cardano"], "coin type 1815 (ed25519 curve)") {
+		t.Fatalf("GetAllAddresses() returned wrong placeholder for Cardano: %q", addresses["Cardano"])
+	}
+	if !strings.HasPrefix(addresses["Bitcoin"], "1") {
+		t.Fatalf("GetAllAddresses() should return a P2PKH address for Bitcoin, got %q", addresses["Bitcoin"])
+	}
+}
+
+func TestAvalancheAddresses(t *testing.T) {
+	// Avalanche local network funded key (ewoq), documented with
+	// X-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u and C-Chain 0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC
+	privKey, _ := hex.DecodeString("56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5ccf558d8027")
+	pub, _ := compressedPublicKey(privKey)
+
+	addr, err := avalancheBech32Address(pub, "X", "local")
+	if err != nil {
+		t.Fatalf("avalancheBech32Address() returned error: %v", err)
+	}
+	if addr != "X-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u" {
+		t.Fatalf("avalancheBech32Address() returned wrong address: %s", addr)
+	}
+	addr, _ = AvalancheBech32Address(pub, "P")
+	if !strings.HasPrefix(addr, "P-avax1") {
+		t.Fatalf("AvalancheBech32Address() returned wrong prefix: %s", addr)
+	}
+
+	cAddr, err := AvalancheCAddress(pub)
+	if err != nil {
+		t.Fatalf("AvalancheCAddress() returned error: %v", err)
+	}
+	if cAddr != "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC" {
+		t.Fatalf("AvalancheCAddress() returned wrong address: %s", cAddr)
+	}
+
+	if _, err := AvalancheBech32Address(pub[1:], "X"); err == nil {
+		t.Fatalf("AvalancheBech32Address() should return error for invalid public key")
+	}
+
+	// GetAddress defaults to C-Chain
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Avalanche", CoinTypeAvalanche, seed)
+	netPub, _ := sleeve.GetPublicKey("Avalanche")
+	expected, _ := AvalancheCAddress(netPub)
+	if addr, _ := sleeve.GetAddress("Avalanche"); addr != expected {
+		t.Fatalf("GetAddress(Avalanche) should return the C-Chain address, got %s", addr)
+	}
+}
+
+func TestNetworkAddress_EVM(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Polygon", CoinTypePolygon, seed)
+	_ = sleeve.DeriveNetworkKey("Fantom", CoinTypeFantom, seed)
+	_ = sleeve.DeriveNetworkKey("BSC", CoinTypeBSC, seed)
+
+	for _, network := range []string{"Polygon", "Fantom", "BSC"} {
+		pub, _ := sleeve.GetPublicKey(network)
+		expected, _ := EthereumAddress(pub)
+		addr, err := sleeve.GetAddress(network)
+		if err != nil {
+			t.Fatalf("GetAddress(%s) returned error: %v", network, err)
+		}
+		if addr != expected {
+			t.Fatalf("GetAddress(%s) should use the Ethereum encoding. Got %s, expected %s", network, addr, expected)
+		}
+	}
+
+	// BSC shares Ethereum's coin type, so has the same address
+	bscAddr, _ := sleeve.GetAddress("BSC")
+	ethAddr, _ := sleeve.GetAddress("Ethereum")
+	if bscAddr != ethAddr {
+		t.Fatalf("GetAddress(BSC) should match GetAddress(Ethereum)")
+	}
+}
+
+func TestSingleSeedSleeve_GetWIF(t *testing.T) {
+	// Private key 1 with each network's version byte
+	key := make([]byte, 32)
+	key[31] = 1
+	expected := map[uint32]string{
+		CoinTypeBitcoin:  "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn",
+		CoinTypeLitecoin: "T33ydQRKp4FCW5LCLLUB7deioUMoveiwekdwUwyfRDeGZm76aUjV",
+		CoinTypeDogecoin: "QNcdLVw8fHkixm6NNyN6nVwxKek4u7qrioRbQmjxac5TVoTtZuot",
+		CoinTypeDash:     "XBHddvWWiMu3nZhhpTXBQWJMmdz5JNKJD85b9fgKAckCT2coW3Y4",
+	}
+	sleeve := &SingleSeedSleeve{networkKeys: make(map[string]*NetworkKey)}
+	for coinType, wif := range expected {
+		name := fmt.Sprintf("coin%d", coinType)
+		sleeve.networkKeys[name] = &NetworkKey{CoinType: coinType, Curve: CurveSecp256k1, Key: key}
+		got, err := sleeve.GetWIF(name)
+		if err != nil {
+			t.Fatalf("GetWIF(%s) returned error: %v", name, err)
+		}
+		if got != wif {
+			t.Fatalf("GetWIF(%s) returned %s, expected %s", name, got, wif)
+		}
+	}
+
+	// Derived key round trips
+	derived, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	wif, _ := derived.GetWIF("Bitcoin")
+	payload, version, err := base58.CheckDecode(wif)
+	privKey, _ := derived.GetPrivateKey("Bitcoin")
+	if err != nil || version != 0x80 || !bytes.Equal(payload, append(privKey, 0x01)) {
+		t.Fatalf("GetWIF(Bitcoin) doesn't encode the Bitcoin private key: %v", err)
+	}
+
+	if _, err := derived.GetWIF("Ethereum"); err == nil {
+		t.Fatalf("GetWIF() should return error for a non Bitcoin family network")
+	}
+	if _, err := derived.GetWIF("Unknown"); err == nil {
+		t.Fatalf("GetWIF() should return error for unknown network")
+	}
+}
+
+func TestSingleSeedSleeve_DeriveNetworkKeyWithParams(t *testing.T) {
+	// Address params override the coin type defaults, private key 1
+	key := make([]byte, 32)
+	key[31] = 1
+	expected := map[string]AddressParams{
+		"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH":         {Format: AddressFormatP2PKH, P2PKHVersion: 0x00},
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4": {Format: AddressFormatP2WPKH, HRP: "bc"},
+	}
+	for addr, params := range expected {
+		params := params
+		netKey := &NetworkKey{CoinType: CoinTypeBitcoin, Curve: CurveSecp256k1, Key: key, AddressParams: &params}
+		got, err := networkAddress(netKey)
+		if err != nil {
+			t.Fatalf("networkAddress() returned error: %v", err)
+		}
+		if got != addr {
+			t.Fatalf("networkAddress() returned wrong address. Got %s, expected %s", got, addr)
+		}
+	}
+
+	// A fork with its own version bytes on Bitcoin's derivation
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	params := AddressParams{Format: AddressFormatP2PKH, P2PKHVersion: 0x3C, WIFVersion: 0xBC}
+	if err := sleeve.DeriveNetworkKeyWithParams("Fork", CoinTypeBitcoin, params, seed); err != nil {
+		t.Fatalf("DeriveNetworkKeyWithParams() returned error: %v", err)
+	}
+	forkKey, _ := sleeve.GetPrivateKey("Fork")
+	btcKey, _ := sleeve.GetPrivateKey("Bitcoin")
+	if !bytes.Equal(forkKey, btcKey) {
+		t.Fatalf("DeriveNetworkKeyWithParams() should derive the same key as the coin type")
+	}
+	addr, _ := sleeve.GetAddress("Fork")
+	if _, version, err := base58.CheckDecode(addr); err != nil || version != 0x3C {
+		t.Fatalf("GetAddress(Fork) returned invalid address %q: %v", addr, err)
+	}
+	wif, _ := sleeve.GetWIF("Fork")
+	if payload, version, err := base58.CheckDecode(wif); err != nil || version != 0xBC ||
+		!bytes.Equal(payload, append(forkKey, 0x01)) {
+		t.Fatalf("GetWIF(Fork) returned invalid WIF %q: %v", wif, err)
+	}
+
+	// Bitcoin keeps its defaults
+	if btcAddr, _ := sleeve.GetAddress("Bitcoin"); btcAddr == "" || btcAddr == addr {
+		t.Fatalf("GetAddress(Bitcoin) should return the default P2PKH address, got %s", btcAddr)
+	}
+
+	// Params without a WIF version don't support WIF
+	_ = sleeve.DeriveNetworkKeyWithParams("NoWIF", CoinTypeLitecoin, AddressParams{Format: AddressFormatP2PKH}, seed)
+	if _, err := sleeve.GetWIF("NoWIF"); err == nil {
+		t.Fatalf("GetWIF() should return error for params without a WIF version")
+	}
+
+	invalid := []AddressParams{
+		{Format: AddressFormatP2WPKH},
+		{Format: AddressFormatP2WPKH, HRP: "BC"},
+		{Format: AddressFormat(42)},
+	}
+	for _, params := range invalid {
+		if err := sleeve.DeriveNetworkKeyWithParams("Invalid", CoinTypeBitcoin, params, seed); err == nil {
+			t.Fatalf("DeriveNetworkKeyWithParams() should return error for invalid params %+v", params)
+		}
+	}
+	if _, err := sleeve.GetPrivateKey("Invalid"); err == nil {
+		t.Fatalf("DeriveNetworkKeyWithParams() shouldn't store a key with invalid params")
+	}
+}
+
+func TestSingleSeedSleeve_GetSegWitAddress(t *testing.T) {
+	// Private key 1, HASH160 751e76e8199196d454941c45d1b3a323f1433bd6
+	key := make([]byte, 32)
+	key[31] = 1
+	newKey := func(coinType uint32) *NetworkKey {
+		return &NetworkKey{CoinType: coinType, Curve: CurveForCoinType(coinType), Key: key}
+	}
+	sleeve := &SingleSeedSleeve{networkKeys: map[string]*NetworkKey{
+		"Bitcoin":  newKey(CoinTypeBitcoin),
+		"Litecoin": newKey(CoinTypeLitecoin),
+		"Dogecoin": newKey(CoinTypeDogecoin),
+		"Ethereum": newKey(CoinTypeEthereum),
+	}}
+
+	expected := []struct {
+		network, hrp, address string
+	}{
+		{"Litecoin", "ltc", "ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n9"},
+		{"Litecoin", "", "ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n9"},
+		{"Bitcoin", "", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{"Bitcoin", "tb", "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx"},
+	}
+	for _, e := range expected {
+		addr, err := sleeve.GetSegWitAddress(e.network, e.hrp)
+		if err != nil {
+			t.Fatalf("GetSegWitAddress(%s, %q) returned error: %v", e.network, e.hrp, err)
+		}
+		if addr != e.address {
+			t.Fatalf("GetSegWitAddress(%s, %q) returned wrong address. Got %s, expected %s",
+				e.network, e.hrp, addr, e.address)
+		}
+	}
+
+	// The default address of Litecoin is unchanged
+	if addr, _ := networkAddress(sleeve.networkKeys["Litecoin"]); addr != "LVuDpNCSSj6pQ7t9Pv6d6sUkLKoqDEVUnJ" {
+		t.Fatalf("networkAddress(Litecoin) should return the P2PKH address, got %s", addr)
+	}
+
+	if _, err := sleeve.GetSegWitAddress("Dogecoin", ""); err == nil {
+		t.Fatalf("GetSegWitAddress() should return error for a network without SegWit")
+	}
+	if _, err := sleeve.GetSegWitAddress("Ethereum", "bc"); err == nil {
+		t.Fatalf("GetSegWitAddress() should return error for a non Bitcoin family network")
+	}
+	if _, err := sleeve.GetSegWitAddress("Litecoin", "LTC"); err == nil {
+		t.Fatalf("GetSegWitAddress() should return error for an uppercase HRP")
+	}
+	if _, err := sleeve.GetSegWitAddress("Unknown", "ltc"); err == nil {
+		t.Fatalf("GetSegWitAddress() should return error for unknown network")
+	}
+}
+
+func TestEOSKeys(t *testing.T) {
+	// Well known EOS development key pair
+	privKey, _ := hex.DecodeString("d2653ff7cbb2d8ff129ac27ef5781ce68b2558c41a74af1f2ddca635cbeef07d")
+	expectedPub := "EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV"
+	expectedWIF := "5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3"
+
+	if wif := EOSPrivateKeyWIF(privKey); wif != expectedWIF {
+		t.Fatalf("EOSPrivateKeyWIF() returned %s, expected %s", wif, expectedWIF)
+	}
+	compressed, _ := compressedPublicKey(privKey)
+	if pub := EOSPublicKey(compressed); pub != expectedPub {
+		t.Fatalf("EOSPublicKey() returned %s, expected %s", pub, expectedPub)
+	}
+
+	// Wired into GetAddress and GetWIF for the EOS coin type
+	sleeve := &SingleSeedSleeve{networkKeys: map[string]*NetworkKey{
+		"EOS": {Network: "EOS", CoinType: CoinTypeEOS, Curve: CurveForCoinType(CoinTypeEOS), Key: privKey},
+	}}
+	if addr, err := sleeve.GetAddress("EOS"); err != nil || addr != expectedPub {
+		t.Fatalf("GetAddress(EOS) returned %s, expected %s: %v", addr, expectedPub, err)
+	}
+	if wif, err := sleeve.GetWIF("EOS"); err != nil || wif != expectedWIF {
+		t.Fatalf("GetWIF(EOS) returned %s, expected %s: %v", wif, expectedWIF, err)
+	}
+}
+
+func TestTezosAddress(t *testing.T) {
+	// Tezos sandbox bootstrap1 account: ed25519 seed -> tz1 address
+	seed, _ := hex.DecodeString("8500c86780141917fcd8ac6a54a43a9eeda1aba9d263ce5dec5a1d0e5df1e598")
+	pub, _ := hex.DecodeString("4798d2cc98473d7e250c898885718afd2e4efbcb1a1595ab9730761ed830de0f")
+	expected := "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+
+	if addr := TezosAddress(pub); addr != expected {
+		t.Fatalf("TezosAddress() returned %s, expected %s", addr, expected)
+	}
+
+	if addr := TezosAddress(ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)); addr != expected {
+		t.Fatalf("TezosAddress() of the bootstrap1 seed returned %s, expected %s", addr, expected)
+	}
+
+	// Tezos keys are derived with SLIP-0010, so the key at the path of Tezos wallets,
+	// m/44'/1729'/account'/0', has the address the wallet shows for the mnemonic
+	walletSeed := mustSeed(slip10VectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(walletSeed, DefaultGenSpec())
+	if err := sleeve.DeriveFromPathString("Temple", "m/44'/1729'/0'/0'", walletSeed); err != nil {
+		t.Fatalf("DeriveFromPathString() returned error for a Tezos path: %v", err)
+	}
+	expected = "tz1VQA4RP4fLjEEMW2FR4pE9kAg5abb5h5GL"
+	if addr, err := sleeve.GetAddress("Temple"); err != nil || addr != expected {
+		t.Fatalf("GetAddress() returned %s for the first Tezos wallet account, expected %s: %v", addr, expected, err)
+	}
+	if err := sleeve.DeriveFromPathString("Invalid", "m/44'/1729'/0'/0", walletSeed); err == nil {
+		t.Fatalf("DeriveFromPathString() should return error for a non-hardened Tezos path")
+	}
+
+	// The sleeve's own Tezos key is the SLIP-0010 key at its path, whose address level is hardened
+	if err := sleeve.DeriveNetworkKey("Tezos", CoinTypeTezos, walletSeed); err != nil {
+		t.Fatalf("DeriveNetworkKey() returned error for Tezos: %v", err)
+	}
+	path := fmt.Sprintf("m/44'/1729'/0'/0'/%d'", sleeve.GetDerivationIndex())
+	if netKey := sleeve.GetAllNetworkKeys()["Tezos"]; netKey.Path != path || !netKey.HardenedAddressIndex {
+		t.Fatalf("DeriveNetworkKey() derived Tezos at %s, expected %s", netKey.Path, path)
+	}
+	parsed, _ := ParsePath(path)
+	node, _ := deriveEd25519NodeAtPath(walletSeed, parsed)
+	key, _ := sleeve.GetPrivateKey("Tezos")
+	if !bytes.Equal(key, node.Key) {
+		t.Fatalf("DeriveNetworkKey() Tezos key isn't the SLIP-0010 key at %s", path)
+	}
+	expected = TezosAddress(ed25519.NewKeyFromSeed(node.Key).Public().(ed25519.PublicKey))
+	if addr, err := sleeve.GetAddress("Tezos"); err != nil || addr != expected {
+		t.Fatalf("GetAddress(Tezos) returned %s, expected %s: %v", addr, expected, err)
+	}
+	if err := sleeve.RederiveAll(walletSeed); err != nil {
+		t.Fatalf("RederiveAll() returned error with a Tezos key: %v", err)
+	}
+	if _, err := sleeve.GetExtendedPublicKey("Tezos"); err == nil {
+		t.Fatalf("GetExtendedPublicKey() should return error for Tezos")
+	}
+}
+
+func TestNearImplicitAddress(t *testing.T) {
+	// Near docs example: public key ed25519:BGCCDDHfysuuVnaNVtEhhqeT4k9Muyem3Kpgq2U1m9HX
+	// has the implicit account 98793cd91a3f870fb126f66285808c7e094afcfc4eda8a970f6648cdf0dbd6de
+	pub := base58.Decode("BGCCDDHfysuuVnaNVtEhhqeT4k9Muyem3Kpgq2U1m9HX")
+	expected := "98793cd91a3f870fb126f66285808c7e094afcfc4eda8a970f6648cdf0dbd6de"
+	if addr := NearImplicitAddress(pub); addr != expected {
+		t.Fatalf("NearImplicitAddress() returned %s, expected %s", addr, expected)
+	}
+
+	// Near keys are derived with SLIP-0010, so the key at the path of Near wallets,
+	// m/44'/397'/0', has the implicit account the wallet shows for the mnemonic
+	seed := mustSeed(slip10VectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	if err := sleeve.DeriveFromPathString("NearWallet", "m/44'/397'/0'", seed); err != nil {
+		t.Fatalf("DeriveFromPathString() returned error for a Near path: %v", err)
+	}
+	expected = "5510e2b44cae6eb807e3e0e45d579dda058c274abcba15e5cb84636f5d1ee412"
+	if pub, err := sleeve.GetPublicKey("NearWallet"); err != nil || hex.EncodeToString(pub) != expected {
+		t.Fatalf("GetPublicKey() returned %x for the Near wallet account, expected %s: %v", pub, expected, err)
+	}
+	addr, err := sleeve.GetAddress("NearWallet")
+	if err != nil || addr != expected {
+		t.Fatalf("GetAddress() returned %s for the Near wallet account, expected %s: %v", addr, expected, err)
+	}
+	if len(addr) != 64 || strings.ToLower(addr) != addr {
+		t.Fatalf("GetAddress(Near) should return 64 lowercase hex characters, got %s", addr)
+	}
+
+	// The sleeve's own Near key is the SLIP-0010 key at its path, whose address level is hardened
+	if err := sleeve.DeriveNetworkKey("Near", CoinTypeNear, seed); err != nil {
+		t.Fatalf("DeriveNetworkKey() returned error for Near: %v", err)
+	}
+	path, _ := ParsePath(fmt.Sprintf("m/44'/397'/0'/0'/%d'", sleeve.GetDerivationIndex()))
+	node, _ := deriveEd25519NodeAtPath(seed, path)
+	expected = NearImplicitAddress(ed25519.NewKeyFromSeed(node.Key).Public().(ed25519.PublicKey))
+	if addr, err := sleeve.GetAddress("Near"); err != nil || addr != expected {
+		t.Fatalf("GetAddress(Near) returned %s, expected the SLIP-0010 key's %s: %v", addr, expected, err)
+	}
+}
+
+func TestZcashTransparentAddress(t *testing.T) {
+	// secp256k1 private key 1: its HASH160 is the one of Bitcoin's 1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH
+	key, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000001")
+	pub, _ := hex.DecodeString("0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	expected := "t1UYsZVJkLPeMjxEtACvSxfWuNmddpWfxzs"
+
+	addr := ZcashTransparentAddress(pub)
+	if addr != expected {
+		t.Fatalf("ZcashTransparentAddress() returned %s, expected %s", addr, expected)
+	}
+
+	// Decoding gives back the two byte version and the public key hash
+	decoded, version, err := base58.CheckDecode(addr)
+	if err != nil {
+		t.Fatalf("base58.CheckDecode(%s) returned error: %v", addr, err)
+	}
+	if version != 0x1C || decoded[0] != 0xB8 {
+		t.Fatalf("ZcashTransparentAddress() has version %02x%02x, expected 1cb8", version, decoded[0])
+	}
+	if !bytes.Equal(decoded[1:], hash160(pub)) {
+		t.Fatalf("ZcashTransparentAddress() encodes %x, expected %x", decoded[1:], hash160(pub))
+	}
+
+	// Zcash network keys are secp256k1 keys, wired into GetAddress
+	sleeve := &SingleSeedSleeve{networkKeys: map[string]*NetworkKey{
+		"Zcash": {Network: "Zcash", CoinType: CoinTypeZcash, Curve: CurveForCoinType(CoinTypeZcash), Key: key},
+	}}
+	if addr, err := sleeve.GetAddress("Zcash"); err != nil || addr != expected {
+		t.Fatalf("GetAddress(Zcash) returned %s, expected %s: %v", addr, expected, err)
+	}
+}
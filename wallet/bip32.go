@@ -8,13 +8,15 @@ package wallet
 
 import (
 	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/xx-labs/sleeve/hasher"
+	"golang.org/x/crypto/ripemd160"
 )
 
 const (
@@ -126,11 +128,10 @@ func (n *Node) Child(idx uint32) (*Node, error) {
 	}
 
 	// Derive public key from private key using secp256k1
-	privKey, err := crypto.ToECDSA(n.Key)
+	pubKey, err := compressedPublicKey(n.Key)
 	if err != nil {
 		return nil, err
 	}
-	pubKey := crypto.CompressPubkey(&privKey.PublicKey)
 
 	// convert idx to bytes
 	idxBytes := make([]byte, 4)
@@ -172,6 +173,68 @@ func (n *Node) Child(idx uint32) (*Node, error) {
 	return childNode, nil
 }
 
+// Compute the hardened child node with given index
+// Returns a new Node without mutating the parent
+func (n *Node) HardenedChild(idx uint32) (*Node, error) {
+	child := &Node{
+		Key:  append([]byte{}, n.Key...),
+		Code: append([]byte{}, n.Code...),
+	}
+	if err := child.ComputeHardenedChild(idx); err != nil {
+		return nil, err
+	}
+	return child, nil
+}
+
+// Get the compressed secp256k1 public key of the node
+func (n *Node) PublicKey() ([]byte, error) {
+	return compressedPublicKey(n.Key)
+}
+
+// Get the node fingerprint: first 4 bytes of RIPEMD160(SHA256(public key))
+func (n *Node) Fingerprint() ([]byte, error) {
+	pub, err := n.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	sha := sha256.Sum256(pub)
+	h := ripemd160.New()
+	h.Write(sha[:])
+	return h.Sum(nil)[:4], nil
+}
+
+// Version bytes of a mainnet extended public key
+const xpubVersion = uint32(0x0488B21E)
+
+// Serialize the node as a base58check encoded extended public key (xpub)
+// Depth, parent fingerprint and child number describe the node position in the tree
+func (n *Node) ExtendedPublicKey(depth uint8, parentFingerprint []byte, childNumber uint32) (string, error) {
+	if len(parentFingerprint) != 4 {
+		return "", errors.New("parent fingerprint must be 4 bytes")
+	}
+	pub, err := n.PublicKey()
+	if err != nil {
+		return "", err
+	}
+
+	// version (4) || depth (1) || parent fingerprint (4) || child number (4) || chain code (32) || public key (33)
+	data := make([]byte, 9, 78+4)
+	binary.BigEndian.PutUint32(data[:4], xpubVersion)
+	data[4] = depth
+	copy(data[5:9], parentFingerprint)
+	idxBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(idxBytes, childNumber)
+	data = append(data, idxBytes...)
+	data = append(data, n.Code...)
+	data = append(data, pub...)
+
+	// Append double SHA256 checksum
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	data = append(data, second[:4]...)
+	return base58.Encode(data), nil
+}
+
 // Validate Private Key
 func validatePrivateKey(keyBytes []byte) error {
 	key := big.NewInt(0).SetBytes(keyBytes)
@@ -186,11 +249,14 @@ func validatePrivateKey(keyBytes []byte) error {
 	return nil
 }
 
+// Returned when a derived key is 0, in which case BIP32 proceeds with the next index
+var errZeroKey = errors.New("validateKeyNotZero: key is 0")
+
 // Check if Key is 0
 func validateKeyNotZero(key *big.Int) error {
 	// Check if key = 0
 	if len(key.Bits()) == 0 {
-		return errors.New("validateKeyNotZero: key is 0")
+		return errZeroKey
 	}
 	return nil
 }
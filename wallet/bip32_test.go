@@ -221,6 +221,44 @@ func TestVectorThree(t *testing.T) {
 	}
 }
 
+// Test vector 1 m/0H extended public key
+const vectorOneHardZeroPub = "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"
+
+func TestNode_ExtendedPublicKey(t *testing.T) {
+	seed, _ := hex.DecodeString(vectorOneSeed)
+	master, _ := NewMasterNode(seed)
+
+	// HardenedChild doesn't mutate the parent
+	masterKey := append([]byte{}, master.Key...)
+	child, err := master.HardenedChild(firstHardened)
+	if err != nil {
+		t.Fatalf("HardenedChild() returned error: %v", err)
+	}
+	if !bytes.Equal(master.Key, masterKey) {
+		t.Fatalf("HardenedChild() mutated the parent node")
+	}
+
+	fingerprint, err := master.Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint() returned error: %v", err)
+	}
+	if hex.EncodeToString(fingerprint) != "3442193e" {
+		t.Fatalf("Fingerprint() returned wrong value: %x", fingerprint)
+	}
+
+	xpub, err := child.ExtendedPublicKey(1, fingerprint, firstHardened)
+	if err != nil {
+		t.Fatalf("ExtendedPublicKey() returned error: %v", err)
+	}
+	if xpub != vectorOneHardZeroPub {
+		t.Fatalf("Failed test vector 1. Got m/0H xpub %s, expected %s", xpub, vectorOneHardZeroPub)
+	}
+
+	if _, err := child.ExtendedPublicKey(1, []byte{1}, firstHardened); err == nil {
+		t.Fatalf("ExtendedPublicKey() should return error for invalid parent fingerprint")
+	}
+}
+
 // Generated test vector that has child key with leading zero byte
 const (
 	leadingZeroSeed = "6772b1242f27082a377b7bb2b22835efa2385eb936b37add89516a9484bca6dfcf423bd2bf53d7c259d1726684048344a70be3da87185854ca42f960d2e45ac2"
@@ -9,6 +9,8 @@ package wallet
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -60,10 +62,87 @@ func ComputeNode(seed []byte, path Path) (*Node, error) {
 	return n, nil
 }
 
+// Maximum number of elements of a BIP32 path, since depth is serialized in 1 byte
+const maxPathDepth = 255
+
+// Derive the BIP32 node at any path from a seed, returning its private key and chain code
+// Unlike ComputeNode, which is restricted to the 5 hardened elements of the quantum path,
+// the path can have any length up to 255 and mix hardened (>= 2^31) and non-hardened elements,
+// so custom derivation schemes can be built without reimplementing BIP32.
+// Returned slices are copies owned by the caller
+func DeriveRaw(path []uint32, seed []byte) (key, chainCode []byte, err error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("DeriveRaw: path can't be empty")
+	}
+	if len(path) > maxPathDepth {
+		return nil, nil, fmt.Errorf("DeriveRaw: path is too deep: %d elements, maximum %d", len(path), maxPathDepth)
+	}
+	node, err := deriveNodeAtPath(seed, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	key = append([]byte{}, node.Key...)
+	chainCode = append([]byte{}, node.Code...)
+	return key, chainCode, nil
+}
+
+// Check whether two paths have the same elements
+func (p Path) Equal(other Path) bool {
+	if len(p) != len(other) {
+		return false
+	}
+	for i := range p {
+		if p[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (p Path) String() string {
 	str := "m"
 	for _, val := range p {
-		str += fmt.Sprintf("/%d'", val ^ firstHardened)
+		if val >= firstHardened {
+			str += fmt.Sprintf("/%d'", val^firstHardened)
+		} else {
+			str += fmt.Sprintf("/%d", val)
+		}
 	}
 	return str
 }
+
+// Parse a textual BIP32 path, e.g. m/44'/60'/0'/0/5
+// Hardened elements are marked with a trailing ', h or H
+func ParsePath(str string) (Path, error) {
+	parts := strings.Split(strings.TrimSpace(str), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("ParsePath: path must start with \"m\": %q", str)
+	}
+	if len(parts) == 1 {
+		return nil, errors.New("ParsePath: path has no elements")
+	}
+
+	p := make(Path, len(parts)-1)
+	for i, part := range parts[1:] {
+		hardened := false
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H") {
+			hardened = true
+			part = part[:len(part)-1]
+		}
+		if part == "" {
+			return nil, fmt.Errorf("ParsePath: empty element %d in path %q", i+1, str)
+		}
+		val, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || part[0] == '+' {
+			return nil, fmt.Errorf("ParsePath: invalid element %q in path %q", parts[i+1], str)
+		}
+		if uint32(val) >= firstHardened {
+			return nil, fmt.Errorf("ParsePath: element %q in path %q must be less than 2^31", parts[i+1], str)
+		}
+		p[i] = uint32(val)
+		if hardened {
+			p[i] |= firstHardened
+		}
+	}
+	return p, nil
+}
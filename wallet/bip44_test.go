@@ -7,6 +7,7 @@
 package wallet
 
 import (
+	"bytes"
 	"crypto/rand"
 	"testing"
 )
@@ -111,3 +112,100 @@ func TestComputeNode(t *testing.T) {
 		t.Fatalf("ComputeNode() should not return error for valid seed and path")
 	}
 }
+
+func TestDeriveRaw(t *testing.T) {
+	seed := make([]byte, 64)
+	_, _ = rand.Read(seed)
+
+	// Quantum paths match ComputeNode
+	path, _ := NewPath(0, 0, 0)
+	node, _ := ComputeNode(seed, path)
+	key, chainCode, err := DeriveRaw(path, seed)
+	if err != nil {
+		t.Fatalf("DeriveRaw() returned error: %v", err)
+	}
+	if !bytes.Equal(key, node.Key) || !bytes.Equal(chainCode, node.Code) {
+		t.Fatalf("DeriveRaw() should match ComputeNode() for the quantum path")
+	}
+
+	// Network paths, with a non-hardened address level, match network keys
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	netKey := sleeve.GetAllNetworkKeys()["Ethereum"]
+	key, _, err = DeriveRaw(netKey.path, seed)
+	if err != nil {
+		t.Fatalf("DeriveRaw() returned error: %v", err)
+	}
+	if !bytes.Equal(key, netKey.Key) {
+		t.Fatalf("DeriveRaw() should match the network key at %s", netKey.Path)
+	}
+
+	// Returned slices are copies
+	key[0] ^= 0xFF
+	if again, _, _ := DeriveRaw(netKey.path, seed); bytes.Equal(again, key) {
+		t.Fatalf("DeriveRaw() should return a copy of the key")
+	}
+
+	// Errors
+	if _, _, err := DeriveRaw(nil, seed); err == nil {
+		t.Fatalf("DeriveRaw() should return error for an empty path")
+	}
+	if _, _, err := DeriveRaw(make([]uint32, maxPathDepth+1), seed); err == nil {
+		t.Fatalf("DeriveRaw() should return error for a path deeper than 255")
+	}
+	if _, _, err := DeriveRaw(path, nil); err == nil {
+		t.Fatalf("DeriveRaw() should return error when seed is nil")
+	}
+}
+
+func TestPath_StringNonHardened(t *testing.T) {
+	// Test path mixing hardened and non-hardened indexes
+	p := Path{purpose, 60 | firstHardened, firstHardened, firstHardened, 5}
+
+	expected := "m/44'/60'/0'/0'/5"
+	str := p.String()
+	if str != expected {
+		t.Fatalf("Path.String() returned incorrect string. Expected %s. Got %s", expected, str)
+	}
+}
+
+func TestPath_Equal(t *testing.T) {
+	path := networkPath(CoinTypeEthereum, 0, 0, 5)
+	if !path.Equal(networkPath(CoinTypeEthereum, 0, 0, 5)) {
+		t.Fatalf("Equal() should return true for the same path")
+	}
+	if path.Equal(networkPath(CoinTypeEthereum, 0, 0, 5|firstHardened)) {
+		t.Fatalf("Equal() should return false for a differently hardened path")
+	}
+	if path.Equal(path[:4]) || path[:4].Equal(path) {
+		t.Fatalf("Equal() should return false for a prefix")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	expected := Path{purpose, 60 | firstHardened, firstHardened, 0, 5}
+	for _, str := range []string{"m/44'/60'/0'/0/5", "m/44h/60h/0h/0/5", "m/44H/60'/0h/0/5", " m/44'/60'/0'/0/5 "} {
+		p, err := ParsePath(str)
+		if err != nil {
+			t.Fatalf("ParsePath(%q) returned error: %s", str, err)
+		}
+		if p.String() != expected.String() {
+			t.Fatalf("ParsePath(%q) returned wrong path. Expected %s. Got %s", str, expected, p)
+		}
+	}
+
+	// Round trip
+	p, _ := ParsePath(expected.String())
+	if p.String() != expected.String() {
+		t.Fatalf("ParsePath() doesn't round trip Path.String()")
+	}
+
+	// Malformed paths
+	for _, str := range []string{
+		"", "m", "44'/60'", "M/44'", "m/44'//0", "m/44'/60'/", "m\\44'\\60'", "m/44''", "m/abc", "m/-1",
+		"m/+1", "m/2147483648", "m/2147483648'", "m/4294967296", "m/44'/0x3c'",
+	} {
+		if _, err := ParsePath(str); err == nil {
+			t.Fatalf("ParsePath(%q) should return error", str)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+
+	"github.com/tyler-smith/go-bip39"
+	"github.com/xx-labs/sleeve/hasher"
+)
+
+// BIP85 deterministic entropy: child entropy is derived at m/83696968'/{app}'/...
+// and computed as HMAC-SHA512(key="bip-entropy-from-k", msg=private key of the node)
+const (
+	bip85Purpose     = uint32(83696968) | firstHardened
+	bip85AppBIP39    = uint32(39) | firstHardened
+	bip85LangEnglish = uint32(0) | firstHardened
+	bip85EntropyKey  = "bip-entropy-from-k"
+	// Number of words of mnemonics derived by RotateMnemonic
+	rotationWords = 24
+)
+
+// Derive BIP85 entropy from the node at path under a master node
+func bip85Entropy(master *Node, path Path) ([]byte, error) {
+	node := master
+	var err error
+	for i, idx := range path {
+		if node, err = node.HardenedChild(idx); err != nil {
+			return nil, fmt.Errorf("failed to derive element %d of path %s: %v", i+1, path, err)
+		}
+	}
+	h := hmac.New(hasher.SHA2_512.New, []byte(bip85EntropyKey))
+	h.Write(node.Key)
+	return h.Sum(nil), nil
+}
+
+// Derive the BIP85 English BIP39 mnemonic of the given index under a master node
+// m/83696968'/39'/0'/{words}'/{index}', using the first words*4/3 bytes of entropy
+func bip85Mnemonic(master *Node, words, index uint32) (string, error) {
+	if index >= firstHardened {
+		return "", fmt.Errorf("invalid BIP85 index %d: must be less than 2^31", index)
+	}
+	path := Path{bip85Purpose, bip85AppBIP39, bip85LangEnglish, words | firstHardened, index | firstHardened}
+	entropy, err := bip85Entropy(master, path)
+	if err != nil {
+		return "", err
+	}
+	defer zero(entropy)
+	return bip39.NewMnemonic(entropy[:words*4/3])
+}
+
+// Derive a new 24 word mnemonic from the sleeve's seed with BIP85, at index counter
+// The mnemonic is that of a successor wallet for scheduled key rotation: it's reproduced
+// from the sleeve's mnemonic and passphrase with the same counter, so only the master needs
+// a backup, while each counter gives an independent wallet. Fails if the sleeve was wiped
+func (s *SingleSeedSleeve) RotateMnemonic(counter uint32) (string, error) {
+	if s.seed == nil {
+		return "", errors.New("sleeve seed is not available - was the sleeve wiped?")
+	}
+	master, err := NewMasterNode(s.seed)
+	if err != nil {
+		return "", fmt.Errorf("failed to create master node: %v", err)
+	}
+	return bip85Mnemonic(master, rotationWords, counter)
+}
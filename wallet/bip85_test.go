@@ -0,0 +1,108 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Master key of the BIP85 test vectors
+const bip85TestVectorXprv = "xprv9s21ZrQH143K2LBWUUQRFXhucrQqBpKdRRxNVq2zBqsx8HVqFk2uYo8kmbaLLHRdqtQpUm98uKfu3vca1LqdGhUtyoFnCNkfmXRyPXLjbKb"
+
+// Get the master node of an xprv
+func masterNodeFromXprv(t *testing.T, xprv string) *Node {
+	data, _, err := base58.CheckDecode(xprv)
+	if err != nil {
+		t.Fatalf("base58.CheckDecode() returned error: %v", err)
+	}
+	// version (remaining 3 bytes) || depth || fingerprint || child number || chain code || 0x00 || key
+	return &Node{Code: data[12:44], Key: data[45:77]}
+}
+
+func TestBIP85Entropy(t *testing.T) {
+	master := masterNodeFromXprv(t, bip85TestVectorXprv)
+
+	// BIP85 test case 1: m/83696968'/0'/0'
+	entropy, err := bip85Entropy(master, Path{bip85Purpose, firstHardened, firstHardened})
+	if err != nil {
+		t.Fatalf("bip85Entropy() returned error: %v", err)
+	}
+	expected := "efecfbccffea313214232d29e71563d941229afb4338c21f9517c41aaa0d16f0" +
+		"0b83d2a09ef747e7a64e8e2bd5a14869e693da66ce94ac2da570ab7ee48618f7"
+	if hex.EncodeToString(entropy) != expected {
+		t.Fatalf("bip85Entropy() returned %x, expected %s", entropy, expected)
+	}
+}
+
+func TestBIP85Mnemonic(t *testing.T) {
+	master := masterNodeFromXprv(t, bip85TestVectorXprv)
+
+	// BIP85 BIP39 application vectors, English, index 0
+	tests := map[uint32]string{
+		12: "girl mad pet galaxy egg matter matrix prison refuse sense ordinary nose",
+		24: "puppy ocean match cereal symbol another shed magic wrap hammer bulb intact gadget divorce " +
+			"twin tonight reason outdoor destroy simple truth cigar social volcano",
+	}
+	for words, expected := range tests {
+		mnemonic, err := bip85Mnemonic(master, words, 0)
+		if err != nil {
+			t.Fatalf("bip85Mnemonic() returned error: %v", err)
+		}
+		if mnemonic != expected {
+			t.Fatalf("bip85Mnemonic() returned wrong %d word mnemonic: %s, expected %s", words, mnemonic, expected)
+		}
+	}
+
+	if _, err := bip85Mnemonic(master, 24, firstHardened); err == nil {
+		t.Fatalf("bip85Mnemonic() should return error for index >= 2^31")
+	}
+}
+
+func TestSingleSeedSleeve_RotateMnemonic(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+
+	seen := map[string]bool{sleeve.GetMnemonic(): true}
+	for counter := uint32(0); counter < 3; counter++ {
+		mnemonic, err := sleeve.RotateMnemonic(counter)
+		if err != nil {
+			t.Fatalf("RotateMnemonic() returned error: %v", err)
+		}
+		if !bip39.IsMnemonicValid(mnemonic) || len(strings.Fields(mnemonic)) != 24 {
+			t.Fatalf("RotateMnemonic() returned an invalid 24 word mnemonic: %s", mnemonic)
+		}
+		if seen[mnemonic] {
+			t.Fatalf("RotateMnemonic() should return a distinct mnemonic for each counter")
+		}
+		seen[mnemonic] = true
+
+		// The same counter reproduces the mnemonic from the master backup
+		recovered, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+		if again, _ := recovered.RotateMnemonic(counter); again != mnemonic {
+			t.Fatalf("RotateMnemonic() should reproduce the mnemonic for counter %d", counter)
+		}
+	}
+
+	// The passphrase is part of the master
+	withPassphrase, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "passphrase", DefaultGenSpec())
+	m0, _ := sleeve.RotateMnemonic(0)
+	if other, _ := withPassphrase.RotateMnemonic(0); other == m0 {
+		t.Fatalf("RotateMnemonic() should depend on the passphrase")
+	}
+
+	if _, err := sleeve.RotateMnemonic(firstHardened); err == nil {
+		t.Fatalf("RotateMnemonic() should return error for counter >= 2^31")
+	}
+	sleeve.Wipe()
+	if _, err := sleeve.RotateMnemonic(0); err == nil {
+		t.Fatalf("RotateMnemonic() should return error after Wipe")
+	}
+}
@@ -0,0 +1,127 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// EIP-2333 BLS12-381 key derivation, used for Ethereum staking keys
+const (
+	// Size of BLS secret keys, and of the hashes of the Lamport tree
+	blsKeySize = 32
+	// Number of 32 byte chunks of a Lamport secret key: 255 * 32 = 8160 bytes
+	blsLamportChunks = 255
+	// Minimum seed size of derive_master_SK
+	blsMinSeedSize = 32
+	// Output size of HKDF_mod_r, 48 bytes to reduce the modulo bias
+	blsOKMSize = 48
+	// Initial salt of HKDF_mod_r
+	blsKeyGenSalt = "BLS-SIG-KEYGEN-SALT-"
+	// EIP-2334 purpose and coin type levels of Ethereum validator keys
+	blsPurpose  = 12381
+	blsCoinType = 3600
+)
+
+// Order r of the BLS12-381 subgroup, secret keys are in [1, r)
+var blsCurveOrder, _ = new(big.Int).SetString(
+	"73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// Derive the BLS12-381 signing key of an Ethereum validator from a BIP39 seed
+// Keys are derived with the EIP-2333 tree at the EIP-2334 path m/12381/3600/{index}/0/0,
+// as by the Ethereum staking deposit CLI, and returned as a 32 byte big endian secret.
+// The BLS curve and Lamport tree are unrelated to BIP32, so no other network key is involved
+func DeriveBLSValidatorKey(index uint32, seed []byte) ([]byte, error) {
+	sk, err := blsMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, childIndex := range []uint32{blsPurpose, blsCoinType, index, 0, 0} {
+		sk = blsChildKey(sk, childIndex)
+	}
+	key := sk.FillBytes(make([]byte, blsKeySize))
+	sk.SetInt64(0)
+	return key, nil
+}
+
+// Derive the EIP-2333 master secret key of a seed: derive_master_SK
+func blsMasterKey(seed []byte) (*big.Int, error) {
+	if len(seed) < blsMinSeedSize {
+		return nil, fmt.Errorf("BLS seed must have at least %d bytes, got %d", blsMinSeedSize, len(seed))
+	}
+	return blsHKDFModR(seed)
+}
+
+// Derive an EIP-2333 child secret key: derive_child_SK
+func blsChildKey(parent *big.Int, index uint32) *big.Int {
+	compressedPK := blsLamportPK(parent, index)
+	// HKDF_mod_r only fails when reading HKDF output, which can't happen for 48 bytes
+	sk, _ := blsHKDFModR(compressedPK)
+	return sk
+}
+
+// Compute the compressed Lamport public key of a parent secret key: parent_SK_to_lamport_PK
+func blsLamportPK(parent *big.Int, index uint32) []byte {
+	salt := make([]byte, 4)
+	binary.BigEndian.PutUint32(salt, index)
+	ikm := parent.FillBytes(make([]byte, blsKeySize))
+	notIKM := make([]byte, blsKeySize)
+	for i, b := range ikm {
+		notIKM[i] = ^b
+	}
+
+	lamportPK := sha256.New()
+	for _, secret := range [][]byte{ikm, notIKM} {
+		lamportSK := blsLamportSK(secret, salt)
+		for i := 0; i < blsLamportChunks; i++ {
+			chunk := sha256.Sum256(lamportSK[i*blsKeySize : (i+1)*blsKeySize])
+			lamportPK.Write(chunk[:])
+		}
+		zero(lamportSK)
+	}
+	zero(ikm)
+	zero(notIKM)
+	return lamportPK.Sum(nil)
+}
+
+// Expand key material to a Lamport secret key of 255 chunks: IKM_to_lamport_SK
+func blsLamportSK(ikm, salt []byte) []byte {
+	okm := make([]byte, blsLamportChunks*blsKeySize)
+	// 8160 bytes is within the 255 * 32 bytes HKDF-SHA256 can expand
+	_, _ = io.ReadFull(hkdf.New(sha256.New, ikm, salt, nil), okm)
+	return okm
+}
+
+// Hash key material to a non-zero secret key modulo r: HKDF_mod_r with an empty key_info
+func blsHKDFModR(ikm []byte) (*big.Int, error) {
+	salt := []byte(blsKeyGenSalt)
+	// IKM || I2OSP(0, 1) and key_info || I2OSP(L, 2)
+	input := append(append([]byte{}, ikm...), 0)
+	defer zero(input)
+	info := []byte{0, blsOKMSize}
+
+	sk := new(big.Int)
+	okm := make([]byte, blsOKMSize)
+	defer zero(okm)
+	for sk.Sign() == 0 {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		if _, err := io.ReadFull(hkdf.New(sha256.New, input, salt, info), okm); err != nil {
+			return nil, errors.New("failed to expand BLS key material")
+		}
+		sk.SetBytes(okm)
+		sk.Mod(sk, blsCurveOrder)
+	}
+	return sk, nil
+}
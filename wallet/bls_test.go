@@ -0,0 +1,100 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+// EIP-2333 test vectors
+var blsTestVectors = []struct {
+	seed     string
+	masterSK string
+	index    uint32
+	childSK  string
+}{
+	{
+		seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		masterSK: "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+		index:    0,
+		childSK:  "20397789859736650942317412262472558107875392172444076792671091975210932703118",
+	},
+	{
+		seed:     "3141592653589793238462643383279502884197169399375105820974944592",
+		masterSK: "29757020647961307431480504535336562678282505419141012933316116377660817309383",
+		index:    3141592653,
+		childSK:  "25457201688850691947727629385191704516744796114925897962676248250929345014287",
+	},
+	{
+		seed:     "0099ff991111002299dd7744ee3355bbdd8844115566cc55663355668888cc00",
+		masterSK: "27580842291869792442942448775674722299803720648445448686099262467207037398656",
+		index:    4294967295,
+		childSK:  "29358610794459428860402234341874281240803786294062035874021252734817515685787",
+	},
+	{
+		seed:     "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+		masterSK: "19022158461524446591288038168518313374041767046816487870552872741050760015818",
+		index:    42,
+		childSK:  "31372231650479070279774297061823572166496564838472787488249775572789064611981",
+	},
+}
+
+func TestBLSKeyDerivation(t *testing.T) {
+	for i, vector := range blsTestVectors {
+		seed, _ := hex.DecodeString(vector.seed)
+		master, err := blsMasterKey(seed)
+		if err != nil {
+			t.Fatalf("blsMasterKey() returned error for vector %d: %v", i, err)
+		}
+		if master.String() != vector.masterSK {
+			t.Fatalf("blsMasterKey() returned wrong key for vector %d. Got %s, expected %s",
+				i, master, vector.masterSK)
+		}
+		if child := blsChildKey(master, vector.index); child.String() != vector.childSK {
+			t.Fatalf("blsChildKey() returned wrong key for vector %d. Got %s, expected %s",
+				i, child, vector.childSK)
+		}
+	}
+
+	if _, err := blsMasterKey(make([]byte, 31)); err == nil {
+		t.Fatalf("blsMasterKey() should return error for a seed shorter than 32 bytes")
+	}
+}
+
+func TestDeriveBLSValidatorKey(t *testing.T) {
+	seed, _ := hex.DecodeString(blsTestVectors[0].seed)
+	key, err := DeriveBLSValidatorKey(1, seed)
+	if err != nil {
+		t.Fatalf("DeriveBLSValidatorKey() returned error: %v", err)
+	}
+	if len(key) != blsKeySize {
+		t.Fatalf("DeriveBLSValidatorKey() returned %d bytes, expected %d", len(key), blsKeySize)
+	}
+
+	// The key is at m/12381/3600/1/0/0
+	sk, _ := blsMasterKey(seed)
+	for _, index := range []uint32{12381, 3600, 1, 0, 0} {
+		sk = blsChildKey(sk, index)
+	}
+	if new(big.Int).SetBytes(key).Cmp(sk) != 0 {
+		t.Fatalf("DeriveBLSValidatorKey() isn't the key at m/12381/3600/1/0/0")
+	}
+	if sk.Sign() == 0 || sk.Cmp(blsCurveOrder) >= 0 {
+		t.Fatalf("DeriveBLSValidatorKey() returned a key out of range")
+	}
+
+	// Each validator index has its own key
+	other, _ := DeriveBLSValidatorKey(2, seed)
+	if hex.EncodeToString(other) == hex.EncodeToString(key) {
+		t.Fatalf("DeriveBLSValidatorKey() should return different keys for different indexes")
+	}
+	if _, err := DeriveBLSValidatorKey(0, seed[:16]); err == nil {
+		t.Fatalf("DeriveBLSValidatorKey() should return error for a short seed")
+	}
+}
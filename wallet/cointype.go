@@ -0,0 +1,91 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CoinType is a BIP44 coin type, as registered in SLIP-0044
+// The CoinType constants are untyped uint32 values, so CoinType(CoinTypeSolana)
+// gives the typed value of a known network
+type CoinType uint32
+
+// Names of the known coin types, in the order returned by CoinTypes
+// CoinTypeBSC isn't listed, since it's the same value as CoinTypeEthereum
+var coinTypeNames = []struct {
+	coinType CoinType
+	name     string
+}{
+	{CoinType(CoinTypeBitcoin), "Bitcoin"},
+	{CoinType(CoinTypeLitecoin), "Litecoin"},
+	{CoinType(CoinTypeDogecoin), "Dogecoin"},
+	{CoinType(CoinTypeDash), "Dash"},
+	{CoinType(CoinTypeEthereum), "Ethereum"},
+	{CoinType(CoinTypeEthereumClassic), "EthereumClassic"},
+	{CoinType(CoinTypeCosmos), "Cosmos"},
+	{CoinType(CoinTypeZcash), "Zcash"},
+	{CoinType(CoinTypeStellar), "Stellar"},
+	{CoinType(CoinTypeEOS), "EOS"},
+	{CoinType(CoinTypePolkadot), "Polkadot"},
+	{CoinType(CoinTypeNear), "Near"},
+	{CoinType(CoinTypeSolana), "Solana"},
+	{CoinType(CoinTypePolygon), "Polygon"},
+	{CoinType(CoinTypeFantom), "Fantom"},
+	{CoinType(CoinTypeTezos), "Tezos"},
+	{CoinType(CoinTypeCardano), "Cardano"},
+	{CoinType(CoinTypeAvalanche), "Avalanche"},
+}
+
+// Returns the network name of a known coin type, or its number otherwise
+func (c CoinType) String() string {
+	for _, known := range coinTypeNames {
+		if known.coinType == c {
+			return known.name
+		}
+	}
+	return strconv.FormatUint(uint64(c), 10)
+}
+
+// Get the curve used by the network of the coin type
+func (c CoinType) Curve() Curve {
+	return CurveForCoinType(uint32(c))
+}
+
+// Get all known coin types, sorted by number
+func CoinTypes() []CoinType {
+	coinTypes := make([]CoinType, len(coinTypeNames))
+	for i, known := range coinTypeNames {
+		coinTypes[i] = known.coinType
+	}
+	return coinTypes
+}
+
+// Parse a coin type from its network name, case insensitive, or its number
+// "BSC" is accepted as an alias of Ethereum. Numbers must be less than 2^31,
+// so the coin type fits in a hardened path element
+func ParseCoinType(s string) (CoinType, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "BSC") {
+		return CoinType(CoinTypeBSC), nil
+	}
+	for _, known := range coinTypeNames {
+		if strings.EqualFold(s, known.name) {
+			return known.coinType, nil
+		}
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unknown coin type %q", s)
+	}
+	if n >= uint64(firstHardened) {
+		return 0, fmt.Errorf("coin type %d must be less than 2^31", n)
+	}
+	return CoinType(n), nil
+}
@@ -0,0 +1,86 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCoinTypes(t *testing.T) {
+	coinTypes := CoinTypes()
+	if len(coinTypes) != len(coinTypeNames) {
+		t.Fatalf("CoinTypes() returned %d coin types, expected %d", len(coinTypes), len(coinTypeNames))
+	}
+	for i, coinType := range coinTypes {
+		if i > 0 && coinType <= coinTypes[i-1] {
+			t.Fatalf("CoinTypes() should be sorted, got %d after %d", coinType, coinTypes[i-1])
+		}
+		// Every name round trips, in any case
+		for _, s := range []string{coinType.String(), strings.ToLower(coinType.String()), strings.ToUpper(coinType.String())} {
+			parsed, err := ParseCoinType(s)
+			if err != nil {
+				t.Fatalf("ParseCoinType(%q) returned error: %v", s, err)
+			}
+			if parsed != coinType {
+				t.Fatalf("ParseCoinType(%q) returned %d, expected %d", s, parsed, coinType)
+			}
+		}
+	}
+
+	// Modifying the returned slice doesn't change the known coin types
+	coinTypes[0] = 12345
+	if CoinTypes()[0] != CoinType(CoinTypeBitcoin) {
+		t.Fatalf("CoinTypes() should return a copy")
+	}
+}
+
+func TestCoinType_String(t *testing.T) {
+	tests := map[CoinType]string{
+		CoinType(CoinTypeBitcoin):  "Bitcoin",
+		CoinType(CoinTypeEthereum): "Ethereum",
+		CoinType(CoinTypePolkadot): "Polkadot",
+		CoinType(CoinTypeEOS):      "EOS",
+		CoinType(CoinTypeCosmos):   "Cosmos",
+		1234:                       "1234",
+	}
+	for coinType, expected := range tests {
+		if coinType.String() != expected {
+			t.Fatalf("CoinType(%d).String() returned %q, expected %q", uint32(coinType), coinType.String(), expected)
+		}
+	}
+	if CoinType(CoinTypeSolana).Curve() != CurveEd25519 {
+		t.Fatalf("CoinType(CoinTypeSolana).Curve() should be ed25519")
+	}
+}
+
+func TestParseCoinType(t *testing.T) {
+	tests := map[string]CoinType{
+		"solana":    CoinType(CoinTypeSolana),
+		" Cardano ": CoinType(CoinTypeCardano),
+		"bsc":       CoinType(CoinTypeEthereum),
+		"501":       CoinType(CoinTypeSolana),
+		"cosmos":    CoinType(CoinTypeCosmos),
+		"1234":      1234,
+		"0":         CoinType(CoinTypeBitcoin),
+	}
+	for s, expected := range tests {
+		coinType, err := ParseCoinType(s)
+		if err != nil {
+			t.Fatalf("ParseCoinType(%q) returned error: %v", s, err)
+		}
+		if coinType != expected {
+			t.Fatalf("ParseCoinType(%q) returned %d, expected %d", s, coinType, expected)
+		}
+	}
+
+	for _, s := range []string{"", "Dogecoins", "-1", "2147483648", "4294967296", "0x3c"} {
+		if _, err := ParseCoinType(s); err == nil {
+			t.Fatalf("ParseCoinType() should return error for %q", s)
+		}
+	}
+}
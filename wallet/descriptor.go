@@ -0,0 +1,469 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Size of the HMAC-SHA256 tag appended to signed descriptors
+const descriptorTagSize = sha256.Size
+
+// Version byte prefixed to the canonical descriptor encoding, bumped whenever the encoding changes
+const canonicalVersion = 1
+
+var (
+	// Returned when a signed descriptor's tag doesn't match, i.e., wrong key or modified payload
+	ErrDescriptorAuth = errors.New("descriptor authentication failed: wrong key or modified descriptor")
+	// Returned by ConfirmRecovery when the recovered wallet doesn't match the expected descriptor
+	ErrRecoveryMismatch   = errors.New("recovered wallet doesn't match the expected descriptor")
+	errEmptyDescriptorKey = errors.New("descriptor authentication key can't be empty")
+)
+
+// WalletDescriptor holds everything needed to reconstruct and audit
+// a single-seed sleeve, except private keys
+type WalletDescriptor struct {
+	Account       uint32              `json:"Account"`
+	Params        string              `json:"Params"`
+	WOTSPublicKey string              `json:"WOTSPublicKey"`
+	WOTSIndex     uint32              `json:"WOTSIndex"`
+	Networks      []NetworkDescriptor `json:"Networks"`
+}
+
+// NetworkDescriptor describes a derived network key, without the private key
+type NetworkDescriptor struct {
+	Network   string `json:"Network"`
+	CoinType  uint32 `json:"CoinType"`
+	Account   uint32 `json:"Account"`
+	Curve     string `json:"Curve"`
+	Path      string `json:"Path"`
+	Address   string `json:"Address,omitempty"`
+	PublicKey string `json:"PublicKey"`
+	// Address level settings, hardened address keys have no extended public key
+	AddressIndex         uint32 `json:"AddressIndex,omitempty"`
+	HardenedAddressIndex bool   `json:"HardenedAddressIndex,omitempty"`
+	// Address params set with DeriveNetworkKeyWithParams, if any
+	AddressParams *AddressParams `json:"AddressParams,omitempty"`
+	// Derivation scheme of keys not at the sleeve's path, e.g., "LedgerLive", empty for SchemeBIP44
+	Scheme string `json:"Scheme,omitempty"`
+	// Index of the next address returned by NextAddress, restored by ImportDescriptor
+	// It's wallet state rather than derivation, so descriptors are compared without it
+	NextAddressIndex uint32 `json:"NextAddressIndex,omitempty"`
+}
+
+// Export the wallet descriptor, with networks sorted by name
+// The output is deterministic, so descriptors of the same wallet can be compared directly
+func (s *SingleSeedSleeve) ExportDescriptor() (WalletDescriptor, error) {
+	names := s.GetNetworkNames()
+	networks := make([]NetworkDescriptor, len(names))
+	for i, name := range names {
+		net, err := s.networkDescriptor(name)
+		if err != nil {
+			return WalletDescriptor{}, err
+		}
+		networks[i] = net
+	}
+
+	return WalletDescriptor{
+		Account:       s.spec.account,
+		Params:        s.spec.params.String(),
+		WOTSPublicKey: s.GetWOTSPublicKeyHex(),
+		WOTSIndex:     s.derivationIndex,
+		Networks:      networks,
+	}, nil
+}
+
+// Get the descriptor of the network key stored under name
+func (s *SingleSeedSleeve) networkDescriptor(name string) (NetworkDescriptor, error) {
+	netKey := s.networkKeys[name]
+	pub, err := networkPublicKey(netKey)
+	if err != nil {
+		return NetworkDescriptor{}, fmt.Errorf("failed to get %s public key: %v", name, err)
+	}
+	addr, err := networkAddress(netKey)
+	if err != nil {
+		return NetworkDescriptor{}, fmt.Errorf("failed to get %s address: %v", name, err)
+	}
+	net := NetworkDescriptor{
+		Network:   netKey.Network,
+		CoinType:  netKey.CoinType,
+		Account:   netKey.Account,
+		Curve:     netKey.Curve.String(),
+		Path:      netKey.Path,
+		Address:   addr,
+		PublicKey: hex.EncodeToString(pub),
+
+		AddressIndex:         netKey.AddressIndex,
+		HardenedAddressIndex: netKey.HardenedAddressIndex,
+
+		NextAddressIndex: s.nextIndex[name],
+	}
+	if netKey.AddressParams != nil {
+		params := *netKey.AddressParams
+		net.AddressParams = &params
+	}
+	if netKey.Scheme != SchemeBIP44 {
+		net.Scheme = netKey.Scheme.String()
+	}
+	return net, nil
+}
+
+// Network fields of the canonical descriptor encoding
+type canonicalNetwork struct {
+	network  string
+	coinType uint32
+	path     string
+}
+
+// Encode the public wallet descriptor canonically: the version byte, then the account,
+// params name, WOTS+ public key, derivation index and networks sorted by name and path.
+// Integers are 4 byte big endian and variable size fields are length-prefixed
+func canonicalDescriptor(account uint32, params string, wotsPK []byte, index uint32,
+	networks []canonicalNetwork) []byte {
+	sort.Slice(networks, func(i, j int) bool {
+		if networks[i].network != networks[j].network {
+			return networks[i].network < networks[j].network
+		}
+		return networks[i].path < networks[j].path
+	})
+
+	var buf bytes.Buffer
+	num := make([]byte, 4)
+	writeUint32 := func(v uint32) {
+		binary.BigEndian.PutUint32(num, v)
+		buf.Write(num)
+	}
+	buf.WriteByte(canonicalVersion)
+	writeUint32(account)
+	writeLenPrefixed(&buf, []byte(params))
+	writeLenPrefixed(&buf, wotsPK)
+	writeUint32(index)
+	writeUint32(uint32(len(networks)))
+	for _, net := range networks {
+		writeLenPrefixed(&buf, []byte(net.network))
+		writeUint32(net.coinType)
+		writeLenPrefixed(&buf, []byte(net.path))
+	}
+	return buf.Bytes()
+}
+
+// Get a stable encoding of the public wallet descriptor, for hashing or external signing
+// It covers the account, params, WOTS+ public key, derivation index and every derived
+// network (name, coin type and path), is prefixed by a version byte and never depends on
+// map order or private material. It matches the CanonicalBytes of the exported descriptor,
+// and underpins DescriptorChecksum and ExportSignedDescriptor
+func (s *SingleSeedSleeve) CanonicalBytes() []byte {
+	networks := make([]canonicalNetwork, 0, len(s.networkKeys))
+	for _, netKey := range s.networkKeys {
+		networks = append(networks, canonicalNetwork{netKey.Network, netKey.CoinType, netKey.Path})
+	}
+	return canonicalDescriptor(s.spec.account, s.spec.params.String(), s.wotsPK, s.derivationIndex, networks)
+}
+
+// Get the canonical encoding of a descriptor, as returned by the sleeve's CanonicalBytes
+// Fails if the WOTS+ public key isn't hex encoded
+func (d WalletDescriptor) CanonicalBytes() ([]byte, error) {
+	wotsPK, err := hex.DecodeString(d.WOTSPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WOTS+ public key: %v", err)
+	}
+	networks := make([]canonicalNetwork, len(d.Networks))
+	for i, net := range d.Networks {
+		networks[i] = canonicalNetwork{net.Network, net.CoinType, net.Path}
+	}
+	return canonicalDescriptor(d.Account, d.Params, wotsPK, d.WOTSIndex, networks), nil
+}
+
+// Compute the tag of a signed descriptor over its canonical encoding and JSON payload
+// Fields outside the canonical encoding, like addresses, are covered by the payload
+func descriptorTag(key, canonical, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(canonical)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Export the wallet descriptor as JSON followed by an HMAC-SHA256 tag keyed by key
+// The tag covers the canonical bytes and the JSON payload, and lets a receiver
+// holding the same key detect tampering of the descriptor in transit
+func (s *SingleSeedSleeve) ExportSignedDescriptor(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errEmptyDescriptorKey
+	}
+	desc, err := s.ExportDescriptor()
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(desc)
+	if err != nil {
+		return nil, err
+	}
+	return append(payload, descriptorTag(key, s.CanonicalBytes(), payload)...), nil
+}
+
+// Verify a descriptor exported with ExportSignedDescriptor and decode it
+// Returns ErrDescriptorAuth if the key is wrong or the data was modified
+func VerifySignedDescriptor(data, key []byte) (WalletDescriptor, error) {
+	if len(key) == 0 {
+		return WalletDescriptor{}, errEmptyDescriptorKey
+	}
+	if len(data) < descriptorTagSize {
+		return WalletDescriptor{}, ErrDescriptorAuth
+	}
+	payload, tag := data[:len(data)-descriptorTagSize], data[len(data)-descriptorTagSize:]
+
+	// The canonical bytes are computed from the payload, which a signed descriptor always decodes
+	var desc WalletDescriptor
+	if err := json.Unmarshal(payload, &desc); err != nil {
+		return WalletDescriptor{}, ErrDescriptorAuth
+	}
+	canonical, err := desc.CanonicalBytes()
+	if err != nil {
+		return WalletDescriptor{}, ErrDescriptorAuth
+	}
+	if !hmac.Equal(descriptorTag(key, canonical, payload), tag) {
+		return WalletDescriptor{}, ErrDescriptorAuth
+	}
+	return desc, nil
+}
+
+// Check whether two descriptors are equal field by field
+func (d WalletDescriptor) Equal(other WalletDescriptor) bool {
+	return descriptorDiff(d, other) == nil
+}
+
+// One field of a descriptor compared to the expected descriptor, see CompareDescriptors
+type DescriptorField struct {
+	// Network the field belongs to, empty for fields of the wallet
+	Network string
+	// Name of the field, e.g., "params" or "path"
+	Name string
+	// Values of the field, formatted with %v
+	Got, Expected string
+}
+
+// Check whether the field has the expected value
+func (f DescriptorField) Match() bool {
+	return f.Got == f.Expected
+}
+
+// Compare a descriptor to the expected one field by field, returning every compared field
+// Networks are compared by position, as ExportDescriptor sorts them, and only when both
+// descriptors have the same number of networks
+func CompareDescriptors(got, expected WalletDescriptor) []DescriptorField {
+	fields := []DescriptorField{
+		descriptorField("", "account", got.Account, expected.Account),
+		descriptorField("", "params", got.Params, expected.Params),
+		descriptorField("", "WOTS+ public key", got.WOTSPublicKey, expected.WOTSPublicKey),
+		descriptorField("", "WOTS+ index", got.WOTSIndex, expected.WOTSIndex),
+		descriptorField("", "number of networks", len(got.Networks), len(expected.Networks)),
+	}
+	if len(got.Networks) != len(expected.Networks) {
+		return fields
+	}
+	for i := range expected.Networks {
+		fields = append(fields, compareNetworkDescriptors(got.Networks[i], expected.Networks[i])...)
+	}
+	return fields
+}
+
+// Compare a network descriptor to the expected one field by field
+func compareNetworkDescriptors(got, expected NetworkDescriptor) []DescriptorField {
+	name := expected.Network
+	return []DescriptorField{
+		descriptorField(name, "name", got.Network, expected.Network),
+		descriptorField(name, "coin type", got.CoinType, expected.CoinType),
+		descriptorField(name, "account", got.Account, expected.Account),
+		descriptorField(name, "curve", got.Curve, expected.Curve),
+		descriptorField(name, "path", got.Path, expected.Path),
+		descriptorField(name, "address index", got.AddressIndex, expected.AddressIndex),
+		descriptorField(name, "hardened address index", got.HardenedAddressIndex, expected.HardenedAddressIndex),
+		descriptorField(name, "address params", addressParamsString(got.AddressParams), addressParamsString(expected.AddressParams)),
+		descriptorField(name, "scheme", got.Scheme, expected.Scheme),
+		descriptorField(name, "public key", got.PublicKey, expected.PublicKey),
+		descriptorField(name, "address", got.Address, expected.Address),
+	}
+}
+
+func descriptorField(network, name string, got, expected interface{}) DescriptorField {
+	return DescriptorField{Network: network, Name: name, Got: fmt.Sprint(got), Expected: fmt.Sprint(expected)}
+}
+
+// Compare a descriptor to the expected one field by field
+// Returns an error describing the first discrepancy, or nil if they're equal
+func descriptorDiff(got, expected WalletDescriptor) error {
+	for _, f := range CompareDescriptors(got, expected) {
+		if f.Match() {
+			continue
+		}
+		if f.Network != "" {
+			return fmt.Errorf("network %s: %s mismatch: got %s, expected %s", f.Network, f.Name, f.Got, f.Expected)
+		}
+		return fmt.Errorf("%s mismatch: got %s, expected %s", f.Name, f.Got, f.Expected)
+	}
+	return nil
+}
+
+// Compare a network descriptor to the expected one field by field
+// Returns an error describing the first discrepancy, or nil if they're equal
+func networkDescriptorDiff(got, expected NetworkDescriptor) error {
+	for _, f := range compareNetworkDescriptors(got, expected) {
+		if !f.Match() {
+			return fmt.Errorf("%s mismatch: got %s, expected %s", f.Name, f.Got, f.Expected)
+		}
+	}
+	return nil
+}
+
+// Get a comparable representation of optional address params
+func addressParamsString(params *AddressParams) string {
+	if params == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%+v", *params)
+}
+
+// Recover a single-seed sleeve from its mnemonic and check it matches the expected descriptor,
+// e.g., one exported with ExportDescriptor when the wallet was created
+// Every network of the descriptor is derived again, with its coin type, account and address index,
+// and compared field by field to the expected entry. Wallet apps should call this after a user
+// restores a backup, to confirm the mnemonic and passphrase recover the same keys
+// Returns an error wrapping ErrRecoveryMismatch that describes the first discrepancy found
+func ConfirmRecovery(mnemonic, passphrase string, spec GenSpec, expected WalletDescriptor) error {
+	recovered, err := recoverDescriptor(mnemonic, passphrase, spec, expected)
+	if err != nil {
+		return err
+	}
+	if err := descriptorDiff(recovered, expected); err != nil {
+		return fmt.Errorf("%w: %v", ErrRecoveryMismatch, err)
+	}
+	return nil
+}
+
+// Recover a single-seed sleeve as ConfirmRecovery does, and compare it to the expected descriptor
+// Returns every compared field, for tools reporting the result of each one, see CompareDescriptors
+// Errors are only returned if the sleeve can't be recovered, mismatches are in the fields
+func ConfirmRecoveryFields(mnemonic, passphrase string, spec GenSpec,
+	expected WalletDescriptor) ([]DescriptorField, error) {
+	recovered, err := recoverDescriptor(mnemonic, passphrase, spec, expected)
+	if err != nil {
+		return nil, err
+	}
+	return CompareDescriptors(recovered, expected), nil
+}
+
+// Recover a single-seed sleeve with only the networks of a descriptor, and export its descriptor
+func recoverDescriptor(mnemonic, passphrase string, spec GenSpec, expected WalletDescriptor) (WalletDescriptor, error) {
+	// Only the networks of the descriptor are derived
+	spec.lazyDerivation = true
+	sleeve, err := NewSingleSeedSleeveFromMnemonic(mnemonic, passphrase, spec)
+	if err != nil {
+		return WalletDescriptor{}, fmt.Errorf("failed to recover sleeve: %w", err)
+	}
+	defer sleeve.Wipe()
+
+	for _, net := range expected.Networks {
+		if _, err := sleeve.deriveDescriptorNetwork(net); err != nil {
+			return WalletDescriptor{}, err
+		}
+	}
+	return sleeve.ExportDescriptor()
+}
+
+// Derive the network key of a network descriptor, with its address params and next address index
+// Returns the name the key is stored under
+func (s *SingleSeedSleeve) deriveDescriptorNetwork(net NetworkDescriptor) (string, error) {
+	scheme := SchemeBIP44
+	if net.Scheme != "" {
+		var err error
+		if scheme, err = ParseDerivationScheme(net.Scheme); err != nil {
+			return "", err
+		}
+	}
+
+	var err error
+	name := s.descriptorKeyName(net, scheme)
+	if scheme != SchemeBIP44 {
+		err = s.deriveSchemeKey(name, net.Network, net.CoinType, net.Account, scheme, s.seed)
+	} else {
+		err = s.deriveNetworkKey(name, net.Network, net.CoinType, net.Account, net.AddressIndex,
+			net.HardenedAddressIndex, s.seed)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to derive %s: %w", name, err)
+	}
+	if net.AddressParams != nil {
+		params := *net.AddressParams
+		s.networkKeys[name].AddressParams = &params
+	}
+	s.setNextAddressIndex(name, net.NextAddressIndex)
+	return name, nil
+}
+
+// Get the name the key of a network descriptor is stored under
+func (s *SingleSeedSleeve) descriptorKeyName(net NetworkDescriptor, scheme DerivationScheme) string {
+	if scheme != SchemeBIP44 {
+		return s.schemeKeyName(net.Network, net.Account, scheme)
+	}
+	// IndexHardened sleeves harden every address key, without a hardened name
+	name := addressKeyName(net.Network, net.AddressIndex, net.HardenedAddressIndex && !s.spec.IndexHardened)
+	if net.Account != s.networkAccount() {
+		name = fmt.Sprintf("%s/%d", name, net.Account)
+	}
+	return name
+}
+
+// Restore the networks of a descriptor into the sleeve, with their NextAddress counters,
+// e.g., after recovering a wallet from its mnemonic, so addresses handed out in earlier
+// sessions aren't reused. Every network is derived again and must match its descriptor.
+// Fails if the descriptor is of a different wallet or account, or if the sleeve was wiped.
+// Nothing is restored if the import fails
+func (s *SingleSeedSleeve) ImportDescriptor(d WalletDescriptor) error {
+	if s.seed == nil {
+		return errors.New("sleeve seed is not available - was the sleeve wiped?")
+	}
+	if d.WOTSPublicKey != s.GetWOTSPublicKeyHex() || d.Account != s.spec.account {
+		return errors.New("can't import the descriptor of a different wallet or account")
+	}
+
+	// Keep the current state to roll back a failed import
+	networkKeys := make(map[string]*NetworkKey, len(s.networkKeys))
+	for name, netKey := range s.networkKeys {
+		networkKeys[name] = netKey
+	}
+	nextIndex := make(map[string]uint32, len(s.nextIndex))
+	for name, index := range s.nextIndex {
+		nextIndex[name] = index
+	}
+	rollback := func(err error) error {
+		s.networkKeys, s.nextIndex = networkKeys, nextIndex
+		return err
+	}
+
+	for _, net := range d.Networks {
+		name, err := s.deriveDescriptorNetwork(net)
+		if err != nil {
+			return rollback(err)
+		}
+		got, err := s.networkDescriptor(name)
+		if err != nil {
+			return rollback(err)
+		}
+		if err := networkDescriptorDiff(got, net); err != nil {
+			return rollback(fmt.Errorf("%w: network %s: %v", ErrRecoveryMismatch, net.Network, err))
+		}
+	}
+	return nil
+}
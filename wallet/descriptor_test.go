@@ -0,0 +1,357 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/xx-labs/sleeve/wots"
+)
+
+func TestSingleSeedSleeve_ExportDescriptor(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", NewGenSpec(2, wots.Level1))
+	_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, mustSeed(testVectorMnemonic))
+
+	desc, err := sleeve.ExportDescriptor()
+	if err != nil {
+		t.Fatalf("ExportDescriptor() returned error: %v", err)
+	}
+
+	if desc.Account != 2 || desc.Params != "Level1" {
+		t.Fatalf("ExportDescriptor() returned wrong spec. Got account %d, params %s", desc.Account, desc.Params)
+	}
+	if desc.WOTSPublicKey != hex.EncodeToString(sleeve.GetWOTSPublicKey()) {
+		t.Fatalf("ExportDescriptor() returned wrong WOTS+ public key")
+	}
+	if desc.WOTSIndex != sleeve.GetDerivationIndex() {
+		t.Fatalf("ExportDescriptor() returned wrong WOTS+ index")
+	}
+
+	// Networks are sorted and match the sleeve
+	if len(desc.Networks) != 4 {
+		t.Fatalf("ExportDescriptor() returned wrong number of networks: %d", len(desc.Networks))
+	}
+	if !sort.SliceIsSorted(desc.Networks, func(i, j int) bool { return desc.Networks[i].Network < desc.Networks[j].Network }) {
+		t.Fatalf("ExportDescriptor() networks should be sorted by name")
+	}
+	for _, net := range desc.Networks {
+		netKey := sleeve.GetAllNetworkKeys()[net.Network]
+		if net.CoinType != netKey.CoinType || net.Path != netKey.Path || net.Curve != netKey.Curve.String() {
+			t.Fatalf("ExportDescriptor() returned wrong entry for %s", net.Network)
+		}
+		pub, _ := sleeve.GetPublicKey(net.Network)
+		if net.PublicKey != hex.EncodeToString(pub) {
+			t.Fatalf("ExportDescriptor() returned wrong public key for %s", net.Network)
+		}
+		addr, _ := sleeve.GetAddress(net.Network)
+		if net.Address != addr {
+			t.Fatalf("ExportDescriptor() returned wrong address for %s", net.Network)
+		}
+	}
+
+	// Deterministic
+	other, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", NewGenSpec(2, wots.Level1))
+	_ = other.DeriveNetworkKey("Solana", CoinTypeSolana, mustSeed(testVectorMnemonic))
+	otherDesc, _ := other.ExportDescriptor()
+	if !reflect.DeepEqual(desc, otherDesc) {
+		t.Fatalf("ExportDescriptor() should be deterministic")
+	}
+
+	// No private key material
+	data, _ := json.Marshal(desc)
+	for name, netKey := range sleeve.GetAllNetworkKeys() {
+		if strings.Contains(string(data), hex.EncodeToString(netKey.Key)) {
+			t.Fatalf("ExportDescriptor() leaks the %s private key", name)
+		}
+	}
+}
+
+func TestSingleSeedSleeve_SignedDescriptor(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	key := []byte("shared secret")
+
+	data, err := sleeve.ExportSignedDescriptor(key)
+	if err != nil {
+		t.Fatalf("ExportSignedDescriptor() returned error: %v", err)
+	}
+	desc, err := VerifySignedDescriptor(data, key)
+	if err != nil {
+		t.Fatalf("VerifySignedDescriptor() returned error: %v", err)
+	}
+	expected, _ := sleeve.ExportDescriptor()
+	if !reflect.DeepEqual(desc, expected) {
+		t.Fatalf("VerifySignedDescriptor() returned wrong descriptor")
+	}
+
+	// Wrong key
+	if _, err := VerifySignedDescriptor(data, []byte("other secret")); err != ErrDescriptorAuth {
+		t.Fatalf("VerifySignedDescriptor() should return ErrDescriptorAuth for wrong key, got: %v", err)
+	}
+
+	// Modified payload
+	modified := bytes.Replace(data, []byte(`"Account":0`), []byte(`"Account":1`), 1)
+	if bytes.Equal(modified, data) {
+		t.Fatalf("Test payload wasn't modified")
+	}
+	if _, err := VerifySignedDescriptor(modified, key); err != ErrDescriptorAuth {
+		t.Fatalf("VerifySignedDescriptor() should return ErrDescriptorAuth for modified payload, got: %v", err)
+	}
+
+	// Truncated data
+	if _, err := VerifySignedDescriptor(data[:10], key); err != ErrDescriptorAuth {
+		t.Fatalf("VerifySignedDescriptor() should return ErrDescriptorAuth for truncated data, got: %v", err)
+	}
+
+	// Empty key
+	if _, err := sleeve.ExportSignedDescriptor(nil); err == nil {
+		t.Fatalf("ExportSignedDescriptor() should return error for empty key")
+	}
+	if _, err := VerifySignedDescriptor(data, nil); err == nil || err == ErrDescriptorAuth {
+		t.Fatalf("VerifySignedDescriptor() should return a key error for empty key, got: %v", err)
+	}
+}
+
+func TestSingleSeedSleeve_CanonicalBytes(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+	_ = sleeve.DeriveNetworkKey("Dogecoin", CoinTypeDogecoin, seed)
+	canonical := sleeve.CanonicalBytes()
+	if canonical[0] != canonicalVersion {
+		t.Fatalf("CanonicalBytes() should start with version %d, got %d", canonicalVersion, canonical[0])
+	}
+
+	// Networks derived in another order give the same bytes
+	other, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	_ = other.DeriveNetworkKey("Dogecoin", CoinTypeDogecoin, seed)
+	_ = other.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+	if !bytes.Equal(other.CanonicalBytes(), canonical) {
+		t.Fatalf("CanonicalBytes() should not depend on derivation order")
+	}
+
+	// No private material: no private key or seed in them, and the same bytes without the mnemonic
+	for name, netKey := range sleeve.GetAllNetworkKeys() {
+		if bytes.Contains(canonical, netKey.Key) {
+			t.Fatalf("CanonicalBytes() leaks the %s private key", name)
+		}
+	}
+	if bytes.Contains(canonical, seed) {
+		t.Fatalf("CanonicalBytes() leaks the seed")
+	}
+	fromMnemonic, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	plain, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	if !bytes.Equal(fromMnemonic.CanonicalBytes(), plain.CanonicalBytes()) {
+		t.Fatalf("CanonicalBytes() should not depend on the mnemonic")
+	}
+
+	// The exported descriptor has the same canonical bytes
+	desc, _ := sleeve.ExportDescriptor()
+	fromDesc, err := desc.CanonicalBytes()
+	if err != nil {
+		t.Fatalf("WalletDescriptor.CanonicalBytes() returned error: %v", err)
+	}
+	if !bytes.Equal(fromDesc, canonical) {
+		t.Fatalf("WalletDescriptor.CanonicalBytes() doesn't match the sleeve's CanonicalBytes()")
+	}
+	desc.WOTSPublicKey = "not hex"
+	if _, err := desc.CanonicalBytes(); err == nil {
+		t.Fatalf("WalletDescriptor.CanonicalBytes() should return error for an invalid WOTS+ public key")
+	}
+
+	// Deriving another network changes the bytes
+	_ = sleeve.DeriveNetworkKey("Near", CoinTypeNear, seed)
+	if bytes.Equal(sleeve.CanonicalBytes(), canonical) {
+		t.Fatalf("CanonicalBytes() should change when a network is derived")
+	}
+}
+
+func TestConfirmRecovery(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	spec := NewGenSpec(1, wots.Level2)
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", spec)
+	_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+	_ = sleeve.DeriveNetworkKeyAt("Ethereum", CoinTypeEthereum, 3, seed)
+	_, _ = sleeve.DeriveAddressKey("Ethereum", CoinTypeEthereum, 2, true, seed)
+	_ = sleeve.DeriveNetworkKeyWithParams("Fork", CoinTypeBitcoin,
+		AddressParams{Format: AddressFormatP2WPKH, HRP: "fork"}, seed)
+	expected, _ := sleeve.ExportDescriptor()
+
+	if err := ConfirmRecovery(testVectorMnemonic, "", spec, expected); err != nil {
+		t.Fatalf("ConfirmRecovery() returned error: %v", err)
+	}
+
+	// Wrong passphrase, account or params
+	if err := ConfirmRecovery(testVectorMnemonic, "wrong", spec, expected); !errors.Is(err, ErrRecoveryMismatch) {
+		t.Fatalf("ConfirmRecovery() should return ErrRecoveryMismatch for wrong passphrase, got: %v", err)
+	}
+	if err := ConfirmRecovery(testVectorMnemonic, "", NewGenSpec(0, wots.Level2), expected); !errors.Is(err, ErrRecoveryMismatch) {
+		t.Fatalf("ConfirmRecovery() should return ErrRecoveryMismatch for wrong account, got: %v", err)
+	}
+	err := ConfirmRecovery(testVectorMnemonic, "", NewGenSpec(1, wots.Level0), expected)
+	if !errors.Is(err, ErrRecoveryMismatch) || !strings.Contains(err.Error(), "params") {
+		t.Fatalf("ConfirmRecovery() should report the params mismatch first, got: %v", err)
+	}
+
+	// Modified network entry
+	modified := expected
+	modified.Networks = append([]NetworkDescriptor{}, expected.Networks...)
+	modified.Networks[len(modified.Networks)-1].Address = "modified"
+	err = ConfirmRecovery(testVectorMnemonic, "", spec, modified)
+	if !errors.Is(err, ErrRecoveryMismatch) || !strings.Contains(err.Error(), "address mismatch") {
+		t.Fatalf("ConfirmRecovery() should report the address mismatch, got: %v", err)
+	}
+
+	// Invalid mnemonic
+	if err := ConfirmRecovery("invalid mnemonic", "", spec, expected); err == nil || errors.Is(err, ErrRecoveryMismatch) {
+		t.Fatalf("ConfirmRecovery() should return a recovery error for invalid mnemonic, got: %v", err)
+	}
+}
+
+func TestConfirmRecoveryFields(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	spec := NewGenSpec(0, wots.Level0)
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", spec)
+	_ = sleeve.DeriveNetworkKeyAtWithOptions("Ethereum", CoinTypeEthereum, 1,
+		NetworkKeyOptions{Scheme: SchemeLedgerLive}, seed)
+	_ = sleeve.DeriveNetworkKeyWithParams("Fork", CoinTypeBitcoin,
+		AddressParams{Format: AddressFormatP2WPKH, HRP: "fork"}, seed)
+	expected, _ := sleeve.ExportDescriptor()
+
+	fields, err := ConfirmRecoveryFields(testVectorMnemonic, "", spec, expected)
+	if err != nil {
+		t.Fatalf("ConfirmRecoveryFields() returned error: %v", err)
+	}
+	if len(fields) != 5+11*len(expected.Networks) {
+		t.Fatalf("ConfirmRecoveryFields() returned %d fields, expected every field", len(fields))
+	}
+	for _, f := range fields {
+		if !f.Match() {
+			t.Fatalf("ConfirmRecoveryFields() reports a %s %s mismatch for the same wallet", f.Network, f.Name)
+		}
+	}
+
+	// The scheme is compared too, and address params, which are taken from the descriptor, give
+	// other addresses
+	modified := expected
+	modified.Networks = append([]NetworkDescriptor{}, expected.Networks...)
+	for i := range modified.Networks {
+		if modified.Networks[i].Scheme != "" {
+			modified.Networks[i].Scheme = SchemeBIP44.String()
+		}
+		if modified.Networks[i].AddressParams != nil {
+			modified.Networks[i].AddressParams = &AddressParams{Format: AddressFormatP2WPKH, HRP: "other"}
+		}
+	}
+	fields, _ = ConfirmRecoveryFields(testVectorMnemonic, "", spec, modified)
+	mismatches := map[string]bool{}
+	for _, f := range fields {
+		if !f.Match() {
+			mismatches[f.Name] = true
+		}
+	}
+	if !mismatches["scheme"] || !mismatches["address"] {
+		t.Fatalf("ConfirmRecoveryFields() should report scheme and address mismatches, got %v", mismatches)
+	}
+}
+
+func TestWalletDescriptor_Equal(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	desc, _ := sleeve.ExportDescriptor()
+	other, _ := sleeve.ExportDescriptor()
+	if !desc.Equal(other) {
+		t.Fatalf("Equal() should return true for the same wallet")
+	}
+	other.Networks = other.Networks[1:]
+	if desc.Equal(other) {
+		t.Fatalf("Equal() should return false for a different number of networks")
+	}
+}
+
+func TestSingleSeedSleeve_NextAddress(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+
+	// Addresses are handed out in order, starting with the network key's own address
+	for i := uint32(0); i < 3; i++ {
+		index, addr, err := sleeve.NextAddress("Ethereum")
+		if err != nil {
+			t.Fatalf("NextAddress() returned error: %v", err)
+		}
+		if index != i {
+			t.Fatalf("NextAddress() returned index %d, expected %d", index, i)
+		}
+		addrKey, _ := sleeve.DeriveAddressKey("Ethereum", CoinTypeEthereum, i, false, seed)
+		expected, _ := networkAddress(addrKey)
+		if addr != expected {
+			t.Fatalf("NextAddress() returned %s for index %d, expected %s", addr, i, expected)
+		}
+	}
+
+	// The counter survives a restore from the descriptor
+	desc, _ := sleeve.ExportDescriptor()
+	data, _ := json.Marshal(desc)
+	var decoded WalletDescriptor
+	_ = json.Unmarshal(data, &decoded)
+
+	restored, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	if err := restored.ImportDescriptor(decoded); err != nil {
+		t.Fatalf("ImportDescriptor() returned error: %v", err)
+	}
+	if index, _, _ := restored.NextAddress("Ethereum"); index != 3 {
+		t.Fatalf("NextAddress() after ImportDescriptor returned index %d, expected 3", index)
+	}
+	if _, ok := restored.GetAllNetworkKeys()["Ethereum#2"]; !ok {
+		t.Fatalf("ImportDescriptor() should restore the networks of the descriptor")
+	}
+
+	// Networks without a supported address format don't advance their counter
+	_ = sleeve.DeriveNetworkKey("Cardano", CoinTypeCardano, seed)
+	if _, _, err := sleeve.NextAddress("Cardano"); err == nil {
+		t.Fatalf("NextAddress() should return error for a network without an address format")
+	}
+	if index, _, _ := sleeve.NextAddress("Polkadot"); index != 0 {
+		t.Fatalf("NextAddress() counters should be per network, got index %d", index)
+	}
+	if _, _, err := sleeve.NextAddress("Unknown"); err == nil {
+		t.Fatalf("NextAddress() should return error for unknown network")
+	}
+	sleeve.Wipe()
+	if _, _, err := sleeve.NextAddress("Ethereum"); err == nil {
+		t.Fatalf("NextAddress() should return error after Wipe")
+	}
+}
+
+func TestSingleSeedSleeve_ImportDescriptor(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	desc, _ := sleeve.ExportDescriptor()
+
+	// Descriptors of another wallet are rejected
+	other, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "passphrase", DefaultGenSpec())
+	if err := other.ImportDescriptor(desc); err == nil {
+		t.Fatalf("ImportDescriptor() should return error for a different wallet")
+	}
+
+	// Networks that don't match are rejected, and nothing is imported
+	desc.Networks = append(desc.Networks, NetworkDescriptor{
+		Network: "Solana", CoinType: CoinTypeSolana, Curve: "ed25519", PublicKey: "00", NextAddressIndex: 5,
+	})
+	restored, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	err := restored.ImportDescriptor(desc)
+	if !errors.Is(err, ErrRecoveryMismatch) {
+		t.Fatalf("ImportDescriptor() should return ErrRecoveryMismatch for a mismatching network, got %v", err)
+	}
+	if _, ok := restored.GetAllNetworkKeys()["Solana"]; ok {
+		t.Fatalf("ImportDescriptor() shouldn't keep networks of a failed import")
+	}
+}
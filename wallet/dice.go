@@ -0,0 +1,71 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Minimum number of dice rolls for EntropySize bytes of entropy: 6^100 >= 2^256 > 6^99
+// A few more rolls make ErrBiasedDiceRolls unlikely: with 100 rolls about 1 in 9
+// sequences is rejected, with 105 about 1 in 85000
+const MinDiceRolls = 100
+
+// Returned when the number read from dice rolls falls in the range that would bias the entropy
+// The rolls can't be used: roll again, or add more rolls
+var ErrBiasedDiceRolls = errors.New("dice rolls would give biased entropy")
+
+// Create a single-seed sleeve with entropy from physical dice rolls
+// Each roll is a value from 1 to 6, and at least MinDiceRolls are required.
+// The rolls are read as the digits of a base-6 number, which is mapped to EntropySize bytes
+// without bias: numbers above the largest multiple of 2^256 are rejected with ErrBiasedDiceRolls,
+// and the rest are reduced mod 2^256. The same rolls always give the same mnemonic
+func NewSingleSeedSleeveFromDiceRolls(rolls []int, passphrase string, spec GenSpec) (*SingleSeedSleeve, error) {
+	ent, err := diceRollsEntropy(rolls)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(ent)
+	return NewSingleSeedSleeveFromEntropy(ent, passphrase, spec)
+}
+
+// Map dice rolls to EntropySize bytes of unbiased entropy
+func diceRollsEntropy(rolls []int) ([]byte, error) {
+	if len(rolls) < MinDiceRolls {
+		return nil, fmt.Errorf("not enough dice rolls: got %d, expected at least %d", len(rolls), MinDiceRolls)
+	}
+
+	// 1. Read the rolls as a base-6 number, mapping 1-6 to the digits 0-5
+	six := big.NewInt(6)
+	value := new(big.Int)
+	for i, roll := range rolls {
+		if roll < 1 || roll > 6 {
+			return nil, fmt.Errorf("invalid dice roll %d at position %d: must be from 1 to 6", roll, i+1)
+		}
+		value.Mul(value, six)
+		value.Add(value, big.NewInt(int64(roll-1)))
+	}
+
+	// 2. Reject numbers from the last partial multiple of 2^256, which would be biased by the reduction
+	bits := uint(EntropySize * 8)
+	limit := new(big.Int).Exp(six, big.NewInt(int64(len(rolls))), nil)
+	limit.Rsh(limit, bits)
+	limit.Lsh(limit, bits)
+	if value.Cmp(limit) >= 0 {
+		return nil, ErrBiasedDiceRolls
+	}
+
+	// 3. Reduce mod 2^256
+	mask := new(big.Int).Lsh(big.NewInt(1), bits)
+	mask.Sub(mask, big.NewInt(1))
+	value.And(value, mask)
+	ent := value.FillBytes(make([]byte, EntropySize))
+	value.SetInt64(0)
+	return ent, nil
+}
@@ -0,0 +1,108 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/xx-labs/sleeve/wots"
+)
+
+// Get n dice rolls all of the same value
+func sameRolls(n, value int) []int {
+	rolls := make([]int, n)
+	for i := range rolls {
+		rolls[i] = value
+	}
+	return rolls
+}
+
+// Test MinDiceRolls is the least number of rolls giving EntropySize bytes
+func TestMinDiceRolls(t *testing.T) {
+	max := new(big.Int).Lsh(big.NewInt(1), EntropySize*8)
+	six := big.NewInt(6)
+	if new(big.Int).Exp(six, big.NewInt(MinDiceRolls), nil).Cmp(max) < 0 {
+		t.Fatalf("%d dice rolls don't give %d bytes of entropy", MinDiceRolls, EntropySize)
+	}
+	if new(big.Int).Exp(six, big.NewInt(MinDiceRolls-1), nil).Cmp(max) >= 0 {
+		t.Fatalf("%d dice rolls already give %d bytes of entropy", MinDiceRolls-1, EntropySize)
+	}
+}
+
+func TestDiceRollsEntropy(t *testing.T) {
+	// All ones is zero
+	ent, err := diceRollsEntropy(sameRolls(MinDiceRolls, 1))
+	if err != nil {
+		t.Fatalf("diceRollsEntropy() returned error: %v", err)
+	}
+	if hex.EncodeToString(ent) != strings.Repeat("00", EntropySize) {
+		t.Fatalf("diceRollsEntropy() of all ones should be zero, got %x", ent)
+	}
+
+	// Rolls read as a base-6 number, reduced mod 2^256
+	rolls := make([]int, MinDiceRolls+1)
+	for i := range rolls {
+		rolls[i] = (i*7)%6 + 1
+	}
+	ent, err = diceRollsEntropy(rolls)
+	if err != nil {
+		t.Fatalf("diceRollsEntropy() returned error: %v", err)
+	}
+	expected := "5a6e97d56593b0471699027bb22614724ff7c3bf31af81237958cef90f0fe296"
+	if hex.EncodeToString(ent) != expected {
+		t.Fatalf("diceRollsEntropy() returned wrong entropy. Got %x, expected %s", ent, expected)
+	}
+
+	// The smallest rejected number: the largest multiple of 2^256 below 6^100
+	biased := "6263365546524225314413253522255442433336134252134625323511345453215331453612416422433556351254153323"
+	rolls = make([]int, len(biased))
+	for i, c := range biased {
+		rolls[i] = int(c - '0')
+	}
+	if _, err := diceRollsEntropy(rolls); !errors.Is(err, ErrBiasedDiceRolls) {
+		t.Fatalf("diceRollsEntropy() should return ErrBiasedDiceRolls for biased rolls, got %v", err)
+	}
+	// One less is accepted, as the largest value
+	rolls[len(rolls)-1]--
+	if ent, err = diceRollsEntropy(rolls); err != nil {
+		t.Fatalf("diceRollsEntropy() returned error: %v", err)
+	}
+	if hex.EncodeToString(ent) != strings.Repeat("ff", EntropySize) {
+		t.Fatalf("diceRollsEntropy() of the largest accepted number should be all ones, got %x", ent)
+	}
+}
+
+func TestNewSingleSeedSleeveFromDiceRolls(t *testing.T) {
+	sleeve, err := NewSingleSeedSleeveFromDiceRolls(sameRolls(MinDiceRolls, 1), "", NewGenSpec(0, wots.DefaultParams))
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromDiceRolls() returned error: %v", err)
+	}
+	expected := strings.Repeat("abandon ", MnemonicWords-1) + "art"
+	if sleeve.GetMnemonic() != expected {
+		t.Fatalf("NewSingleSeedSleeveFromDiceRolls() returned wrong mnemonic. Got %s, expected %s",
+			sleeve.GetMnemonic(), expected)
+	}
+
+	// Errors
+	if _, err := NewSingleSeedSleeveFromDiceRolls(sameRolls(MinDiceRolls-1, 1), "", DefaultGenSpec()); err == nil {
+		t.Fatalf("NewSingleSeedSleeveFromDiceRolls() should return error for too few rolls")
+	}
+	for _, roll := range []int{0, 7, -1} {
+		rolls := sameRolls(MinDiceRolls, 3)
+		rolls[50] = roll
+		if _, err := NewSingleSeedSleeveFromDiceRolls(rolls, "", DefaultGenSpec()); err == nil {
+			t.Fatalf("NewSingleSeedSleeveFromDiceRolls() should return error for roll %d", roll)
+		}
+	}
+	if _, err := NewSingleSeedSleeveFromDiceRolls(sameRolls(MinDiceRolls, 6), "", DefaultGenSpec()); !errors.Is(err, ErrBiasedDiceRolls) {
+		t.Fatalf("NewSingleSeedSleeveFromDiceRolls() should return ErrBiasedDiceRolls for all sixes, got %v", err)
+	}
+}
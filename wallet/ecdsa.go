@@ -0,0 +1,94 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// SigFormat selects the encoding of ECDSA signatures
+type SigFormat uint8
+
+const (
+	// ASN.1 DER encoded SEQUENCE { r INTEGER, s INTEGER }, as used by Bitcoin
+	SigFormatDER SigFormat = iota
+	// 64 bytes r || s, each 32 byte big endian
+	SigFormatCompact
+)
+
+func (f SigFormat) String() string {
+	switch f {
+	case SigFormatDER:
+		return "DER"
+	case SigFormatCompact:
+		return "compact"
+	default:
+		return "UNKNOWN SIGNATURE FORMAT"
+	}
+}
+
+// Sign a 32 byte hash with the secp256k1 key of a network, in the given format
+// The signature is normalized to low s (BIP62), so it is accepted by chains rejecting malleable signatures.
+// Nonces are derived with RFC6979 from the key and hash, never from randomness
+func (s *SingleSeedSleeve) SignECDSA(network string, hash []byte, format SigFormat) ([]byte, error) {
+	if s.watchOnly {
+		return nil, ErrWatchOnly
+	}
+	netKey, exists := s.networkKeys[network]
+	if !exists {
+		return nil, fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	if netKey.Curve != CurveSecp256k1 {
+		return nil, fmt.Errorf("network %s uses %s, ECDSA signing requires secp256k1", network, netKey.Curve)
+	}
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("hash has size %d, expected 32", len(hash))
+	}
+	privKey, err := secp256k1PrivateKey(netKey.Key)
+	if err != nil {
+		return nil, err
+	}
+	r, sv := new(big.Int), new(big.Int)
+	if err := signLowS(privKey, hash, r, sv); err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case SigFormatDER:
+		return asn1.Marshal(struct{ R, S *big.Int }{r, sv})
+	case SigFormatCompact:
+		out := make([]byte, 64)
+		r.FillBytes(out[:32])
+		sv.FillBytes(out[32:])
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unknown signature format %d", format)
+	}
+}
+
+// Sign a 32 byte hash with a secp256k1 private key, setting r and the low s value of the signature
+// btcec signs with an RFC6979 nonce and normalizes s to low s (BIP62)
+func signLowS(privKey *btcec.PrivateKey, hash []byte, r, sv *big.Int) error {
+	sig, err := privKey.Sign(hash)
+	if err != nil {
+		return err
+	}
+	r.Set(sig.R)
+	sv.Set(sig.S)
+	return nil
+}
+
+// Sign a 32 byte hash with the secp256k1 key of a network, using an RFC6979 deterministic nonce
+// Signing the same hash with the same key always gives byte-identical signatures, so they can be
+// reproduced across machines. The signature is in the 64 byte compact format, normalized to low s
+func (s *SingleSeedSleeve) SignECDSADeterministic(network string, hash []byte) ([]byte, error) {
+	return s.SignECDSA(network, hash, SigFormatCompact)
+}
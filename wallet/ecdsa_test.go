@@ -0,0 +1,123 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// Half the order of secp256k1, the largest canonical s value
+var secp256k1HalfN = new(big.Int).Rsh(btcec.S256().N, 1)
+
+// Verify a 64 byte r || s secp256k1 signature of a hash with a serialized public key
+func verifySecp256k1(pubkey, hash, sig []byte) bool {
+	pub, err := btcec.ParsePubKey(pubkey, btcec.S256())
+	if err != nil || len(sig) != 64 {
+		return false
+	}
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	return ecdsa.Verify(pub.ToECDSA(), hash, r, s)
+}
+
+func TestSingleSeedSleeve_SignECDSA(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	privKey, _ := sleeve.GetPrivateKey("Bitcoin")
+	pub, _ := secp256k1PrivateKey(privKey)
+
+	for i := 0; i < 16; i++ {
+		hash := keccak256([]byte{byte(i)})
+
+		// DER parses with a standard decoder, and s is canonical
+		der, err := sleeve.SignECDSA("Bitcoin", hash, SigFormatDER)
+		if err != nil {
+			t.Fatalf("SignECDSA() returned error: %v", err)
+		}
+		var parsed struct{ R, S *big.Int }
+		rest, err := asn1.Unmarshal(der, &parsed)
+		if err != nil || len(rest) != 0 {
+			t.Fatalf("SignECDSA() DER signature doesn't parse: %v", err)
+		}
+		if parsed.S.Cmp(secp256k1HalfN) > 0 {
+			t.Fatalf("SignECDSA() returned non canonical s")
+		}
+		if !ecdsa.Verify(&pub.ToECDSA().PublicKey, hash, parsed.R, parsed.S) {
+			t.Fatalf("SignECDSA() DER signature doesn't verify")
+		}
+
+		// Compact is r || s of the same signature
+		compact, err := sleeve.SignECDSA("Bitcoin", hash, SigFormatCompact)
+		if err != nil {
+			t.Fatalf("SignECDSA() returned error: %v", err)
+		}
+		if len(compact) != 64 {
+			t.Fatalf("SignECDSA() compact signature has size %d", len(compact))
+		}
+		r := new(big.Int).SetBytes(compact[:32])
+		s := new(big.Int).SetBytes(compact[32:])
+		if r.Cmp(parsed.R) != 0 || s.Cmp(parsed.S) != 0 {
+			t.Fatalf("SignECDSA() compact and DER signatures differ")
+		}
+		if !verifySecp256k1(pub.PubKey().SerializeCompressed(), hash, compact) {
+			t.Fatalf("SignECDSA() compact signature doesn't verify")
+		}
+	}
+
+	// Errors
+	hash := keccak256([]byte("msg"))
+	if _, err := sleeve.SignECDSA("Unknown", hash, SigFormatDER); err == nil {
+		t.Fatalf("SignECDSA() should return error for unknown network")
+	}
+	if _, err := sleeve.SignECDSA("Polkadot", hash, SigFormatDER); err == nil {
+		t.Fatalf("SignECDSA() should return error for a network not using secp256k1")
+	}
+	if _, err := sleeve.SignECDSA("Bitcoin", hash[:31], SigFormatDER); err == nil {
+		t.Fatalf("SignECDSA() should return error for wrong hash size")
+	}
+	if _, err := sleeve.SignECDSA("Bitcoin", hash, SigFormat(9)); err == nil {
+		t.Fatalf("SignECDSA() should return error for unknown format")
+	}
+}
+
+func TestSingleSeedSleeve_SignECDSADeterministic(t *testing.T) {
+	// RFC6979 vector for secp256k1: private key 1, SHA256("Satoshi Nakamoto")
+	key := make([]byte, 32)
+	key[31] = 1
+	sleeve := &SingleSeedSleeve{networkKeys: map[string]*NetworkKey{
+		"Bitcoin": {Network: "Bitcoin", CoinType: CoinTypeBitcoin, Curve: CurveSecp256k1, Key: key},
+	}}
+	hash := sha256.Sum256([]byte("Satoshi Nakamoto"))
+	sig, err := sleeve.SignECDSADeterministic("Bitcoin", hash[:])
+	if err != nil {
+		t.Fatalf("SignECDSADeterministic() returned error: %v", err)
+	}
+	expected := "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8" +
+		"2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5"
+	if hex.EncodeToString(sig) != expected {
+		t.Fatalf("SignECDSADeterministic() returned %x, expected %s", sig, expected)
+	}
+
+	// Repeated signings are byte-identical, in every format
+	derived, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	msg := keccak256([]byte("message"))
+	first, _ := derived.SignECDSADeterministic("Ethereum", msg)
+	firstDER, _ := derived.SignECDSA("Ethereum", msg, SigFormatDER)
+	for i := 0; i < 8; i++ {
+		sig, _ := derived.SignECDSADeterministic("Ethereum", msg)
+		der, _ := derived.SignECDSA("Ethereum", msg, SigFormatDER)
+		if !bytes.Equal(sig, first) || !bytes.Equal(der, firstDER) {
+			t.Fatalf("Signing the same hash twice should give identical signatures")
+		}
+	}
+}
@@ -0,0 +1,194 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// Mainnet chain IDs of the EVM chains, by coin type
+// Their keys get EIP-55 addresses, and transactions are signed with EIP-155 replay protection
+var evmChainIDs = map[uint32]uint64{
+	CoinTypeEthereum:        1,
+	CoinTypeEthereumClassic: 61,
+	CoinTypePolygon:         137,
+	CoinTypeFantom:          250,
+}
+
+// Get the mainnet chain ID of an EVM coin type
+// Returns false if the coin type isn't a known EVM chain
+func EVMChainID(coinType uint32) (uint64, bool) {
+	chainID, ok := evmChainIDs[coinType]
+	return chainID, ok
+}
+
+// Size of EVM addresses
+const evmAddressSize = 20
+
+// Legacy EVM transaction, signed with EIP-155 replay protection by SignEVMTransaction
+// Nil big integers are encoded as 0
+type EVMTransaction struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	Gas      uint64
+	// 20 byte recipient address, nil for contract creations
+	To    []byte
+	Value *big.Int
+	Data  []byte
+}
+
+// EVM transaction signed by SignEVMTransaction
+type SignedEVMTransaction struct {
+	// RLP encoded signed transaction, as sent with eth_sendRawTransaction
+	Raw []byte
+	// Transaction hash: Keccak-256 of Raw
+	Hash []byte
+	// EIP-155 signature values, V is chainID*2 + 35 + the recovery ID
+	V, R, S *big.Int
+}
+
+// Get the RLP fields of the transaction, without signature values
+func (tx *EVMTransaction) rlpFields() ([][]byte, error) {
+	if len(tx.To) != 0 && len(tx.To) != evmAddressSize {
+		return nil, fmt.Errorf("recipient address has size %d, expected %d", len(tx.To), evmAddressSize)
+	}
+	gasPrice, err := rlpBigInt(tx.GasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price: %v", err)
+	}
+	value, err := rlpBigInt(tx.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %v", err)
+	}
+	return [][]byte{rlpUint(tx.Nonce), gasPrice, rlpUint(tx.Gas), rlpBytes(tx.To), value, rlpBytes(tx.Data)}, nil
+}
+
+// Get the hash signed for the transaction on a chain
+// EIP-155: Keccak-256 of the RLP list (nonce, gasPrice, gas, to, value, data, chainID, 0, 0)
+func (tx *EVMTransaction) SigningHash(chainID *big.Int) ([]byte, error) {
+	if chainID == nil || chainID.Sign() <= 0 {
+		return nil, errors.New("chain ID must be positive")
+	}
+	fields, err := tx.rlpFields()
+	if err != nil {
+		return nil, err
+	}
+	id, _ := rlpBigInt(chainID)
+	fields = append(fields, id, rlpUint(0), rlpUint(0))
+	return keccak256(rlpList(fields...)), nil
+}
+
+// Sign an EVM transaction with the secp256k1 key of a network, for the given chain ID
+// The chain ID is always required and included in the signature (EIP-155), so the
+// transaction can't be replayed on another chain, e.g., Ethereum and Ethereum Classic
+// share coin type derivations in many wallets, but not chain IDs. EVMChainID gives the
+// mainnet chain ID of a coin type
+func (s *SingleSeedSleeve) SignEVMTransaction(network string, tx *EVMTransaction, chainID *big.Int) (*SignedEVMTransaction, error) {
+	if s.watchOnly {
+		return nil, ErrWatchOnly
+	}
+	netKey, exists := s.networkKeys[network]
+	if !exists {
+		return nil, fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	if netKey.Curve != CurveSecp256k1 {
+		return nil, fmt.Errorf("network %s uses %s, EVM transactions require secp256k1", network, netKey.Curve)
+	}
+	return signEVMTransaction(netKey.Key, tx, chainID)
+}
+
+// Sign an EVM transaction with a secp256k1 private key, see SignEVMTransaction
+func signEVMTransaction(key []byte, tx *EVMTransaction, chainID *big.Int) (*SignedEVMTransaction, error) {
+	if tx == nil {
+		return nil, errors.New("transaction can't be nil")
+	}
+	hash, err := tx.SigningHash(chainID)
+	if err != nil {
+		return nil, err
+	}
+	privKey, err := secp256k1PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	// Compact recoverable signature: 27 + recovery ID || r || s, with an RFC6979 nonce and low s
+	sig, err := btcec.SignCompact(btcec.S256(), privKey, hash, false)
+	if err != nil {
+		return nil, err
+	}
+	v := new(big.Int).Lsh(chainID, 1)
+	v.Add(v, big.NewInt(35+int64(sig[0]-27)))
+	r := new(big.Int).SetBytes(sig[1:33])
+	sv := new(big.Int).SetBytes(sig[33:])
+
+	fields, _ := tx.rlpFields()
+	vField, _ := rlpBigInt(v)
+	rField, _ := rlpBigInt(r)
+	sField, _ := rlpBigInt(sv)
+	raw := rlpList(append(fields, vField, rField, sField)...)
+	return &SignedEVMTransaction{Raw: raw, Hash: keccak256(raw), V: v, R: r, S: sv}, nil
+}
+
+// RLP encode a byte string
+func rlpBytes(b []byte) []byte {
+	if len(b) == 1 && b[0] < 0x80 {
+		return []byte{b[0]}
+	}
+	return append(rlpHeader(0x80, len(b)), b...)
+}
+
+// RLP encode an unsigned integer, as its big endian bytes without leading zeros
+func rlpUint(u uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, u)
+	for len(buf) > 0 && buf[0] == 0 {
+		buf = buf[1:]
+	}
+	return rlpBytes(buf)
+}
+
+// RLP encode a non-negative big integer, nil is encoded as 0
+func rlpBigInt(b *big.Int) ([]byte, error) {
+	if b == nil {
+		return rlpBytes(nil), nil
+	}
+	if b.Sign() < 0 {
+		return nil, errors.New("negative integers can't be RLP encoded")
+	}
+	return rlpBytes(b.Bytes()), nil
+}
+
+// RLP encode a list of encoded items
+func rlpList(items ...[]byte) []byte {
+	size := 0
+	for _, item := range items {
+		size += len(item)
+	}
+	out := rlpHeader(0xc0, size)
+	for _, item := range items {
+		out = append(out, item...)
+	}
+	return out
+}
+
+// Get the RLP header of a string (offset 0x80) or list (offset 0xc0) with a payload size
+// Payloads up to 55 bytes have their size in the header byte, longer ones its big endian bytes
+func rlpHeader(offset byte, size int) []byte {
+	if size <= 55 {
+		return []byte{offset + byte(size)}
+	}
+	sizeBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(sizeBytes, uint64(size))
+	for sizeBytes[0] == 0 {
+		sizeBytes = sizeBytes[1:]
+	}
+	return append([]byte{offset + 55 + byte(len(sizeBytes))}, sizeBytes...)
+}
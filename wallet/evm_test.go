@@ -0,0 +1,172 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// Recover the EIP-55 address of the sender of a signed EVM transaction on a chain
+func recoverEVMSender(tx *EVMTransaction, signed *SignedEVMTransaction, chainID *big.Int) (string, error) {
+	hash, err := tx.SigningHash(chainID)
+	if err != nil {
+		return "", err
+	}
+	recID := new(big.Int).Sub(signed.V, new(big.Int).Lsh(chainID, 1))
+	recID.Sub(recID, big.NewInt(35))
+	sig := make([]byte, 65)
+	sig[0] = 27 + byte(recID.Uint64())
+	signed.R.FillBytes(sig[1:33])
+	signed.S.FillBytes(sig[33:])
+	pub, _, err := btcec.RecoverCompact(btcec.S256(), sig, hash)
+	if err != nil {
+		return "", err
+	}
+	return ethereumAddress(pub), nil
+}
+
+func TestSignEVMTransaction_EIP155Example(t *testing.T) {
+	// Example of EIP-155: private key 0x4646...46 signing on chain ID 1
+	key := bytes.Repeat([]byte{0x46}, 32)
+	to, _ := hex.DecodeString("3535353535353535353535353535353535353535")
+	tx := &EVMTransaction{
+		Nonce:    9,
+		GasPrice: big.NewInt(20000000000),
+		Gas:      21000,
+		To:       to,
+		Value:    big.NewInt(1000000000000000000),
+	}
+	hash, err := tx.SigningHash(big.NewInt(1))
+	if err != nil {
+		t.Fatalf("SigningHash() returned error: %v", err)
+	}
+	if hex.EncodeToString(hash) != "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53" {
+		t.Fatalf("SigningHash() returned wrong hash %x", hash)
+	}
+	signed, err := signEVMTransaction(key, tx, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("signEVMTransaction() returned error: %v", err)
+	}
+	expected := "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a7640000" +
+		"8025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb7" +
+		"03304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+	if hex.EncodeToString(signed.Raw) != expected {
+		t.Fatalf("signEVMTransaction() returned %x, expected %s", signed.Raw, expected)
+	}
+	if signed.V.Uint64() != 37 || !bytes.Equal(signed.Hash, keccak256(signed.Raw)) {
+		t.Fatalf("signEVMTransaction() returned wrong V %d or hash %x", signed.V, signed.Hash)
+	}
+}
+
+func TestRLP(t *testing.T) {
+	long := bytes.Repeat([]byte{'a'}, 56)
+	tests := []struct {
+		encoded  []byte
+		expected string
+	}{
+		{rlpBytes(nil), "80"},
+		{rlpBytes([]byte{0x7f}), "7f"},
+		{rlpBytes([]byte{0x80}), "8180"},
+		{rlpBytes([]byte("dog")), "83646f67"},
+		{rlpBytes(long), "b838" + hex.EncodeToString(long)},
+		{rlpUint(0), "80"},
+		{rlpUint(15), "0f"},
+		{rlpUint(1024), "820400"},
+		{rlpList(), "c0"},
+		{rlpList(rlpBytes([]byte("cat")), rlpBytes([]byte("dog"))), "c88363617483646f67"},
+		{rlpList(rlpBytes(long)), "f83ab838" + hex.EncodeToString(long)},
+	}
+	for _, tt := range tests {
+		if hex.EncodeToString(tt.encoded) != tt.expected {
+			t.Fatalf("RLP encoding returned %x, expected %s", tt.encoded, tt.expected)
+		}
+	}
+	if _, err := rlpBigInt(big.NewInt(-1)); err == nil {
+		t.Fatalf("rlpBigInt() should return error for negative integers")
+	}
+}
+
+func TestSingleSeedSleeve_SignEVMTransaction_EthereumClassic(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	if err := sleeve.DeriveNetworkKey("EthereumClassic", CoinTypeEthereumClassic, seed); err != nil {
+		t.Fatalf("DeriveNetworkKey() returned error: %v", err)
+	}
+
+	// ETC keys get EIP-55 addresses, and their own key
+	addr, err := sleeve.GetAddress("EthereumClassic")
+	if err != nil {
+		t.Fatalf("GetAddress() returned error: %v", err)
+	}
+	pub, _ := sleeve.GetPublicKey("EthereumClassic")
+	if expected, _ := EthereumAddress(pub); addr != expected {
+		t.Fatalf("GetAddress() returned %s, expected the EIP-55 address %s", addr, expected)
+	}
+	ethAddr, _ := sleeve.GetAddress("Ethereum")
+	if addr == ethAddr {
+		t.Fatalf("Ethereum Classic should have a different key than Ethereum")
+	}
+
+	chainID, ok := EVMChainID(CoinTypeEthereumClassic)
+	if !ok || chainID != 61 {
+		t.Fatalf("EVMChainID(CoinTypeEthereumClassic) returned %d, %v, expected 61", chainID, ok)
+	}
+
+	to, _ := hex.DecodeString("00000000000000000000000000000000000000aa")
+	tx := &EVMTransaction{Nonce: 7, GasPrice: big.NewInt(1e9), Gas: 21000, To: to, Value: big.NewInt(1e18)}
+	signed, err := sleeve.SignEVMTransaction("EthereumClassic", tx, new(big.Int).SetUint64(chainID))
+	if err != nil {
+		t.Fatalf("SignEVMTransaction() returned error: %v", err)
+	}
+	if v := signed.V.Uint64(); v != 61*2+35 && v != 61*2+36 {
+		t.Fatalf("signed transaction has V %d, expected chain ID 61", v)
+	}
+
+	// The sender is recovered with ETC's chain ID, and not with Ethereum's
+	sender, err := recoverEVMSender(tx, signed, big.NewInt(61))
+	if err != nil {
+		t.Fatalf("recoverEVMSender() returned error: %v", err)
+	}
+	if sender != addr {
+		t.Fatalf("recovered sender %s, expected %s", sender, addr)
+	}
+	if sender, err := recoverEVMSender(tx, signed, big.NewInt(1)); err == nil && sender == addr {
+		t.Fatalf("ETC transaction should not be valid on chain ID 1")
+	}
+}
+
+func TestSingleSeedSleeve_SignEVMTransaction_Errors(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	tx := &EVMTransaction{Gas: 21000, GasPrice: big.NewInt(1)}
+
+	if _, err := sleeve.SignEVMTransaction("Ethereum", tx, nil); err == nil {
+		t.Fatalf("SignEVMTransaction() should return error for nil chain ID")
+	}
+	if _, err := sleeve.SignEVMTransaction("Ethereum", tx, big.NewInt(0)); err == nil {
+		t.Fatalf("SignEVMTransaction() should return error for zero chain ID")
+	}
+	if _, err := sleeve.SignEVMTransaction("Ethereum", nil, big.NewInt(1)); err == nil {
+		t.Fatalf("SignEVMTransaction() should return error for nil transaction")
+	}
+	if _, err := sleeve.SignEVMTransaction("Ethereum", &EVMTransaction{To: make([]byte, 19)}, big.NewInt(1)); err == nil {
+		t.Fatalf("SignEVMTransaction() should return error for a wrong recipient address size")
+	}
+	if _, err := sleeve.SignEVMTransaction("Ethereum", &EVMTransaction{Value: big.NewInt(-1)}, big.NewInt(1)); err == nil {
+		t.Fatalf("SignEVMTransaction() should return error for a negative value")
+	}
+	if _, err := sleeve.SignEVMTransaction("Polkadot", tx, big.NewInt(1)); err == nil {
+		t.Fatalf("SignEVMTransaction() should return error for sr25519 network")
+	}
+	if _, err := sleeve.SignEVMTransaction("Unknown", tx, big.NewInt(1)); err == nil {
+		t.Fatalf("SignEVMTransaction() should return error for unknown network")
+	}
+}
@@ -0,0 +1,129 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/vedhavyas/go-subkey"
+	sr "github.com/vedhavyas/go-subkey/sr25519"
+)
+
+// Derive the standard networks of a spec both from a dual-mnemonic sleeve and a single-seed sleeve
+// of the same mnemonic, to show users migrating to single-seed that their addresses change
+// dualAddrs are those of a standard wallet restored from the legacy output mnemonic:
+// BIP44 m/44'/coin'/account'/0/0 for secp256k1 networks, and the Polkadot{.js} sr25519
+// key of the mnemonic for Polkadot. singleAddrs are those of the single-seed sleeve.
+// Both are keyed by network name. Networks without an address format are given as
+// the hex encoded public key instead. Returns an error for ed25519 networks, since
+// standard ed25519 wallets use SLIP-0010, which isn't supported
+func CompareStandardVsSingleSeed(mnemonic, passphrase string, spec GenSpec) (dualAddrs, singleAddrs map[string]string, err error) {
+	dual, err := NewSleeveFromMnemonic(mnemonic, passphrase, spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate dual-mnemonic sleeve: %w", err)
+	}
+	single, err := NewSingleSeedSleeveFromMnemonic(mnemonic, passphrase, spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate single-seed sleeve: %w", err)
+	}
+	defer single.Wipe()
+
+	networks := spec.standardNetworkList()
+	dualAddrs = make(map[string]string, len(networks))
+	singleAddrs = make(map[string]string, len(networks))
+	for _, net := range networks {
+		dualAddrs[net.Name], err = standardWalletAddress(dual.GetOutputMnemonic(), net.CoinType, spec.account)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to derive %s from the output mnemonic: %w", net.Name, err)
+		}
+		netKey, exists := single.networkKeys[net.Name]
+		if !exists {
+			if err = single.DeriveNetworkKey(net.Name, net.CoinType, single.seed); err != nil {
+				return nil, nil, err
+			}
+			netKey = single.networkKeys[net.Name]
+		}
+		singleAddrs[net.Name], err = addressOrPublicKey(netKey)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return dualAddrs, singleAddrs, nil
+}
+
+// Get the address of a standard wallet restored from a mnemonic, for the first address of a coin type
+func standardWalletAddress(mnemonic string, coinType, account uint32) (string, error) {
+	switch CurveForCoinType(coinType) {
+	case CurveSr25519:
+		// Polkadot{.js} derives the sr25519 key from the mnemonic itself
+		kp, err := subkey.DeriveKeyPair(sr.Scheme{}, mnemonic)
+		if err != nil {
+			return "", err
+		}
+		return generateSS58Address(polkadotPrefix, kp.Public()), nil
+	case CurveSecp256k1:
+		seed := bip39.NewSeed(mnemonic, "")
+		defer zero(seed)
+		path := Path{purpose, coinType | firstHardened, account | firstHardened, 0, 0}
+		node, err := deriveNodeAtPath(seed, path)
+		if err != nil {
+			return "", err
+		}
+		defer zero(node.Key)
+		return addressOrPublicKey(&NetworkKey{CoinType: coinType, Curve: CurveSecp256k1, Key: node.Key})
+	default:
+		return "", fmt.Errorf("standard derivation for coin type %d isn't supported", coinType)
+	}
+}
+
+// Get the address of a network key, or its hex encoded public key if it has no address format
+func addressOrPublicKey(netKey *NetworkKey) (string, error) {
+	addr, err := networkAddress(netKey)
+	if err != nil || addr != "" {
+		return addr, err
+	}
+	pub, err := networkPublicKey(netKey)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(pub), nil
+}
+
+// Get the xx network address a dual-mnemonic Sleeve of the same mnemonic, passphrase
+// and spec would give, i.e., XXNetworkAddressFromMnemonic of its output mnemonic
+// A single-seed sleeve has no output mnemonic of its own, but it's computed the same way
+// from the quantum path node the WOTS+ key is generated from, so the legacy address can
+// be shown to users comparing both modes. Index 0 is the address of the output mnemonic,
+// as given by the legacy wallet, and other indexes use the "//index" hard derivation
+// of Polkadot{.js}. Returns an error once the sleeve is wiped, since the quantum path
+// node is needed, and the single-seed network keys are unrelated to the legacy address
+func (s *SingleSeedSleeve) GetLegacyStandardAddress(index uint32) (string, error) {
+	output, err := s.legacyOutputMnemonic()
+	if err != nil {
+		return "", err
+	}
+	uri := output
+	if index > 0 {
+		uri = fmt.Sprintf("%s//%d", output, index)
+	}
+	kp, err := subkey.DeriveKeyPair(sr.Scheme{}, uri)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive legacy standard key: %v", err)
+	}
+	return generateSS58Address(xxNetworkPrefix, kp.Public()), nil
+}
+
+// Compute the output mnemonic of the dual-mnemonic Sleeve equivalent to the single-seed sleeve
+func (s *SingleSeedSleeve) legacyOutputMnemonic() (string, error) {
+	if s.wotsSeed == nil {
+		return "", errors.New("sleeve was wiped, so the legacy output mnemonic can't be computed: " +
+			"single-seed has no separate standard mnemonic, its network keys are derived from the input mnemonic")
+	}
+	return bip39.NewMnemonic(sleeveOutput(s.wotsSeed, s.wotsPK))
+}
@@ -0,0 +1,116 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"testing"
+)
+
+func TestStandardWalletAddress(t *testing.T) {
+	// Well known first account of the "test ... junk" development mnemonic, at m/44'/60'/0'/0/0
+	mnemonic := "test test test test test test test test test test test junk"
+	addr, err := standardWalletAddress(mnemonic, CoinTypeEthereum, 0)
+	if err != nil {
+		t.Fatalf("standardWalletAddress() returned error: %v", err)
+	}
+	if expected := "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"; addr != expected {
+		t.Fatalf("standardWalletAddress() returned %s, expected %s", addr, expected)
+	}
+
+	if _, err := standardWalletAddress(mnemonic, CoinTypeSolana, 0); err == nil {
+		t.Fatalf("standardWalletAddress() should return error for ed25519 networks")
+	}
+}
+
+func TestCompareStandardVsSingleSeed(t *testing.T) {
+	dualAddrs, singleAddrs, err := CompareStandardVsSingleSeed(testVectorMnemonic, "", DefaultGenSpec())
+	if err != nil {
+		t.Fatalf("CompareStandardVsSingleSeed() returned error: %v", err)
+	}
+	if len(dualAddrs) != len(standardNetworks) || len(singleAddrs) != len(standardNetworks) {
+		t.Fatalf("CompareStandardVsSingleSeed() should return every standard network, got %d and %d",
+			len(dualAddrs), len(singleAddrs))
+	}
+
+	// Dual-mnemonic addresses come from the output mnemonic
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	for _, net := range standardNetworks {
+		expected, _ := standardWalletAddress(expectedOutputMnemonic, net.CoinType, 0)
+		if dualAddrs[net.Name] != expected {
+			t.Fatalf("%s dual-mnemonic address %s doesn't match the output mnemonic address %s",
+				net.Name, dualAddrs[net.Name], expected)
+		}
+		if dualAddrs[net.Name] == singleAddrs[net.Name] {
+			t.Fatalf("%s addresses shouldn't match between dual-mnemonic and single-seed", net.Name)
+		}
+	}
+	ethAddr, _ := sleeve.GetAddress("Ethereum")
+	if singleAddrs["Ethereum"] != ethAddr {
+		t.Fatalf("Single-seed Ethereum address %s doesn't match the sleeve's %s", singleAddrs["Ethereum"], ethAddr)
+	}
+
+	btcAddr, _ := sleeve.GetAddress("Bitcoin")
+	if singleAddrs["Bitcoin"] != btcAddr {
+		t.Fatalf("Single-seed Bitcoin address %s doesn't match the sleeve's %s", singleAddrs["Bitcoin"], btcAddr)
+	}
+
+	// Standard ed25519 derivation isn't supported
+	spec := DefaultGenSpec()
+	spec.standards = []StandardNetwork{{"Solana", CoinTypeSolana}}
+	if _, _, err := CompareStandardVsSingleSeed(testVectorMnemonic, "", spec); err == nil {
+		t.Fatalf("CompareStandardVsSingleSeed() should return error for ed25519 networks")
+	}
+
+	if _, _, err := CompareStandardVsSingleSeed("invalid mnemonic", "", DefaultGenSpec()); err == nil {
+		t.Fatalf("CompareStandardVsSingleSeed() should return error for invalid mnemonic")
+	}
+}
+
+func TestSingleSeedSleeve_GetLegacyStandardAddress(t *testing.T) {
+	spec := DefaultGenSpec()
+	legacy, err := NewSleeveFromMnemonic(testVectorMnemonic, "", spec)
+	if err != nil {
+		t.Fatalf("NewSleeveFromMnemonic() returned error: %v", err)
+	}
+	sleeve, err := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", spec)
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonic() returned error: %v", err)
+	}
+
+	// Index 0 is the address the legacy wallet gives for its output mnemonic
+	addr, err := sleeve.GetLegacyStandardAddress(0)
+	if err != nil {
+		t.Fatalf("GetLegacyStandardAddress() returned error: %v", err)
+	}
+	expected := XXNetworkAddressFromMnemonic(legacy.GetOutputMnemonic())
+	if addr != expected {
+		t.Fatalf("GetLegacyStandardAddress(0) returned %s, expected %s", addr, expected)
+	}
+	if expected != XXNetworkAddressFromMnemonic(expectedOutputMnemonic) {
+		t.Fatalf("legacy output mnemonic doesn't match the test vector")
+	}
+
+	// Other indexes are hard derivations of the output mnemonic
+	addr1, err := sleeve.GetLegacyStandardAddress(1)
+	if err != nil {
+		t.Fatalf("GetLegacyStandardAddress() returned error: %v", err)
+	}
+	if addr1 == addr {
+		t.Fatalf("GetLegacyStandardAddress(1) should differ from index 0")
+	}
+	if addr1 != XXNetworkAddressFromMnemonic(legacy.GetOutputMnemonic()+"//1") {
+		t.Fatalf("GetLegacyStandardAddress(1) should use the //1 hard derivation")
+	}
+	if ok, err := ValidateXXNetworkAddress(addr1); !ok || err != nil {
+		t.Fatalf("GetLegacyStandardAddress(1) returned invalid address %s: %v", addr1, err)
+	}
+
+	sleeve.Wipe()
+	if _, err := sleeve.GetLegacyStandardAddress(0); err == nil {
+		t.Fatalf("GetLegacyStandardAddress() should return error after Wipe")
+	}
+}
@@ -0,0 +1,128 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RowError is the error of a row of a network configuration CSV
+type RowError struct {
+	Line int // Line number, starting at 1
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// NetworkImportError holds the errors of all rows of a network configuration CSV
+// that couldn't be derived, in line order
+type NetworkImportError struct {
+	Rows []*RowError
+}
+
+func (e *NetworkImportError) Error() string {
+	msgs := make([]string, len(e.Rows))
+	for i, row := range e.Rows {
+		msgs[i] = row.Error()
+	}
+	return fmt.Sprintf("failed to derive %d networks: %s", len(e.Rows), strings.Join(msgs, "; "))
+}
+
+// Derive the networks listed in a CSV, one "name,cointype[,curve]" row per network
+// The optional curve must be the one used for the coin type, e.g., "ed25519" for Solana.
+// Empty lines, lines starting with '#', and a "name,cointype" header as the first other row are skipped.
+// Rows are read and derived one at a time, and valid rows are derived even if others fail:
+// errors of malformed or failed rows are returned together as a *NetworkImportError,
+// with their line numbers. Errors reading r or a wrong seed are returned directly
+func (s *SingleSeedSleeve) DeriveNetworksFromReader(r io.Reader, seed []byte) error {
+	if err := s.checkSeed(seed); err != nil {
+		return err
+	}
+
+	var rowErrors []*RowError
+	names := make(map[string]int)
+	firstRow := true
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, coinType, err := parseNetworkRow(text)
+		if err == errHeaderRow && firstRow {
+			firstRow = false
+			continue
+		}
+		firstRow = false
+		if err == nil {
+			if first, dup := names[name]; dup {
+				err = fmt.Errorf("network %s is already listed on line %d", name, first)
+			} else {
+				names[name] = line
+				err = s.DeriveNetworkKey(name, coinType, seed)
+			}
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, &RowError{Line: line, Err: err})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read networks: %w", err)
+	}
+
+	if len(rowErrors) > 0 {
+		return &NetworkImportError{Rows: rowErrors}
+	}
+	return nil
+}
+
+// Returned by parseNetworkRow for a "name,cointype" header
+var errHeaderRow = errors.New("header row")
+
+// Parse a "name,cointype[,curve]" CSV row
+func parseNetworkRow(text string) (string, uint32, error) {
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.TrimLeadingSpace = true
+	fields, err := reader.Read()
+	if err != nil {
+		return "", 0, fmt.Errorf("malformed row: %v", err)
+	}
+	if len(fields) < 2 || len(fields) > 3 {
+		return "", 0, fmt.Errorf("malformed row: expected name,cointype[,curve], got %d fields", len(fields))
+	}
+
+	name := strings.TrimSpace(fields[0])
+	if strings.EqualFold(name, "name") && strings.EqualFold(strings.TrimSpace(fields[1]), "cointype") {
+		return "", 0, errHeaderRow
+	}
+	if name == "" {
+		return "", 0, errors.New("network name can't be empty")
+	}
+	coinType, err := strconv.ParseUint(strings.TrimSpace(fields[1]), 10, 32)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid coin type %q", fields[1])
+	}
+	if len(fields) == 3 {
+		curve := strings.TrimSpace(fields[2])
+		if expected := CurveForCoinType(uint32(coinType)); curve != "" && !strings.EqualFold(curve, expected.String()) {
+			return "", 0, fmt.Errorf("coin type %d uses curve %s, got %s", coinType, expected, curve)
+		}
+	}
+	return name, uint32(coinType), nil
+}
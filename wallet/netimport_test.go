@@ -0,0 +1,88 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSingleSeedSleeve_DeriveNetworksFromReader(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+
+	csv := strings.Join([]string{
+		"name,cointype,curve",
+		"Solana,501,ed25519",
+		"",
+		"# Comment",
+		"Litecoin, 2",
+		"Broken",
+		"Cardano,1815,secp256k1",
+		"Dogecoin,notanumber",
+		"\"Quoted, name\",3",
+		"Huge,2147483648",
+		"Litecoin,2",
+		"Bitcoin,1",
+	}, "\n")
+
+	err := sleeve.DeriveNetworksFromReader(strings.NewReader(csv), seed)
+	var importErr *NetworkImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("DeriveNetworksFromReader() should return a NetworkImportError, got: %v", err)
+	}
+
+	// Failed rows are reported with line numbers
+	expectedLines := []int{6, 7, 8, 10, 11, 12}
+	if len(importErr.Rows) != len(expectedLines) {
+		t.Fatalf("DeriveNetworksFromReader() returned %d row errors, expected %d: %v",
+			len(importErr.Rows), len(expectedLines), err)
+	}
+	for i, line := range expectedLines {
+		if importErr.Rows[i].Line != line {
+			t.Fatalf("Row error %d has line %d, expected %d", i, importErr.Rows[i].Line, line)
+		}
+		if !strings.Contains(err.Error(), importErr.Rows[i].Error()) {
+			t.Fatalf("Import error doesn't mention row error %q", importErr.Rows[i])
+		}
+	}
+	var coinErr *InvalidCoinTypeError
+	if !errors.As(importErr.Rows[3], &coinErr) {
+		t.Fatalf("Row errors should wrap derivation errors, got: %v", importErr.Rows[3])
+	}
+
+	// Valid rows are derived
+	for name, coinType := range map[string]uint32{"Solana": CoinTypeSolana, "Litecoin": CoinTypeLitecoin, "Quoted, name": 3} {
+		expected, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+		_ = expected.DeriveNetworkKey(name, coinType, seed)
+		key, err := sleeve.GetPrivateKey(name)
+		expectedKey, _ := expected.GetPrivateKey(name)
+		if err != nil || !bytes.Equal(key, expectedKey) {
+			t.Fatalf("DeriveNetworksFromReader() didn't derive %s: %v", name, err)
+		}
+	}
+
+	// No errors for a valid file
+	if err := sleeve.DeriveNetworksFromReader(strings.NewReader("Dash,5\nTezos,1729,ED25519\n"), seed); err != nil {
+		t.Fatalf("DeriveNetworksFromReader() returned error: %v", err)
+	}
+
+	// A header after comments and empty lines is skipped, but not one after other rows
+	header := "# Networks\n\nname,cointype\nNear,397\nname,cointype\n"
+	err = sleeve.DeriveNetworksFromReader(strings.NewReader(header), seed)
+	if !errors.As(err, &importErr) || len(importErr.Rows) != 1 || importErr.Rows[0].Line != 5 {
+		t.Fatalf("DeriveNetworksFromReader() should only reject the second header, got: %v", err)
+	}
+
+	// Wrong seed fails the whole import
+	err = sleeve.DeriveNetworksFromReader(strings.NewReader("Dash,5\n"), mustSeed(wotsTestVectorMnemonic))
+	if !errors.Is(err, ErrSeedMismatch) {
+		t.Fatalf("DeriveNetworksFromReader() should return ErrSeedMismatch for wrong seed, got: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xx-labs/sleeve/wots"
+)
+
+// Options for NewSingleSeedSleeveWithOptions
+type sleeveOptions struct {
+	passphrase string
+	spec       GenSpec
+}
+
+// Option configures a single-seed sleeve created with NewSingleSeedSleeveWithOptions
+type Option func(*sleeveOptions) error
+
+// Use a BIP39 passphrase (default: none)
+func WithPassphrase(passphrase string) Option {
+	return func(o *sleeveOptions) error {
+		o.passphrase = passphrase
+		return nil
+	}
+}
+
+// Use a BIP44 account, which must be less than 2^31 (default: 0)
+func WithAccount(account uint32) Option {
+	return func(o *sleeveOptions) error {
+		if account >= firstHardened {
+			return fmt.Errorf("invalid account %d: must be less than 2^31", account)
+		}
+		o.spec.account = account
+		return nil
+	}
+}
+
+// Use a WOTS+ security level (default: wots.DefaultParams)
+func WithWOTSLevel(level wots.ParamsEncoding) Option {
+	return func(o *sleeveOptions) error {
+		if wots.DecodeParams(level) == nil {
+			return errors.New("unknown WOTS+ params encoding")
+		}
+		o.spec.params = level
+		return nil
+	}
+}
+
+// Derive the given networks automatically, instead of Bitcoin, Ethereum and Polkadot
+// With no networks, no network is derived automatically
+func WithStandardNetworks(networks ...StandardNetwork) Option {
+	return func(o *sleeveOptions) error {
+		seen := make(map[string]bool, len(networks))
+		for _, net := range networks {
+			if net.Name == "" {
+				return errors.New("standard network name can't be empty")
+			}
+			if seen[net.Name] {
+				return fmt.Errorf("duplicate standard network %s", net.Name)
+			}
+			if net.CoinType >= firstHardened {
+				return &InvalidCoinTypeError{CoinType: net.CoinType}
+			}
+			seen[net.Name] = true
+		}
+		o.spec.standards = append([]StandardNetwork{}, networks...)
+		return nil
+	}
+}
+
+// Don't derive the standard networks on creation, to create sleeves faster
+// They can be derived later with DeriveStandardNetworks
+func WithLazyDerivation() Option {
+	return func(o *sleeveOptions) error {
+		o.spec.lazyDerivation = true
+		return nil
+	}
+}
+
+// Use a generation spec as is, for constructors taking a GenSpec
+func withGenSpec(spec GenSpec) Option {
+	return func(o *sleeveOptions) error {
+		o.spec = spec
+		return nil
+	}
+}
+
+// Create a single-seed sleeve reading entropy from the provided CSPRNG, configured by options
+// Without options, this is the same as NewSingleSeedSleeve with no passphrase and DefaultGenSpec
+func NewSingleSeedSleeveWithOptions(csprng io.Reader, opts ...Option) (*SingleSeedSleeve, error) {
+	o := sleeveOptions{spec: DefaultGenSpec()}
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return nil, err
+		}
+	}
+	spec := o.spec
+
+	// 1. Read EntropySize bytes of entropy from csprng
+	start := spec.startTiming()
+	ent := make([]byte, EntropySize)
+	if n, err := csprng.Read(ent); n != EntropySize || err != nil {
+		return nil, errors.New("couldn't read enough bytes of entropy from provided reader")
+	}
+
+	// 2. Reject obviously broken entropy, unless disabled in the spec
+	if !spec.skipEntropyCheck {
+		if err := checkEntropyHealth(ent); err != nil {
+			return nil, err
+		}
+	}
+	spec.reportTiming("entropy", start)
+
+	// 3. Get sleeve from entropy
+	return NewSingleSeedSleeveFromEntropy(ent, o.passphrase, spec)
+}
@@ -0,0 +1,94 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/xx-labs/sleeve/wots"
+)
+
+func TestNewSingleSeedSleeveWithOptions(t *testing.T) {
+	// Defaults match NewSingleSeedSleeve with DefaultGenSpec
+	sleeve, err := NewSingleSeedSleeveWithOptions(rand.Reader)
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveWithOptions() returned error: %v", err)
+	}
+	if sleeve.GetWOTSParams() != wots.DefaultParams || sleeve.HasPassphrase() ||
+		sleeve.StandardNetworkCount() != len(standardNetworks) {
+		t.Fatalf("NewSingleSeedSleeveWithOptions() without options doesn't use the defaults")
+	}
+
+	// Options compose
+	sleeve, err = NewSingleSeedSleeveWithOptions(rand.Reader,
+		WithPassphrase("pass"),
+		WithAccount(2),
+		WithWOTSLevel(wots.Level1),
+		WithStandardNetworks(StandardNetwork{"Solana", CoinTypeSolana}),
+	)
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveWithOptions() returned error: %v", err)
+	}
+	if !sleeve.HasPassphrase() || sleeve.GetWOTSParams() != wots.Level1 {
+		t.Fatalf("NewSingleSeedSleeveWithOptions() didn't apply the passphrase and WOTS+ level")
+	}
+	if names := strings.Join(sleeve.GetNetworkNames(), ","); names != "Solana" {
+		t.Fatalf("WithStandardNetworks() derived networks %s, expected Solana", names)
+	}
+	if path, _ := sleeve.GetFullPath("Solana"); !strings.HasPrefix(path, "m/44'/501'/0'/") {
+		t.Fatalf("WithAccount() should keep network keys under account 0', got path %s", path)
+	}
+
+	// Same wallet as the GenSpec constructors
+	expected, _ := NewSingleSeedSleeveFromMnemonic(sleeve.GetMnemonic(), "pass", NewGenSpec(2, wots.Level1))
+	if !bytes.Equal(sleeve.GetWOTSPublicKey(), expected.GetWOTSPublicKey()) {
+		t.Fatalf("NewSingleSeedSleeveWithOptions() generated a different wallet than NewGenSpec()")
+	}
+
+	// Only configured networks are guarded as standard networks
+	seed := sleeve.GetSeedUnsafe()
+	if err := sleeve.DeriveNetworkKey("Solana", CoinTypeStellar, seed); err == nil {
+		t.Fatalf("DeriveNetworkKey() should return error for a configured standard network")
+	}
+	if err := sleeve.DeriveNetworkKey("Bitcoin", CoinTypeLitecoin, seed); err != nil {
+		t.Fatalf("DeriveNetworkKey() returned error for a network that isn't standard: %v", err)
+	}
+}
+
+func TestWithLazyDerivation(t *testing.T) {
+	sleeve, err := NewSingleSeedSleeveWithOptions(rand.Reader, WithLazyDerivation())
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveWithOptions() returned error: %v", err)
+	}
+	if len(sleeve.GetAllNetworkKeys()) != 0 {
+		t.Fatalf("WithLazyDerivation() shouldn't derive networks on creation")
+	}
+	if err := sleeve.DeriveStandardNetworks(sleeve.GetSeedUnsafe()); err != nil {
+		t.Fatalf("DeriveStandardNetworks() returned error: %v", err)
+	}
+	if sleeve.StandardNetworkCount() != len(standardNetworks) {
+		t.Fatalf("DeriveStandardNetworks() should derive the standard networks of a lazy sleeve")
+	}
+}
+
+func TestNewSingleSeedSleeveWithOptions_Errors(t *testing.T) {
+	invalid := map[string]Option{
+		"account":           WithAccount(1 << 31),
+		"WOTS+ level":       WithWOTSLevel(wots.ParamsEncoding(99)),
+		"empty name":        WithStandardNetworks(StandardNetwork{"", CoinTypeBitcoin}),
+		"duplicate network": WithStandardNetworks(StandardNetwork{"A", 1}, StandardNetwork{"A", 2}),
+		"coin type":         WithStandardNetworks(StandardNetwork{"A", 1 << 31}),
+	}
+	for name, opt := range invalid {
+		if _, err := NewSingleSeedSleeveWithOptions(rand.Reader, opt); err == nil {
+			t.Fatalf("NewSingleSeedSleeveWithOptions() should return error for invalid %s", name)
+		}
+	}
+}
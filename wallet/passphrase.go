@@ -0,0 +1,134 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Estimated entropy below which PassphraseStrength warns a passphrase is weak
+// The BIP39 seed is only stretched with 2048 rounds of PBKDF2, so passphrases are cheap to guess offline
+const WeakPassphraseBits = 64
+
+// Common passwords, guessed first by any attacker
+var commonPassphrases = map[string]bool{
+	"password": true, "passw0rd": true, "passphrase": true, "123456": true, "12345678": true,
+	"123456789": true, "1234567890": true, "qwerty": true, "qwertyuiop": true, "letmein": true,
+	"welcome": true, "admin": true, "monkey": true, "dragon": true, "iloveyou": true,
+	"trustno1": true, "abc123": true, "secret": true, "sunshine": true, "princess": true,
+	"football": true, "baseball": true, "master": true, "shadow": true, "superman": true,
+	"bitcoin": true, "ethereum": true, "crypto": true, "wallet": true, "satoshi": true,
+	"hodl": true, "moon": true, "lambo": true, "xxnetwork": true,
+}
+
+// Bits of entropy of a word from a dictionary of the BIP39 English wordlist's size
+var dictionaryWordBits = math.Log2(2048)
+
+// Estimate the entropy of a BIP39 passphrase in bits, with warnings about weaknesses
+// The passphrase acts as a 25th mnemonic word, so a weak one adds little protection
+// if the mnemonic leaks. The estimate is deterministic and charset based: each character
+// costs log2 of the size of the character classes used, while runs of letters that are
+// common passwords or BIP39 words cost a dictionary word, and repeated characters cost 1 bit.
+// Warnings never include the passphrase, and nothing is stored
+func PassphraseStrength(passphrase string) (bits float64, warnings []string) {
+	if passphrase == "" {
+		return 0, []string{"no passphrase: the mnemonic alone recovers the wallet"}
+	}
+	runes := []rune(passphrase)
+	if commonPassphrases[strings.ToLower(passphrase)] {
+		return math.Log2(float64(len(commonPassphrases))),
+			[]string{"passphrase is a common password"}
+	}
+
+	charBits := math.Log2(float64(charsetSize(runes)))
+	hasWords, hasRepeats := false, false
+	for i := 0; i < len(runes); {
+		// Letter runs found in a dictionary are guessed as whole words
+		if unicode.IsLetter(runes[i]) {
+			j := i
+			for j < len(runes) && unicode.IsLetter(runes[j]) {
+				j++
+			}
+			if word := strings.ToLower(string(runes[i:j])); isDictionaryWord(word) {
+				bits += dictionaryWordBits
+				hasWords = true
+				i = j
+				continue
+			}
+		}
+		if i > 0 && runes[i] == runes[i-1] {
+			bits++
+			hasRepeats = true
+		} else {
+			bits += charBits
+		}
+		i++
+	}
+
+	if hasWords {
+		warnings = append(warnings, "passphrase contains common or BIP39 words, which are guessed as whole words")
+	}
+	if hasRepeats {
+		warnings = append(warnings, "passphrase contains repeated characters")
+	}
+	if len(runes) < 12 {
+		warnings = append(warnings, "passphrase is shorter than 12 characters")
+	}
+	if bits < WeakPassphraseBits {
+		warnings = append(warnings, fmt.Sprintf("passphrase is weak: estimated %.0f bits, at least %d recommended",
+			bits, WeakPassphraseBits))
+	}
+	return bits, warnings
+}
+
+// Get the number of characters of the classes used by a passphrase:
+// lowercase, uppercase, digits, ASCII symbols and any other character
+func charsetSize(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	size := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.used {
+			size += class.size
+		}
+	}
+	return size
+}
+
+// Check whether a lowercase word is a common password or a BIP39 word
+// Words shorter than 3 letters are costed as characters
+func isDictionaryWord(word string) bool {
+	if len(word) < 3 {
+		return false
+	}
+	if commonPassphrases[word] {
+		return true
+	}
+	_, ok := bip39.GetWordIndex(word)
+	return ok
+}
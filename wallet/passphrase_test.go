@@ -0,0 +1,64 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPassphraseStrength(t *testing.T) {
+	// Long random passphrases score high, without warnings
+	random := "Xq7#pL9!vR2@mK4$wN8%tB"
+	bits, warnings := PassphraseStrength(random)
+	if bits < 128 {
+		t.Fatalf("PassphraseStrength() returned %.1f bits for a random passphrase, expected at least 128", bits)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("PassphraseStrength() returned warnings for a random passphrase: %v", warnings)
+	}
+
+	// Common passwords and dictionary words score low, with warnings
+	weak := []string{"password", "Password", "abandon", "bitcoin2021", "satoshi satoshi", "aaaaaaaaaaaaaaaa", "hello"}
+	for _, passphrase := range weak {
+		bits, warnings := PassphraseStrength(passphrase)
+		if bits >= WeakPassphraseBits {
+			t.Fatalf("PassphraseStrength() returned %.1f bits for a weak passphrase, expected less than %d",
+				bits, WeakPassphraseBits)
+		}
+		if len(warnings) == 0 {
+			t.Fatalf("PassphraseStrength() should warn about a weak passphrase")
+		}
+	}
+
+	// Warnings never leak the passphrase
+	_, warnings = PassphraseStrength("zebra7zebra")
+	for _, w := range warnings {
+		if strings.Contains(w, "zebra") {
+			t.Fatalf("PassphraseStrength() warning contains the passphrase: %q", w)
+		}
+	}
+
+	// Dictionary words cost less than random letters of the same length
+	wordBits, _ := PassphraseStrength("abandon")
+	lettersBits, _ := PassphraseStrength("qzvxkwj")
+	if wordBits >= lettersBits {
+		t.Fatalf("PassphraseStrength() should score a BIP39 word (%.1f bits) below random letters (%.1f bits)",
+			wordBits, lettersBits)
+	}
+
+	// Empty passphrases have no entropy
+	if bits, warnings := PassphraseStrength(""); bits != 0 || len(warnings) != 1 {
+		t.Fatalf("PassphraseStrength() returned %.1f bits and %v for an empty passphrase", bits, warnings)
+	}
+
+	// The estimate is deterministic
+	again, _ := PassphraseStrength(random)
+	if again != bits {
+		t.Fatalf("PassphraseStrength() should be deterministic: %.1f and %.1f bits", bits, again)
+	}
+}
@@ -0,0 +1,139 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/sha3"
+)
+
+// Parse a 32 byte secp256k1 private key
+// The key must be in [1, N-1], as required by BIP32
+func secp256k1PrivateKey(key []byte) (*btcec.PrivateKey, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("invalid secp256k1 private key size %d, expected %d", len(key), keySize)
+	}
+	k := new(big.Int).SetBytes(key)
+	if k.Sign() == 0 || k.Cmp(N) >= 0 {
+		return nil, errors.New("invalid secp256k1 private key, must be in [1, N-1]")
+	}
+	privKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), key)
+	return privKey, nil
+}
+
+// Get the secp256k1 public key of a 32 byte private key
+// The key must be in [1, N-1], as required by BIP32
+func secp256k1PublicKey(key []byte) (*btcec.PublicKey, error) {
+	privKey, err := secp256k1PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return privKey.PubKey(), nil
+}
+
+// Get the compressed secp256k1 public key (33 bytes) of a 32 byte private key
+func compressedPublicKey(key []byte) ([]byte, error) {
+	pub, err := secp256k1PublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pub.SerializeCompressed(), nil
+}
+
+// Compute the legacy Keccak-256 hash used by Ethereum, which differs from SHA3-256 in its padding
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// Get the EIP-55 checksummed Ethereum address of a secp256k1 public key,
+// compressed (33 bytes) or uncompressed (65 bytes)
+// The address is the last 20 bytes of the Keccak-256 hash of the uncompressed key,
+// without its 0x04 prefix, as used by every EVM chain
+func EthereumAddress(pubkey []byte) (string, error) {
+	pub, err := btcec.ParsePubKey(pubkey, btcec.S256())
+	if err != nil {
+		return "", err
+	}
+	return ethereumAddress(pub), nil
+}
+
+// Get the EIP-55 checksummed Ethereum address of a parsed public key
+func ethereumAddress(pub *btcec.PublicKey) string {
+	addr := hex.EncodeToString(keccak256(pub.SerializeUncompressed()[1:])[12:])
+
+	// EIP-55: uppercase each letter whose nibble in the hash of the lowercase hex address is >= 8
+	checksum := keccak256([]byte(addr))
+	out := []byte(addr)
+	for i, c := range out {
+		nibble := checksum[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c >= 'a' && nibble&0xf >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// Get the EIP-55 checksummed address of a 32 byte secp256k1 private key, shared by all EVM chains
+// Useful for keys held outside a sleeve. Returns an error if the key isn't in [1, N-1]
+func EVMAddressFromPrivateKey(privKey []byte) (string, error) {
+	pub, err := secp256k1PublicKey(privKey)
+	if err != nil {
+		return "", err
+	}
+	return ethereumAddress(pub), nil
+}
+
+// Get the EIP-55 checksummed Ethereum address of the secp256k1 key of a network
+// Works for any secp256k1 network, so the EVM address of a key derived for another
+// coin type can be shown, e.g., for chains missing from the EVM registry
+func (s *SingleSeedSleeve) GetEthereumAddress(network string) (string, error) {
+	netKey, exists := s.networkKeys[network]
+	if !exists {
+		return "", fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	if netKey.Curve != CurveSecp256k1 {
+		return "", fmt.Errorf("network %s uses %s, Ethereum addresses require secp256k1", network, netKey.Curve)
+	}
+	pub, err := networkPublicKey(netKey)
+	if err != nil {
+		return "", err
+	}
+	return EthereumAddress(pub)
+}
+
+// Get the compressed (33 bytes) and uncompressed (65 bytes) secp256k1 public keys of a network
+// Both are computed from the network's public key at once, so callers don't need to convert
+// between formats. Returns an error for networks not on secp256k1, e.g., ed25519 networks,
+// whose public keys have a single format
+func (s *SingleSeedSleeve) GetPublicKeyFormats(network string) (compressed, uncompressed []byte, err error) {
+	netKey, exists := s.networkKeys[network]
+	if !exists {
+		return nil, nil, fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	if netKey.Curve != CurveSecp256k1 {
+		return nil, nil, fmt.Errorf("network %s uses %s, compressed and uncompressed public keys only apply to secp256k1",
+			network, netKey.Curve)
+	}
+	if compressed, err = networkPublicKey(netKey); err != nil {
+		return nil, nil, err
+	}
+	pub, err := btcec.ParsePubKey(compressed, btcec.S256())
+	if err != nil {
+		return nil, nil, err
+	}
+	return compressed, pub.SerializeUncompressed(), nil
+}
@@ -0,0 +1,180 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+func TestEthereumAddress(t *testing.T) {
+	// Private key 1, i.e., the generator point
+	key, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000001")
+	pub, err := compressedPublicKey(key)
+	if err != nil {
+		t.Fatalf("compressedPublicKey() returned error: %v", err)
+	}
+	if hex.EncodeToString(pub) != "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" {
+		t.Fatalf("compressedPublicKey() returned wrong key %x", pub)
+	}
+	addr, err := EthereumAddress(pub)
+	if err != nil {
+		t.Fatalf("EthereumAddress() returned error: %v", err)
+	}
+	if addr != "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf" {
+		t.Fatalf("EthereumAddress() returned %s", addr)
+	}
+
+	// Well known addresses of private keys 2 and 3
+	vectors := map[byte]string{
+		2: "0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF",
+		3: "0x6813Eb9362372EEF6200f3b1dbC3f819671cBA69",
+	}
+	for k, expected := range vectors {
+		key[31] = k
+		if addr, _ := EVMAddressFromPrivateKey(key); addr != expected {
+			t.Fatalf("EVMAddressFromPrivateKey() returned %s for key %d, expected %s", addr, k, expected)
+		}
+	}
+
+	// Same results for random keys, compressed or not
+	for i := 0; i < 20; i++ {
+		privKey, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("NewPrivateKey() returned error: %v", err)
+		}
+		compressed, err := compressedPublicKey(privKey.Serialize())
+		if err != nil {
+			t.Fatalf("compressedPublicKey() returned error: %v", err)
+		}
+		if !bytes.Equal(compressed, privKey.PubKey().SerializeCompressed()) {
+			t.Fatalf("compressedPublicKey() returned %x, expected %x", compressed, privKey.PubKey().SerializeCompressed())
+		}
+		expected, _ := EthereumAddress(compressed)
+		addr, err := EthereumAddress(privKey.PubKey().SerializeUncompressed())
+		if err != nil {
+			t.Fatalf("EthereumAddress() returned error: %v", err)
+		}
+		if addr != expected {
+			t.Fatalf("EthereumAddress() returned %s for the uncompressed key, expected %s", addr, expected)
+		}
+	}
+
+	invalid := make([]byte, 33)
+	rand.Read(invalid[1:])
+	invalid[0] = 5
+	if _, err := EthereumAddress(invalid); err == nil {
+		t.Fatalf("EthereumAddress() should return error for invalid public key")
+	}
+}
+
+func TestCompressedPublicKey_InvalidKey(t *testing.T) {
+	n := N.Bytes()
+	for _, key := range [][]byte{make([]byte, 32), n, make([]byte, 31), nil} {
+		if _, err := compressedPublicKey(key); err == nil {
+			t.Fatalf("compressedPublicKey() should return error for key %x", key)
+		}
+	}
+}
+
+func TestSingleSeedSleeve_GetEthereumAddress(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+
+	addr, err := sleeve.GetEthereumAddress("Ethereum")
+	if err != nil {
+		t.Fatalf("GetEthereumAddress() returned error: %v", err)
+	}
+	expected, _ := sleeve.GetAddress("Ethereum")
+	if addr != expected {
+		t.Fatalf("GetEthereumAddress() returned %s, expected %s", addr, expected)
+	}
+
+	// Any secp256k1 key has an Ethereum address
+	btcKey, _ := sleeve.GetPrivateKey("Bitcoin")
+	expected, _ = EVMAddressFromPrivateKey(btcKey)
+	addr, err = sleeve.GetEthereumAddress("Bitcoin")
+	if err != nil {
+		t.Fatalf("GetEthereumAddress() returned error: %v", err)
+	}
+	if addr != expected || addr == "" {
+		t.Fatalf("GetEthereumAddress() returned %s for the Bitcoin key", addr)
+	}
+
+	if _, err := sleeve.GetEthereumAddress("Polkadot"); err == nil {
+		t.Fatalf("GetEthereumAddress() should return error for sr25519 network")
+	}
+	if _, err := sleeve.GetEthereumAddress("Unknown"); err == nil {
+		t.Fatalf("GetEthereumAddress() should return error for unknown network")
+	}
+}
+
+func TestEVMAddressFromPrivateKey(t *testing.T) {
+	key, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000001")
+	addr, err := EVMAddressFromPrivateKey(key)
+	if err != nil {
+		t.Fatalf("EVMAddressFromPrivateKey() returned error: %v", err)
+	}
+	if addr != "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf" {
+		t.Fatalf("EVMAddressFromPrivateKey() returned %s", addr)
+	}
+
+	// Same address as the sleeve gives for its EVM networks
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	ethKey, _ := sleeve.GetPrivateKey("Ethereum")
+	expected, _ := sleeve.GetAddress("Ethereum")
+	if addr, err := EVMAddressFromPrivateKey(ethKey); err != nil || addr != expected {
+		t.Fatalf("EVMAddressFromPrivateKey() returned %s, %v, expected %s", addr, err, expected)
+	}
+
+	// Keys must be 32 byte scalars in [1, N-1]
+	nPlusOne := new(big.Int).Add(N, big.NewInt(1)).Bytes()
+	for _, key := range [][]byte{nil, make([]byte, 32), N.Bytes(), nPlusOne, key[1:], append(key, 0)} {
+		if _, err := EVMAddressFromPrivateKey(key); err == nil {
+			t.Fatalf("EVMAddressFromPrivateKey() should return error for key %x", key)
+		}
+	}
+}
+
+func TestSingleSeedSleeve_GetPublicKeyFormats(t *testing.T) {
+	// Private key 1, i.e., the generator point
+	key, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000001")
+	sleeve := &SingleSeedSleeve{networkKeys: map[string]*NetworkKey{
+		"Bitcoin": {Network: "Bitcoin", CoinType: CoinTypeBitcoin, Curve: CurveSecp256k1, Key: key},
+		"Solana":  {Network: "Solana", CoinType: CoinTypeSolana, Curve: CurveEd25519, Key: key},
+	}}
+
+	compressed, uncompressed, err := sleeve.GetPublicKeyFormats("Bitcoin")
+	if err != nil {
+		t.Fatalf("GetPublicKeyFormats() returned error: %v", err)
+	}
+	expectedCompressed := "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	expectedUncompressed := "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+	if hex.EncodeToString(compressed) != expectedCompressed {
+		t.Fatalf("GetPublicKeyFormats() returned compressed key %x, expected %s", compressed, expectedCompressed)
+	}
+	if hex.EncodeToString(uncompressed) != expectedUncompressed {
+		t.Fatalf("GetPublicKeyFormats() returned uncompressed key %x, expected %s", uncompressed, expectedUncompressed)
+	}
+
+	// The compressed key is the one returned by GetPublicKey
+	if pub, _ := sleeve.GetPublicKey("Bitcoin"); !bytes.Equal(pub, compressed) {
+		t.Fatalf("GetPublicKeyFormats() compressed key should match GetPublicKey()")
+	}
+
+	if _, _, err := sleeve.GetPublicKeyFormats("Solana"); err == nil {
+		t.Fatalf("GetPublicKeyFormats() should return error for ed25519 network")
+	}
+	if _, _, err := sleeve.GetPublicKeyFormats("Unknown"); err == nil {
+		t.Fatalf("GetPublicKeyFormats() should return error for unknown network")
+	}
+}
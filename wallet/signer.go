@@ -0,0 +1,165 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"math/big"
+)
+
+// Size of the signatures of local signers, for both secp256k1 and ed25519
+const localSignatureSize = 64
+
+// Signer signs hashes with a network key
+// Implementations can keep the private key out of the process, e.g., in an HSM or a remote signer
+type Signer interface {
+	// Public key, encoded as returned by GetPublicKey for the key's curve
+	Public() []byte
+	// Sign a hash
+	Sign(hash []byte) ([]byte, error)
+}
+
+// Set an external signer for a network, used by NetworkSigner and SignWithNetwork
+// instead of the local key, which then never needs to be derived
+// Setting a nil signer removes it, falling back to the local key
+func (s *SingleSeedSleeve) SetExternalSigner(network string, signer Signer) {
+	if signer == nil {
+		delete(s.externalSigners, network)
+		return
+	}
+	if s.externalSigners == nil {
+		s.externalSigners = make(map[string]Signer)
+	}
+	s.externalSigners[network] = signer
+}
+
+// Get the signer of a network: its external signer if one is set, or else a signer
+// using the local key. Local keys of standard networks are derived on first use,
+// so sleeves created without deriving networks can sign as well
+func (s *SingleSeedSleeve) NetworkSigner(network string) (Signer, error) {
+	if signer, ok := s.externalSigners[network]; ok {
+		return signer, nil
+	}
+	if s.watchOnly {
+		return nil, ErrWatchOnly
+	}
+	if _, exists := s.networkKeys[network]; !exists {
+		coinType, ok := s.standardCoinType(network)
+		if !ok || s.seed == nil {
+			return nil, fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+		}
+		if err := s.DeriveNetworkKey(network, coinType, s.seed); err != nil {
+			return nil, err
+		}
+	}
+	return &localSigner{sleeve: s, network: network}, nil
+}
+
+// Sign a hash with the signer of a network, see NetworkSigner
+func (s *SingleSeedSleeve) SignWithNetwork(network string, hash []byte) ([]byte, error) {
+	signer, err := s.NetworkSigner(network)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(hash)
+}
+
+// Sign many hashes with the signer of a network, see NetworkSigner
+// This is not allocation-free. With a local key, the private key is parsed once for the whole
+// batch and the signatures share a single buffer, which saves the per-call allocations of
+// SignWithNetwork. Each secp256k1 signature still allocates, since btcec signs with big
+// integers and returns a new signature, so batching saves about a sixth of its allocations.
+// crypto/ed25519 expands the key again for each hash into memory that can't be zeroed; the
+// ed25519 private key itself is zeroed after the batch. Signatures are the same as
+// SignWithNetwork's. Fails without signing anything if any secp256k1 hash doesn't have 32 bytes
+func (s *SingleSeedSleeve) SignBatch(network string, hashes [][]byte) ([][]byte, error) {
+	signer, err := s.NetworkSigner(network)
+	if err != nil {
+		return nil, err
+	}
+	sigs := make([][]byte, len(hashes))
+	if _, ok := signer.(*localSigner); !ok {
+		for i, hash := range hashes {
+			if sigs[i], err = signer.Sign(hash); err != nil {
+				return nil, fmt.Errorf("failed to sign hash %d: %v", i, err)
+			}
+		}
+		return sigs, nil
+	}
+
+	netKey := s.networkKeys[network]
+	buf := make([]byte, len(hashes)*localSignatureSize)
+	for i := range sigs {
+		sigs[i] = buf[i*localSignatureSize : (i+1)*localSignatureSize : (i+1)*localSignatureSize]
+	}
+	switch netKey.Curve {
+	case CurveSecp256k1:
+		for i, hash := range hashes {
+			if len(hash) != 32 {
+				return nil, fmt.Errorf("hash %d has size %d, expected 32", i, len(hash))
+			}
+		}
+		privKey, err := secp256k1PrivateKey(netKey.Key)
+		if err != nil {
+			return nil, err
+		}
+		// btcec allocates the signature anyway, r and s are only copied out of it
+		r, sv := new(big.Int), new(big.Int)
+		for i, hash := range hashes {
+			if err := signLowS(privKey, hash, r, sv); err != nil {
+				return nil, fmt.Errorf("failed to sign hash %d: %v", i, err)
+			}
+			r.FillBytes(sigs[i][:32])
+			sv.FillBytes(sigs[i][32:])
+		}
+	case CurveEd25519:
+		privKey := ed25519.NewKeyFromSeed(netKey.Key)
+		defer zero(privKey)
+		for i, hash := range hashes {
+			copy(sigs[i], ed25519.Sign(privKey, hash))
+		}
+	default:
+		return nil, fmt.Errorf("signing with %s keys isn't supported", netKey.Curve)
+	}
+	return sigs, nil
+}
+
+// Signer using a network key derived by the sleeve
+// secp256k1 signatures are 64 byte compact low s signatures, as returned by SignECDSA,
+// and ed25519 signatures are standard 64 byte signatures of the hash
+type localSigner struct {
+	sleeve  *SingleSeedSleeve
+	network string
+}
+
+// Get the public key of the network, nil if the sleeve was wiped
+func (l *localSigner) Public() []byte {
+	pub, err := l.sleeve.GetPublicKey(l.network)
+	if err != nil {
+		return nil
+	}
+	return pub
+}
+
+// Sign a hash with the network key
+func (l *localSigner) Sign(hash []byte) ([]byte, error) {
+	netKey, exists := l.sleeve.networkKeys[l.network]
+	if !exists {
+		return nil, fmt.Errorf("network %s not found - call DeriveNetworkKey first", l.network)
+	}
+	switch netKey.Curve {
+	case CurveSecp256k1:
+		return l.sleeve.SignECDSA(l.network, hash, SigFormatCompact)
+	case CurveEd25519:
+		privKey := ed25519.NewKeyFromSeed(netKey.Key)
+		defer zero(privKey)
+		return ed25519.Sign(privKey, hash), nil
+	default:
+		return nil, fmt.Errorf("signing with %s keys isn't supported", netKey.Curve)
+	}
+}
@@ -0,0 +1,163 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// Fake HSM holding a secp256k1 key the sleeve never sees
+type fakeHSM struct {
+	key   *btcec.PrivateKey
+	signs int
+}
+
+func (h *fakeHSM) Public() []byte {
+	return h.key.PubKey().SerializeCompressed()
+}
+
+func (h *fakeHSM) Sign(hash []byte) ([]byte, error) {
+	h.signs++
+	sig, err := h.key.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 64)
+	sig.R.FillBytes(out[:32])
+	sig.S.FillBytes(out[32:])
+	return out, nil
+}
+
+func TestSingleSeedSleeve_ExternalSigner(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveWithOptions(rand.Reader, WithLazyDerivation())
+	hsmKey, _ := btcec.NewPrivateKey(btcec.S256())
+	hsm := &fakeHSM{key: hsmKey}
+	sleeve.SetExternalSigner("Ethereum", hsm)
+
+	hash := keccak256([]byte("message"))
+	sig, err := sleeve.SignWithNetwork("Ethereum", hash)
+	if err != nil {
+		t.Fatalf("SignWithNetwork() returned error: %v", err)
+	}
+	if hsm.signs != 1 || !verifySecp256k1(hsm.Public(), hash, sig) {
+		t.Fatalf("SignWithNetwork() should delegate to the external signer")
+	}
+	if _, err := sleeve.GetPrivateKey("Ethereum"); err == nil {
+		t.Fatalf("External signing shouldn't derive the local key")
+	}
+	signer, _ := sleeve.NetworkSigner("Ethereum")
+	if !bytes.Equal(signer.Public(), hsm.Public()) {
+		t.Fatalf("NetworkSigner() should return the external signer")
+	}
+
+	// Removing the signer falls back to the local key, derived lazily
+	sleeve.SetExternalSigner("Ethereum", nil)
+	sig, err = sleeve.SignWithNetwork("Ethereum", hash)
+	if err != nil {
+		t.Fatalf("SignWithNetwork() returned error: %v", err)
+	}
+	localPub, err := sleeve.GetPublicKey("Ethereum")
+	if err != nil {
+		t.Fatalf("Local signing should derive the network key: %v", err)
+	}
+	if hsm.signs != 1 || !verifySecp256k1(localPub, hash, sig) {
+		t.Fatalf("SignWithNetwork() should sign with the local key")
+	}
+	signer, _ = sleeve.NetworkSigner("Ethereum")
+	if !bytes.Equal(signer.Public(), localPub) {
+		t.Fatalf("Local signer returned wrong public key")
+	}
+
+	// ed25519 local keys
+	_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, sleeve.GetSeedUnsafe())
+	sig, err = sleeve.SignWithNetwork("Solana", hash)
+	solPub, _ := sleeve.GetPublicKey("Solana")
+	if err != nil || !ed25519.Verify(solPub, hash, sig) {
+		t.Fatalf("SignWithNetwork() returned invalid ed25519 signature: %v", err)
+	}
+
+	// Unsupported curves and unknown networks
+	if _, err := sleeve.SignWithNetwork("Polkadot", hash); err == nil {
+		t.Fatalf("SignWithNetwork() should return error for sr25519 keys")
+	}
+	if _, err := sleeve.SignWithNetwork("Unknown", hash); err == nil {
+		t.Fatalf("SignWithNetwork() should return error for unknown network")
+	}
+
+	// External signers work after wiping, local keys don't
+	sleeve.SetExternalSigner("Ethereum", hsm)
+	sleeve.Wipe()
+	if _, err := sleeve.SignWithNetwork("Ethereum", hash); err != nil {
+		t.Fatalf("SignWithNetwork() with an external signer returned error after Wipe: %v", err)
+	}
+	if _, err := sleeve.SignWithNetwork("Bitcoin", hash); err == nil {
+		t.Fatalf("SignWithNetwork() should return error for local keys after Wipe")
+	}
+}
+
+func TestSingleSeedSleeve_SignBatch(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+	hashes := make([][]byte, 5)
+	for i := range hashes {
+		hashes[i] = keccak256([]byte{byte(i)})
+	}
+
+	// Same signatures as signing one by one, on both curves
+	for _, network := range []string{"Ethereum", "Solana"} {
+		sigs, err := sleeve.SignBatch(network, hashes)
+		if err != nil {
+			t.Fatalf("SignBatch(%s) returned error: %v", network, err)
+		}
+		if len(sigs) != len(hashes) {
+			t.Fatalf("SignBatch(%s) returned %d signatures, expected %d", network, len(sigs), len(hashes))
+		}
+		for i, hash := range hashes {
+			expected, _ := sleeve.SignWithNetwork(network, hash)
+			if !bytes.Equal(sigs[i], expected) {
+				t.Fatalf("SignBatch(%s) signature %d doesn't match SignWithNetwork()", network, i)
+			}
+		}
+		// Appending to a signature doesn't overwrite the next one in the shared buffer
+		next := append([]byte{}, sigs[1]...)
+		sigs[0] = append(sigs[0], 0xFF)
+		if !bytes.Equal(sigs[1], next) {
+			t.Fatalf("SignBatch(%s) signatures overlap", network)
+		}
+	}
+
+	// External signers sign each hash
+	hsmKey, _ := btcec.NewPrivateKey(btcec.S256())
+	hsm := &fakeHSM{key: hsmKey}
+	sleeve.SetExternalSigner("Ethereum", hsm)
+	sigs, err := sleeve.SignBatch("Ethereum", hashes)
+	if err != nil {
+		t.Fatalf("SignBatch() returned error with an external signer: %v", err)
+	}
+	if hsm.signs != len(hashes) || !verifySecp256k1(hsm.Public(), hashes[2], sigs[2]) {
+		t.Fatalf("SignBatch() should delegate to the external signer")
+	}
+	sleeve.SetExternalSigner("Ethereum", nil)
+
+	// Errors
+	if _, err := sleeve.SignBatch("Ethereum", [][]byte{hashes[0], hashes[1][:31]}); err == nil {
+		t.Fatalf("SignBatch() should return error for a hash that isn't 32 bytes")
+	}
+	if _, err := sleeve.SignBatch("Unknown", hashes); err == nil {
+		t.Fatalf("SignBatch() should return error for unknown network")
+	}
+	_ = sleeve.DeriveNetworkKey("Polkadot2", CoinTypePolkadot, seed)
+	if _, err := sleeve.SignBatch("Polkadot2", hashes); err == nil {
+		t.Fatalf("SignBatch() should return error for sr25519 keys")
+	}
+}
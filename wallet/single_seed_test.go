@@ -2,10 +2,21 @@ package wallet
 
 import (
 	"bytes"
+	"crypto/hmac"
 	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/tyler-smith/go-bip39"
 	"github.com/xx-labs/sleeve/hasher"
 	"github.com/xx-labs/sleeve/wots"
@@ -28,8 +39,11 @@ func TestNewSingleSeedSleeve(t *testing.T) {
 
 	// Should have automatically derived standard networks
 	networks := sleeve.GetAllNetworkKeys()
-	if len(networks) != 3 {
-		t.Fatalf("Expected 3 standard networks, got %d", len(networks))
+	if len(networks) != sleeve.StandardNetworkCount() {
+		t.Fatalf("Expected %d standard networks, got %d", sleeve.StandardNetworkCount(), len(networks))
+	}
+	if len(sleeve.GetUserNetworkKeys()) != 0 {
+		t.Fatalf("Expected no user networks, got %d", len(sleeve.GetUserNetworkKeys()))
 	}
 
 	// Verify Bitcoin, Ethereum, Polkadot are present
@@ -58,9 +72,57 @@ func TestNewSingleSeedSleeveFromMnemonic(t *testing.T) {
 		t.Fatalf("Mnemonic mismatch")
 	}
 
-	// Verify derivation index is deterministic
-	if sleeve.GetDerivationIndex() == 0 {
-		t.Fatalf("Derivation index should not be zero")
+	// Verify derivation index was computed and is deterministic
+	// The index itself can be 0, so it's not compared to 0
+	other, _ := NewSingleSeedSleeveFromMnemonic(mnemonic, "", DefaultGenSpec())
+	if !sleeve.HasDerivationIndex() || sleeve.GetDerivationIndex() != other.GetDerivationIndex() {
+		t.Fatalf("Derivation index should be computed deterministically")
+	}
+}
+
+// Test single-seed sleeve with provided master seed
+func TestNewSingleSeedSleeveFromSeed(t *testing.T) {
+	mnemonic := testVectorMnemonic
+	seed, _ := bip39.NewSeedWithErrorChecking(mnemonic, "")
+
+	fromMnem, _ := NewSingleSeedSleeveFromMnemonic(mnemonic, "", DefaultGenSpec())
+	fromSeed, err := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromSeed() returned error: %v", err)
+	}
+
+	// Mnemonic is unknown when creating from seed
+	if fromSeed.GetMnemonic() != "" {
+		t.Fatalf("GetMnemonic() should be empty for sleeve created from seed")
+	}
+
+	// Seed round trips
+	if !bytes.Equal(fromMnem.GetSeedUnsafe(), seed) {
+		t.Fatalf("GetSeedUnsafe() returned wrong seed")
+	}
+
+	// WOTS and network keys must match the mnemonic-based sleeve
+	if !bytes.Equal(fromSeed.GetWOTSPublicKey(), fromMnem.GetWOTSPublicKey()) {
+		t.Fatalf("WOTS public keys should be identical")
+	}
+	for name, netKey := range fromMnem.GetAllNetworkKeys() {
+		key, err := fromSeed.GetPrivateKey(name)
+		if err != nil {
+			t.Fatalf("Network %s not derived from seed: %v", name, err)
+		}
+		if !bytes.Equal(key, netKey.Key) {
+			t.Fatalf("%s keys should be identical", name)
+		}
+	}
+
+	// Invalid seed sizes
+	_, err = NewSingleSeedSleeveFromSeed(seed[:32], DefaultGenSpec())
+	if err == nil {
+		t.Fatalf("NewSingleSeedSleeveFromSeed() should return error with short seed")
+	}
+	_, err = NewSingleSeedSleeveFromSeed(nil, DefaultGenSpec())
+	if err == nil {
+		t.Fatalf("NewSingleSeedSleeveFromSeed() should return error with nil seed")
 	}
 }
 
@@ -154,6 +216,16 @@ func TestSingleSeedSleeve_WOTSConsistency(t *testing.T) {
 		t.Fatalf("WOTS public key mismatch - generation changed!")
 	}
 
+	// Verify exposed WOTS material is the quantum path node
+	wotsSeed, wotsCode := ssSleeve.GetWOTSMaterial()
+	if !bytes.Equal(wotsSeed, node.Key) || !bytes.Equal(wotsCode, node.Code) {
+		t.Fatalf("GetWOTSMaterial() doesn't match the quantum path node")
+	}
+	auditPK := wots.NewKeyFromSeed(wots.DecodeParams(wots.DefaultParams), wotsSeed, wotsCode).ComputePK()
+	if !bytes.Equal(auditPK, manualWOTSPK) {
+		t.Fatalf("WOTS public key recomputed from GetWOTSMaterial() doesn't match")
+	}
+
 	// Verify against known test vector
 	expectedPk, _ := hex.DecodeString(wotsExpectedPubKeyHex)
 	if !bytes.Equal(ssSleeve.GetWOTSPublicKey(), expectedPk) {
@@ -194,6 +266,77 @@ func TestSingleSeedSleeve_CustomNetwork(t *testing.T) {
 	}
 }
 
+// Test the full path reflects the account and WOTS-derived index
+func TestSingleSeedSleeve_GetFullPath(t *testing.T) {
+	mnemonic := testVectorMnemonic
+
+	// Network keys are under account 0' by default, the account only selects the WOTS+ key
+	for _, account := range []uint32{0, 7} {
+		for _, inPaths := range []bool{false, true} {
+			spec := NewGenSpec(account, wots.DefaultParams)
+			spec.AccountInNetworkPaths = inPaths
+			sleeve, _ := NewSingleSeedSleeveFromMnemonic(mnemonic, "", spec)
+
+			path, err := sleeve.GetFullPath("Ethereum")
+			if err != nil {
+				t.Fatalf("GetFullPath() returned error: %v", err)
+			}
+			expected := fmt.Sprintf("m/44'/60'/%d'/0'/%d", spec.NetworkAccount(), sleeve.GetDerivationIndex())
+			if path != expected || (!inPaths && spec.NetworkAccount() != 0) {
+				t.Fatalf("GetFullPath() returned wrong path. Got: %s, Expected: %s", path, expected)
+			}
+			if sleeve.GetAllNetworkKeys()["Ethereum"].Path != expected {
+				t.Fatalf("NetworkKey.Path doesn't match GetFullPath()")
+			}
+			if preview, _ := PreviewDerivation(CoinTypeEthereum, spec.NetworkAccount(), 0,
+				sleeve.GetDerivationIndex()); preview != expected {
+				t.Fatalf("PreviewDerivation() returned %s, expected %s", preview, expected)
+			}
+		}
+	}
+
+	// Different accounts must produce different keys, through the derivation index
+	sleeve0, _ := NewSingleSeedSleeveFromSeed(mustSeed(mnemonic), NewGenSpec(0, wots.DefaultParams))
+	sleeve1, _ := NewSingleSeedSleeveFromSeed(mustSeed(mnemonic), NewGenSpec(1, wots.DefaultParams))
+	key0, _ := sleeve0.GetPrivateKey("Ethereum")
+	key1, _ := sleeve1.GetPrivateKey("Ethereum")
+	if bytes.Equal(key0, key1) {
+		t.Fatalf("Keys for different accounts should differ")
+	}
+
+	// Regression vector for account 1, with keys derived by the original single-seed code
+	// under account 0', so wallets generated for other accounts keep their addresses
+	const trezorMnemonic = "hamster diagram private dutch cause delay private meat slide toddler razor " +
+		"book happy fancy gospel tennis maple dilemma loan word shrug inflict delay length"
+	seed, _ := bip39.NewSeedWithErrorChecking(trezorMnemonic, "TREZOR")
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, NewGenSpec(1, wots.Level0))
+	vectors := map[string]string{
+		"Bitcoin":  "abc25a934fdfba4f9b7bb1757ed6aaa1aa6a36e5e7e3b87d04e2e9d38b8a4eb5",
+		"Ethereum": "4107533873055b2970594b3cbf65ea56f7324383d94f854850d8e091ca787dce",
+	}
+	for network, expected := range vectors {
+		key, _ := sleeve.GetPrivateKey(network)
+		path, _ := sleeve.GetFullPath(network)
+		if hex.EncodeToString(key) != expected || !strings.Contains(path, "'/0'/0'/373061829") {
+			t.Fatalf("Account 1 %s key at %s doesn't match the original derivation", network, path)
+		}
+	}
+
+	// Unknown network
+	if _, err := sleeve0.GetFullPath("Unknown"); err == nil {
+		t.Fatalf("GetFullPath() should return error for unknown network")
+	}
+}
+
+// Get the BIP39 seed of a valid mnemonic with no passphrase
+func mustSeed(mnemonic string) []byte {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		panic(err)
+	}
+	return seed
+}
+
 // Test error handling for invalid mnemonic
 func TestSingleSeedSleeve_InvalidMnemonic(t *testing.T) {
 	// Too few words
@@ -219,6 +362,40 @@ func TestSingleSeedSleeve_InvalidMnemonic(t *testing.T) {
 	}
 }
 
+// Test recovering from a mnemonic with an invalid checksum
+func TestSingleSeedSleeve_FromMnemonicUnchecked(t *testing.T) {
+	invalidChkMnem := "armed output survey rent myself sentence warm eyebrow scan isolate thunder point" +
+		" bulk skirt sketch bird palm sleep dash jazz list behave spin spin"
+	if _, err := NewSingleSeedSleeveFromMnemonic(invalidChkMnem, "", DefaultGenSpec()); err == nil {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonic() should return error for mnemonic with invalid checksum")
+	}
+	sleeve, err := NewSingleSeedSleeveFromMnemonicUnchecked(invalidChkMnem, "pass", DefaultGenSpec())
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonicUnchecked() returned error: %v", err)
+	}
+	expected, _ := NewSingleSeedSleeveFromSeed(bip39.NewSeed(invalidChkMnem, "pass"), DefaultGenSpec())
+	if sleeve.GetWOTSPublicKeyHex() != expected.GetWOTSPublicKeyHex() {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonicUnchecked() should use the BIP39 seed of the mnemonic")
+	}
+
+	// Valid mnemonics give the same sleeve as the checked constructor
+	unchecked, _ := NewSingleSeedSleeveFromMnemonicUnchecked(" "+strings.ToUpper(testVectorMnemonic), "", DefaultGenSpec())
+	checked, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	if unchecked.GetMnemonic() != checked.GetMnemonic() || unchecked.GetWOTSPublicKeyHex() != checked.GetWOTSPublicKeyHex() {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonicUnchecked() doesn't match NewSingleSeedSleeveFromMnemonic() for a valid mnemonic")
+	}
+
+	// Words are still validated
+	invalidMnem := "armed output survey rent myself sentence warm eyebrow scan isolate thunder point" +
+		" bulk skirt sketch bird palm sleep dash jazz list behave spin xxnetwork"
+	if _, err := NewSingleSeedSleeveFromMnemonicUnchecked(invalidMnem, "", DefaultGenSpec()); err == nil {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonicUnchecked() should return error for mnemonic with invalid word")
+	}
+	if _, err := NewSingleSeedSleeveFromMnemonicUnchecked("one two three", "", DefaultGenSpec()); err == nil {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonicUnchecked() should return error for mnemonic with too few words")
+	}
+}
+
 // Test passphrase support
 func TestSingleSeedSleeve_Passphrase(t *testing.T) {
 	mnemonic := testVectorMnemonic
@@ -245,6 +422,43 @@ func TestSingleSeedSleeve_Passphrase(t *testing.T) {
 	if sleeve1.GetDerivationIndex() == sleeve2.GetDerivationIndex() {
 		t.Fatalf("Derivation indices should differ with different passphrases")
 	}
+
+	// Only the sleeve with a passphrase reports having one
+	if sleeve1.HasPassphrase() {
+		t.Fatalf("HasPassphrase() should be false for empty passphrase")
+	}
+	if !sleeve2.HasPassphrase() {
+		t.Fatalf("HasPassphrase() should be true for non-empty passphrase")
+	}
+}
+
+// Test passphrase check detects a mistyped passphrase
+func TestSingleSeedSleeve_PassphraseCheck(t *testing.T) {
+	mnemonic := testVectorMnemonic
+
+	sleeve1, _ := NewSingleSeedSleeveFromMnemonic(mnemonic, "test_passphrase", DefaultGenSpec())
+	sleeve2, _ := NewSingleSeedSleeveFromMnemonic(mnemonic, "test_passphrase", DefaultGenSpec())
+	sleeve3, _ := NewSingleSeedSleeveFromMnemonic(mnemonic, "test_passphrsae", DefaultGenSpec())
+
+	check := sleeve1.PassphraseCheck()
+	if len(check) != 8 {
+		t.Fatalf("PassphraseCheck() returned check of wrong length: %d", len(check))
+	}
+
+	// Same passphrase should produce the same check
+	if check != sleeve2.PassphraseCheck() {
+		t.Fatalf("PassphraseCheck() should match when passphrase is the same")
+	}
+
+	// Mistyped passphrase should produce a different check
+	if check == sleeve3.PassphraseCheck() {
+		t.Fatalf("PassphraseCheck() should differ when passphrase is mistyped")
+	}
+
+	// Check value is not the same as the fingerprint
+	if check == sleeve1.Fingerprint() {
+		t.Fatalf("PassphraseCheck() should be domain separated from Fingerprint()")
+	}
 }
 
 // Test with different WOTS+ parameters
@@ -265,6 +479,10 @@ func TestSingleSeedSleeve_WOTSParams(t *testing.T) {
 			t.Fatalf("Empty WOTS PK with level %v", level)
 		}
 
+		if sleeve.GetWOTSParams() != level {
+			t.Fatalf("GetWOTSParams() returned wrong level. Got: %v, Expected: %v", sleeve.GetWOTSParams(), level)
+		}
+
 		// Each level should produce different WOTS PK
 		// (because they use different params in the derivation path)
 	}
@@ -356,6 +574,12 @@ func TestSingleSeedSleeve_Recovery(t *testing.T) {
 	if wotsIndexOriginal != wotsIndexRecovered {
 		t.Fatalf("WOTS index not recovered correctly")
 	}
+
+	// The library check agrees
+	expected, _ := originalSleeve.ExportDescriptor()
+	if err := ConfirmRecovery(mnemonic, "", DefaultGenSpec(), expected); err != nil {
+		t.Fatalf("ConfirmRecovery() returned error: %v", err)
+	}
 }
 
 // Test error handling for NewSingleSeedSleeve with bad readers
@@ -373,20 +597,63 @@ func TestSingleSeedSleeve_ErrorReaders(t *testing.T) {
 	}
 }
 
+// Test that low-quality entropy from the reader is rejected by the health check
+func TestSingleSeedSleeve_LowEntropy(t *testing.T) {
+	pattern := make([]byte, EntropySize)
+	for i := range pattern {
+		pattern[i] = byte(i % 4)
+	}
+	good, _ := hex.DecodeString(testVectorEntropy)
+	run := make([]byte, EntropySize)
+	copy(run, good)
+	copy(run[10:], []byte{7, 7, 7, 7, 7, 7})
+
+	for name, ent := range map[string][]byte{
+		"all zero": make([]byte, EntropySize),
+		"pattern":  pattern,
+		"long run": run,
+	} {
+		_, err := NewSingleSeedSleeve(bytes.NewReader(ent), "", DefaultGenSpec())
+		if err == nil {
+			t.Fatalf("NewSingleSeedSleeve() should reject %s entropy", name)
+		}
+
+		// Disabling the check allows it
+		_, err = NewSingleSeedSleeve(bytes.NewReader(ent), "", DefaultGenSpec().WithEntropyCheck(false))
+		if err != nil {
+			t.Fatalf("NewSingleSeedSleeve() with entropy check disabled returned error for %s entropy: %v", name, err)
+		}
+	}
+
+	// Test vector entropy passes
+	if _, err := NewSingleSeedSleeve(bytes.NewReader(good), "", DefaultGenSpec()); err != nil {
+		t.Fatalf("NewSingleSeedSleeve() rejected good entropy: %v", err)
+	}
+
+	// rand.Reader always passes
+	ent := make([]byte, EntropySize)
+	for i := 0; i < 1000; i++ {
+		_, _ = rand.Read(ent)
+		if err := checkEntropyHealth(ent); err != nil {
+			t.Fatalf("checkEntropyHealth() rejected entropy from rand.Reader: %v", err)
+		}
+	}
+}
+
 // Test error handling for NewSingleSeedSleeveFromEntropy
 func TestSingleSeedSleeve_InvalidEntropy(t *testing.T) {
 	// Test wrong entropy size (31 bytes) - invalid for BIP39
 	ent := make([]byte, EntropySize-1)
 	_, err := NewSingleSeedSleeveFromEntropy(ent, "", DefaultGenSpec())
-	if err == nil {
-		t.Fatalf("NewSingleSeedSleeveFromEntropy() should return error when entropy size is invalid")
+	if !errors.Is(err, ErrInvalidBIP39Entropy) {
+		t.Fatalf("NewSingleSeedSleeveFromEntropy() should return ErrInvalidBIP39Entropy when entropy size is invalid, got %v", err)
 	}
 
 	// Test valid BIP39 entropy size (16 bytes), but not enough for Sleeve
 	ent = make([]byte, EntropySize/2)
 	_, err = NewSingleSeedSleeveFromEntropy(ent, "", DefaultGenSpec())
-	if err == nil {
-		t.Fatalf("NewSingleSeedSleeveFromEntropy() should return error when entropy is too small for Sleeve")
+	if !errors.Is(err, ErrEntropyTooSmallForSleeve) {
+		t.Fatalf("NewSingleSeedSleeveFromEntropy() should return ErrEntropyTooSmallForSleeve when entropy is too small for Sleeve, got %v", err)
 	}
 
 	// Test valid entropy size
@@ -400,6 +667,35 @@ func TestSingleSeedSleeve_InvalidEntropy(t *testing.T) {
 	}
 }
 
+// Test entropy based construction is deterministic for all WOTS+ levels
+func TestSingleSeedSleeve_EntropyAllLevels(t *testing.T) {
+	ent, _ := hex.DecodeString(testVectorEntropy)
+	levels := []wots.ParamsEncoding{wots.Level0, wots.Level1, wots.Level2, wots.Level3}
+
+	pks := make(map[string]bool)
+	for _, level := range levels {
+		spec := NewGenSpec(0, level)
+		sleeve1, err := NewSingleSeedSleeveFromEntropy(ent, "", spec)
+		if err != nil {
+			t.Fatalf("NewSingleSeedSleeveFromEntropy() failed with WOTS level %v: %v", level, err)
+		}
+		sleeve2, _ := NewSingleSeedSleeveFromEntropy(ent, "", spec)
+
+		if sleeve1.GetMnemonic() != testVectorMnemonic {
+			t.Fatalf("Wrong mnemonic with WOTS level %v", level)
+		}
+		if !bytes.Equal(sleeve1.GetWOTSPublicKey(), sleeve2.GetWOTSPublicKey()) {
+			t.Fatalf("WOTS PK not deterministic with WOTS level %v", level)
+		}
+		pks[hex.EncodeToString(sleeve1.GetWOTSPublicKey())] = true
+	}
+
+	// Each level should produce a different WOTS PK
+	if len(pks) != len(levels) {
+		t.Fatalf("Expected %d distinct WOTS PKs, got %d", len(levels), len(pks))
+	}
+}
+
 // Test GetWOTSKey function
 func TestSingleSeedSleeve_GetWOTSKey(t *testing.T) {
 	sleeve, _ := NewSingleSeedSleeve(rand.Reader, "", DefaultGenSpec())
@@ -432,11 +728,26 @@ func TestSingleSeedSleeve_DeriveNetworkKey_Errors(t *testing.T) {
 	sleeve, _ := NewSingleSeedSleeveFromMnemonic(mnemonic, "", DefaultGenSpec())
 	seed, _ := bip39.NewSeedWithErrorChecking(mnemonic, "")
 
-	// Test with invalid coin type at boundary
+	// Large coin types below 2^31 are valid
 	err := sleeve.DeriveNetworkKey("TestCoin", 999999, seed)
 	if err != nil {
-		// This should actually succeed - BIP32 supports large coin types
-		t.Logf("Note: Large coin type rejected (expected for some implementations)")
+		t.Fatalf("DeriveNetworkKey() should accept large coin type: %v", err)
+	}
+	err = sleeve.DeriveNetworkKey("MaxCoin", firstHardened-1, seed)
+	if err != nil {
+		t.Fatalf("DeriveNetworkKey() should accept coin type 2^31-1: %v", err)
+	}
+
+	// Coin types >= 2^31 are rejected with a typed error
+	for _, coinType := range []uint32{firstHardened, 0xFFFFFFFF} {
+		err = sleeve.DeriveNetworkKey("OverflowCoin", coinType, seed)
+		var coinErr *InvalidCoinTypeError
+		if !errors.As(err, &coinErr) || coinErr.CoinType != coinType {
+			t.Fatalf("DeriveNetworkKey() should return InvalidCoinTypeError for coin type %d, got: %v", coinType, err)
+		}
+	}
+	if _, err := sleeve.GetPrivateKey("OverflowCoin"); err == nil {
+		t.Fatalf("Rejected coin type should not be stored")
 	}
 
 	// Test that we can successfully derive a custom network
@@ -571,10 +882,21 @@ func TestSingleSeedSleeve_MultipleDerivatonsIndependent(t *testing.T) {
 
 	// Verify total network count
 	allNetworks := sleeve.GetAllNetworkKeys()
-	expectedCount := 3 + len(networks) // 3 standard + custom networks
+	expectedCount := sleeve.StandardNetworkCount() + len(networks) // standard + custom networks
 	if len(allNetworks) != expectedCount {
 		t.Fatalf("Expected %d networks, got %d", expectedCount, len(allNetworks))
 	}
+
+	// Verify only the custom networks are reported as user networks
+	userNetworks := sleeve.GetUserNetworkKeys()
+	if len(userNetworks) != len(networks) {
+		t.Fatalf("Expected %d user networks, got %d", len(networks), len(userNetworks))
+	}
+	for _, net := range networks {
+		if _, exists := userNetworks[net.name]; !exists {
+			t.Fatalf("User network %s not found", net.name)
+		}
+	}
 }
 
 // Test address derivation for known test vector
@@ -623,3 +945,1414 @@ func TestSingleSeedSleeve_KnownAddresses(t *testing.T) {
 		t.Fatalf("Network keys not deterministic")
 	}
 }
+
+// Test the sleeve summary doesn't leak any secrets
+func TestSingleSeedSleeve_String(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	str := sleeve.String()
+
+	// Verify public information is present
+	if !strings.Contains(str, sleeve.Fingerprint()) {
+		t.Fatalf("String() doesn't contain the fingerprint")
+	}
+	if !strings.Contains(str, fmt.Sprintf("%d", sleeve.GetDerivationIndex())) {
+		t.Fatalf("String() doesn't contain the derivation index")
+	}
+	if !strings.Contains(str, wots.DefaultParams.String()) {
+		t.Fatalf("String() doesn't contain the WOTS+ params level")
+	}
+	for name := range sleeve.GetAllNetworkKeys() {
+		if !strings.Contains(str, name) {
+			t.Fatalf("String() doesn't contain network %s", name)
+		}
+	}
+
+	// Verify no secrets are present
+	for _, word := range strings.Fields(testVectorMnemonic) {
+		if strings.Contains(str, word) {
+			t.Fatalf("String() leaked mnemonic word %s", word)
+		}
+	}
+	for name, netKey := range sleeve.GetAllNetworkKeys() {
+		keyHex := hex.EncodeToString(netKey.Key)
+		if strings.Contains(str, keyHex) || strings.Contains(str, keyHex[:16]) {
+			t.Fatalf("String() leaked private key of network %s", name)
+		}
+	}
+}
+
+// Test that derived network keys carry the curve of their network
+func TestSingleSeedSleeve_NetworkKeyCurve(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	seed := mustSeed(testVectorMnemonic)
+	if err := sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed); err != nil {
+		t.Fatalf("DeriveNetworkKey() returned error: %v", err)
+	}
+
+	expected := map[string]Curve{
+		"Bitcoin":  CurveSecp256k1,
+		"Ethereum": CurveSecp256k1,
+		"Polkadot": CurveSr25519,
+		"Solana":   CurveEd25519,
+	}
+	keys := sleeve.GetAllNetworkKeys()
+	for name, curve := range expected {
+		if keys[name].Curve != curve {
+			t.Fatalf("%s key has wrong curve. Got: %s, Expected: %s", name, keys[name].Curve, curve)
+		}
+	}
+
+	if CurveForCoinType(2).String() != "secp256k1" {
+		t.Fatalf("Unlisted coin types should default to secp256k1")
+	}
+	if Curve(255).String() != "UNKNOWN CURVE" {
+		t.Fatalf("Curve.String() should return unknown for invalid curve")
+	}
+}
+
+// Test that DeriveAndGetKey returns the same key as DeriveNetworkKey + GetPrivateKey
+func TestSingleSeedSleeve_DeriveAndGetKey(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	seed := mustSeed(testVectorMnemonic)
+
+	key, err := sleeve.DeriveAndGetKey("Solana", CoinTypeSolana, seed)
+	if err != nil {
+		t.Fatalf("DeriveAndGetKey() returned error: %v", err)
+	}
+	stored, err := sleeve.GetPrivateKey("Solana")
+	if err != nil {
+		t.Fatalf("DeriveAndGetKey() should store the key: %v", err)
+	}
+	if !bytes.Equal(key, stored) {
+		t.Fatalf("DeriveAndGetKey() returned a different key than GetPrivateKey()")
+	}
+
+	// Must match the auto-derived standard network key
+	key, _ = sleeve.DeriveAndGetKey("Ethereum", CoinTypeEthereum, seed)
+	other, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	expected, _ := other.GetPrivateKey("Ethereum")
+	if !bytes.Equal(key, expected) {
+		t.Fatalf("DeriveAndGetKey() returned wrong key for Ethereum")
+	}
+
+	// Invalid seed
+	if _, err := sleeve.DeriveAndGetKey("Bad", 1, []byte{1}); err == nil {
+		t.Fatalf("DeriveAndGetKey() should return error for invalid seed")
+	}
+}
+
+// Test that standard networks can't be shadowed with a different coin type
+func TestSingleSeedSleeve_StandardNetworkShadowing(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	seed := mustSeed(testVectorMnemonic)
+	btcKey, _ := sleeve.GetPrivateKey("Bitcoin")
+	btcKey = append([]byte{}, btcKey...)
+
+	// Mismatched coin type is rejected and the existing key is kept
+	err := sleeve.DeriveNetworkKey("Bitcoin", 999, seed)
+	if err == nil || !strings.Contains(err.Error(), "ReplaceNetworkKey") {
+		t.Fatalf("DeriveNetworkKey() should reject shadowing a standard network, got: %v", err)
+	}
+	key, _ := sleeve.GetPrivateKey("Bitcoin")
+	if !bytes.Equal(key, btcKey) {
+		t.Fatalf("Rejected DeriveNetworkKey() should not modify the Bitcoin key")
+	}
+
+	// Matching coin type is allowed
+	if err := sleeve.DeriveNetworkKey("Bitcoin", CoinTypeBitcoin, seed); err != nil {
+		t.Fatalf("DeriveNetworkKey() should allow re-deriving with the standard coin type: %v", err)
+	}
+
+	// Explicit replacement is allowed and no longer counts as standard
+	if err := sleeve.ReplaceNetworkKey("Bitcoin", 999, seed); err != nil {
+		t.Fatalf("ReplaceNetworkKey() returned error: %v", err)
+	}
+	if sleeve.GetAllNetworkKeys()["Bitcoin"].CoinType != 999 {
+		t.Fatalf("ReplaceNetworkKey() should override the Bitcoin entry")
+	}
+	if sleeve.StandardNetworkCount() != len(standardNetworks)-1 {
+		t.Fatalf("Replaced network should not count as standard")
+	}
+	if _, ok := sleeve.GetUserNetworkKeys()["Bitcoin"]; !ok {
+		t.Fatalf("Replaced network should be returned as a user network")
+	}
+}
+
+// Test that the descriptor checksum is deterministic and tracks the descriptor contents
+func TestSingleSeedSleeve_DescriptorChecksum(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	checksum := sleeve.DescriptorChecksum()
+	if len(checksum) != 8 {
+		t.Fatalf("DescriptorChecksum() should be 8 hex characters, got %q", checksum)
+	}
+
+	// Same inputs give the same checksum
+	same, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	if same.DescriptorChecksum() != checksum {
+		t.Fatalf("DescriptorChecksum() should be deterministic")
+	}
+
+	// Different account changes the checksum
+	other, _ := NewSingleSeedSleeveFromSeed(seed, NewGenSpec(1, wots.DefaultParams))
+	if other.DescriptorChecksum() == checksum {
+		t.Fatalf("DescriptorChecksum() should change with the account")
+	}
+
+	// Deriving another network changes the checksum
+	_ = same.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+	withSolana := same.DescriptorChecksum()
+	if withSolana == checksum {
+		t.Fatalf("DescriptorChecksum() should change when a network is derived")
+	}
+
+	// Changing a network's coin type changes the checksum
+	_ = same.DeriveNetworkKey("Solana", 502, seed)
+	if same.DescriptorChecksum() == withSolana {
+		t.Fatalf("DescriptorChecksum() should change when a network's coin type changes")
+	}
+}
+
+// Test deriving the same network under multiple accounts
+func TestSingleSeedSleeve_DeriveNetworkKeyAt(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	account0, _ := sleeve.GetPrivateKey("Ethereum")
+
+	if err := sleeve.DeriveNetworkKeyAt("Ethereum", CoinTypeEthereum, 1, seed); err != nil {
+		t.Fatalf("DeriveNetworkKeyAt() returned error: %v", err)
+	}
+
+	// Both accounts coexist
+	keys := sleeve.GetAllNetworkKeys()
+	if _, ok := keys["Ethereum/1"]; !ok {
+		t.Fatalf("DeriveNetworkKeyAt() should store account 1 under Ethereum/1")
+	}
+	if len(keys) != len(standardNetworks)+1 {
+		t.Fatalf("GetAllNetworkKeys() returned wrong number of keys: %d", len(keys))
+	}
+	key0, _ := sleeve.GetPrivateKeyAt("Ethereum", 0)
+	key1, err := sleeve.GetPrivateKeyAt("Ethereum", 1)
+	if err != nil {
+		t.Fatalf("GetPrivateKeyAt() returned error: %v", err)
+	}
+	if !bytes.Equal(key0, account0) {
+		t.Fatalf("GetPrivateKeyAt() for the sleeve's account should return the DeriveNetworkKey key")
+	}
+	if bytes.Equal(key0, key1) {
+		t.Fatalf("Keys for different accounts should differ")
+	}
+
+	// Entry records the account and path
+	netKey := keys["Ethereum/1"]
+	expectedPath := fmt.Sprintf("m/44'/60'/1'/0'/%d", sleeve.GetDerivationIndex())
+	if netKey.Network != "Ethereum" || netKey.Account != 1 || netKey.Path != expectedPath {
+		t.Fatalf("DeriveNetworkKeyAt() stored wrong entry: %s account %d path %s", netKey.Network, netKey.Account, netKey.Path)
+	}
+
+	// Deriving at the sleeve's own account matches DeriveNetworkKey
+	if err := sleeve.DeriveNetworkKeyAt("Ethereum", CoinTypeEthereum, 0, seed); err != nil {
+		t.Fatalf("DeriveNetworkKeyAt() returned error: %v", err)
+	}
+	if key, _ := sleeve.GetPrivateKey("Ethereum"); !bytes.Equal(key, account0) {
+		t.Fatalf("DeriveNetworkKeyAt() for the sleeve's account should match DeriveNetworkKey")
+	}
+
+	// Errors
+	if err := sleeve.DeriveNetworkKeyAt("Ethereum", CoinTypeEthereum, firstHardened, seed); err == nil {
+		t.Fatalf("DeriveNetworkKeyAt() should return error for account >= 2^31")
+	}
+	if err := sleeve.DeriveNetworkKeyAt("Bitcoin", 999, 1, seed); err == nil {
+		t.Fatalf("DeriveNetworkKeyAt() should reject shadowing a standard network")
+	}
+	if _, err := sleeve.GetPrivateKeyAt("Ethereum", 2); err == nil {
+		t.Fatalf("GetPrivateKeyAt() should return error for an account that wasn't derived")
+	}
+}
+
+func TestSingleSeedSleeve_DeriveNetworkKeyAtWithOptions_FullyHardened(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	standard, _ := sleeve.GetPrivateKey("Ethereum")
+
+	opts := NetworkKeyOptions{FullyHardened: true}
+	if err := sleeve.DeriveNetworkKeyAtWithOptions("Ethereum", CoinTypeEthereum, 0, opts, seed); err != nil {
+		t.Fatalf("DeriveNetworkKeyAtWithOptions() returned error: %v", err)
+	}
+	if err := sleeve.DeriveNetworkKeyAtWithOptions("Ethereum", CoinTypeEthereum, 1, opts, seed); err != nil {
+		t.Fatalf("DeriveNetworkKeyAtWithOptions() returned error: %v", err)
+	}
+
+	// Every level of the path is hardened
+	keys := sleeve.GetAllNetworkKeys()
+	netKey, ok := keys["Ethereum#0'"]
+	if !ok {
+		t.Fatalf("DeriveNetworkKeyAtWithOptions() should store a fully hardened key under Ethereum#0'")
+	}
+	expectedPath := fmt.Sprintf("m/44'/60'/0'/0'/%d'", sleeve.GetDerivationIndex())
+	if netKey.Path != expectedPath || !netKey.HardenedAddressIndex {
+		t.Fatalf("DeriveNetworkKeyAtWithOptions() stored path %s, expected %s", netKey.Path, expectedPath)
+	}
+	if _, ok := keys["Ethereum#0'/1"]; !ok {
+		t.Fatalf("DeriveNetworkKeyAtWithOptions() should store account 1 under Ethereum#0'/1")
+	}
+
+	// Fully hardened keys differ from the standard path keys, which are kept
+	if bytes.Equal(netKey.Key, standard) {
+		t.Fatalf("Fully hardened key should differ from the standard path key")
+	}
+	if key, _ := sleeve.GetPrivateKey("Ethereum"); !bytes.Equal(key, standard) {
+		t.Fatalf("DeriveNetworkKeyAtWithOptions() shouldn't replace the standard key")
+	}
+	// It's the hardened address 0 key of DeriveAddressKey
+	addrKey, _ := sleeve.DeriveAddressKey("Ethereum", CoinTypeEthereum, 0, true, seed)
+	if !bytes.Equal(netKey.Key, addrKey.Key) {
+		t.Fatalf("Fully hardened key should match the hardened address 0 key")
+	}
+
+	// No xpub can be exported for fully hardened networks
+	if _, err := sleeve.GetExtendedPublicKey("Ethereum#0'"); err == nil {
+		t.Fatalf("GetExtendedPublicKey() should return error for a fully hardened network")
+	}
+	if _, err := sleeve.GetExtendedPublicKey("Ethereum"); err != nil {
+		t.Fatalf("GetExtendedPublicKey() returned error for the standard network: %v", err)
+	}
+}
+
+func TestSingleSeedSleeve_DerivationSchemes(t *testing.T) {
+	// Addresses of the BIP39 test mnemonic "abandon ... about" documented by MetaMask and Ledger Live
+	seed := bip39.NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	sleeve, err := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromSeed() returned error: %v", err)
+	}
+
+	tests := []struct {
+		scheme   DerivationScheme
+		account  uint32
+		name     string
+		path     string
+		expected string
+	}{
+		{SchemeLedgerLive, 0, "Ethereum@LedgerLive", "m/44'/60'/0'/0/0", "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+		{SchemeLedgerLive, 1, "Ethereum@LedgerLive/1", "m/44'/60'/1'/0/0", "0x78839F6054d7ed13918bAe0473BA31b1Ca9D7265"},
+		{SchemeMetaMask, 1, "Ethereum@MetaMask/1", "m/44'/60'/0'/0/1", "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0"},
+	}
+	for _, tt := range tests {
+		opts := NetworkKeyOptions{Scheme: tt.scheme}
+		if err := sleeve.DeriveNetworkKeyAtWithOptions("Ethereum", CoinTypeEthereum, tt.account, opts, seed); err != nil {
+			t.Fatalf("DeriveNetworkKeyAtWithOptions(%s) returned error: %v", tt.scheme, err)
+		}
+		netKey, ok := sleeve.GetAllNetworkKeys()[tt.name]
+		if !ok {
+			t.Fatalf("DeriveNetworkKeyAtWithOptions(%s) should store the key under %s", tt.scheme, tt.name)
+		}
+		if netKey.Scheme != tt.scheme || netKey.Path != tt.path || netKey.Account != tt.account {
+			t.Fatalf("DeriveNetworkKeyAtWithOptions(%s) stored scheme %s, path %s and account %d",
+				tt.scheme, netKey.Scheme, netKey.Path, netKey.Account)
+		}
+		if addr, _ := sleeve.GetAddress(tt.name); addr != tt.expected {
+			t.Fatalf("GetAddress(%s) returned %s, expected %s", tt.name, addr, tt.expected)
+		}
+	}
+
+	// The sleeve's own key is kept, and differs from every scheme's key
+	standard := sleeve.GetAllNetworkKeys()["Ethereum"]
+	if standard.Scheme != SchemeBIP44 {
+		t.Fatalf("DeriveNetworkKey() should use SchemeBIP44, got %s", standard.Scheme)
+	}
+	for _, tt := range tests {
+		if addr, _ := sleeve.GetAddress("Ethereum"); addr == tt.expected {
+			t.Fatalf("SchemeBIP44 key should differ from the %s key", tt.scheme)
+		}
+	}
+
+	// Schemes are recorded in the descriptor and restored
+	desc, _ := sleeve.ExportDescriptor()
+	restored, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	if err := restored.ImportDescriptor(desc); err != nil {
+		t.Fatalf("ImportDescriptor() returned error: %v", err)
+	}
+	if addr, _ := restored.GetAddress("Ethereum@MetaMask/1"); addr != tests[2].expected {
+		t.Fatalf("ImportDescriptor() restored %s for the MetaMask key, expected %s", addr, tests[2].expected)
+	}
+
+	// Errors
+	opts := NetworkKeyOptions{Scheme: SchemeLedgerLive, FullyHardened: true}
+	if err := sleeve.DeriveNetworkKeyAtWithOptions("Ethereum", CoinTypeEthereum, 0, opts, seed); err == nil {
+		t.Fatalf("DeriveNetworkKeyAtWithOptions() should return error for fully hardened Ledger Live keys")
+	}
+	opts = NetworkKeyOptions{Scheme: SchemeMetaMask + 1}
+	if err := sleeve.DeriveNetworkKeyAtWithOptions("Ethereum", CoinTypeEthereum, 0, opts, seed); err == nil {
+		t.Fatalf("DeriveNetworkKeyAtWithOptions() should return error for an unknown scheme")
+	}
+	for _, name := range []string{"BIP44", "ledgerlive", "MetaMask"} {
+		if _, err := ParseDerivationScheme(name); err != nil {
+			t.Fatalf("ParseDerivationScheme(%q) returned error: %v", name, err)
+		}
+	}
+	if _, err := ParseDerivationScheme("Trezor"); err == nil {
+		t.Fatalf("ParseDerivationScheme() should return error for an unknown scheme")
+	}
+}
+
+// Test that PreviewDerivation mirrors the path and curve used by DeriveNetworkKey
+func TestPreviewDerivation(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	for _, account := range []uint32{0, 3} {
+		for _, inPaths := range []bool{false, true} {
+			spec := NewGenSpec(account, wots.DefaultParams)
+			spec.AccountInNetworkPaths = inPaths
+			sleeve, _ := NewSingleSeedSleeveFromSeed(seed, spec)
+			_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+			for _, netKey := range sleeve.GetAllNetworkKeys() {
+				path, curve := PreviewDerivation(netKey.CoinType, spec.NetworkAccount(), 0, sleeve.GetDerivationIndex())
+				if path != netKey.Path {
+					t.Fatalf("PreviewDerivation() returned wrong path for %s. Got: %s, Expected: %s", netKey.Network, path, netKey.Path)
+				}
+				if curve != netKey.Curve.String() {
+					t.Fatalf("PreviewDerivation() returned wrong curve for %s. Got: %s, Expected: %s", netKey.Network, curve, netKey.Curve)
+				}
+			}
+		}
+	}
+
+	path, curve := PreviewDerivation(CoinTypePolkadot, 1, 2, 42)
+	if path != "m/44'/354'/1'/2'/42" || curve != "sr25519" {
+		t.Fatalf("PreviewDerivation() returned wrong result: %s, %s", path, curve)
+	}
+}
+
+// Test symmetric key derivation with HKDF-SHA256
+func TestSingleSeedSleeve_DeriveSymmetricKey(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+
+	key, err := sleeve.DeriveSymmetricKey("app/notes", 32, seed)
+	if err != nil {
+		t.Fatalf("DeriveSymmetricKey() returned error: %v", err)
+	}
+	if len(key) != 32 {
+		t.Fatalf("DeriveSymmetricKey() returned wrong length: %d", len(key))
+	}
+
+	// Same context reproduces
+	again, _ := sleeve.DeriveSymmetricKey("app/notes", 32, seed)
+	if !bytes.Equal(key, again) {
+		t.Fatalf("DeriveSymmetricKey() should be deterministic")
+	}
+
+	// Different context gives a different key
+	other, _ := sleeve.DeriveSymmetricKey("app/photos", 32, seed)
+	if bytes.Equal(key, other) {
+		t.Fatalf("DeriveSymmetricKey() should give different keys for different contexts")
+	}
+
+	// Shorter key is a prefix of the longer output
+	short, _ := sleeve.DeriveSymmetricKey("app/notes", 16, seed)
+	if !bytes.Equal(short, key[:16]) {
+		t.Fatalf("DeriveSymmetricKey() shorter key should be a prefix of the longer one")
+	}
+
+	// Not the same as any network key
+	for name, netKey := range sleeve.GetAllNetworkKeys() {
+		if bytes.Equal(key, netKey.Key) {
+			t.Fatalf("DeriveSymmetricKey() returned the %s private key", name)
+		}
+	}
+
+	// Errors
+	if _, err := sleeve.DeriveSymmetricKey("", 32, seed); err == nil {
+		t.Fatalf("DeriveSymmetricKey() should return error for empty context")
+	}
+	for _, length := range []int{0, -1, 255*32 + 1} {
+		if _, err := sleeve.DeriveSymmetricKey("app", length, seed); err == nil {
+			t.Fatalf("DeriveSymmetricKey() should return error for length %d", length)
+		}
+	}
+	if _, err := sleeve.DeriveSymmetricKey("app", 32, nil); err == nil {
+		t.Fatalf("DeriveSymmetricKey() should return error for empty seed")
+	}
+}
+
+// Test address index derivation, with and without hardening
+func TestSingleSeedSleeve_DeriveAddressKey(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	index := sleeve.GetDerivationIndex()
+
+	// Address 0 non-hardened is the standard network key
+	netKey, err := sleeve.DeriveAddressKey("Ethereum", CoinTypeEthereum, 0, false, seed)
+	if err != nil {
+		t.Fatalf("DeriveAddressKey() returned error: %v", err)
+	}
+	standard, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	expected, _ := standard.GetPrivateKey("Ethereum")
+	if !bytes.Equal(netKey.Key, expected) {
+		t.Fatalf("DeriveAddressKey() for address 0 should match DeriveNetworkKey")
+	}
+
+	// Address 2, non-hardened and hardened
+	soft, _ := sleeve.DeriveAddressKey("Ethereum", CoinTypeEthereum, 2, false, seed)
+	hard, _ := sleeve.DeriveAddressKey("Ethereum", CoinTypeEthereum, 2, true, seed)
+	if soft.Path != fmt.Sprintf("m/44'/60'/0'/0'/%d", index+2) || soft.HardenedAddressIndex {
+		t.Fatalf("DeriveAddressKey() returned wrong non-hardened entry: %s", soft.Path)
+	}
+	if hard.Path != fmt.Sprintf("m/44'/60'/0'/0'/%d'", index+2) || !hard.HardenedAddressIndex {
+		t.Fatalf("DeriveAddressKey() returned wrong hardened entry: %s", hard.Path)
+	}
+	if bytes.Equal(soft.Key, hard.Key) || bytes.Equal(soft.Key, expected) {
+		t.Fatalf("DeriveAddressKey() keys for different addresses should differ")
+	}
+	keys := sleeve.GetAllNetworkKeys()
+	if keys["Ethereum#2"] != soft || keys["Ethereum#2'"] != hard {
+		t.Fatalf("DeriveAddressKey() stored keys under wrong names")
+	}
+
+	// Standard network names can't be shadowed
+	if _, err := sleeve.DeriveAddressKey("Bitcoin", 999, 1, false, seed); err == nil {
+		t.Fatalf("DeriveAddressKey() should reject shadowing a standard network")
+	}
+}
+
+// Test that address keys can be derived from the extended public key
+func TestSingleSeedSleeve_GetExtendedPublicKey(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+
+	xpub, err := sleeve.GetExtendedPublicKey("Ethereum")
+	if err != nil {
+		t.Fatalf("GetExtendedPublicKey() returned error: %v", err)
+	}
+	if !strings.HasPrefix(xpub, "xpub") {
+		t.Fatalf("GetExtendedPublicKey() returned invalid xpub: %s", xpub)
+	}
+	data := base58.Decode(xpub)
+	if len(data) != 82 || data[4] != 4 {
+		t.Fatalf("GetExtendedPublicKey() returned wrong depth or length")
+	}
+	code, parentPub := data[13:45], data[45:78]
+
+	// Public child derivation from the xpub matches each address key
+	for _, address := range []uint32{0, 1, 5} {
+		netKey, _ := sleeve.DeriveAddressKey("Ethereum", CoinTypeEthereum, address, false, seed)
+		idx := make([]byte, 4)
+		binary.BigEndian.PutUint32(idx, netKey.path[4])
+		h := hmac.New(hasher.SHA2_512.New, code)
+		h.Write(parentPub)
+		h.Write(idx)
+		il := h.Sum(nil)[:32]
+
+		curve := btcec.S256()
+		parent, _ := btcec.ParsePubKey(parentPub, curve)
+		x, y := curve.ScalarBaseMult(il)
+		x, y = curve.Add(x, y, parent.X, parent.Y)
+		childPub := (&btcec.PublicKey{Curve: curve, X: x, Y: y}).SerializeCompressed()
+
+		expected, _ := sleeve.GetPublicKey(addressKeyName("Ethereum", address, false))
+		if !bytes.Equal(childPub, expected) {
+			t.Fatalf("Public key derived from xpub doesn't match address %d key", address)
+		}
+	}
+
+	// Hardened address index and non-secp256k1 networks have no xpub
+	_, _ = sleeve.DeriveAddressKey("Ethereum", CoinTypeEthereum, 1, true, seed)
+	if _, err := sleeve.GetExtendedPublicKey("Ethereum#1'"); err == nil || !strings.Contains(err.Error(), "hardened") {
+		t.Fatalf("GetExtendedPublicKey() should return error for hardened address index, got: %v", err)
+	}
+	if _, err := sleeve.GetExtendedPublicKey("Polkadot"); err == nil {
+		t.Fatalf("GetExtendedPublicKey() should return error for sr25519 networks")
+	}
+	if _, err := sleeve.GetExtendedPublicKey("Unknown"); err == nil {
+		t.Fatalf("GetExtendedPublicKey() should return error for unknown network")
+	}
+}
+
+// Test parallel generation of single-seed sleeves
+func TestGenerateWalletsParallel(t *testing.T) {
+	sleeves, err := GenerateWalletsParallel(6, NewGenSpec(1, wots.Level1), 4)
+	if err != nil {
+		t.Fatalf("GenerateWalletsParallel() returned error: %v", err)
+	}
+	if len(sleeves) != 6 {
+		t.Fatalf("GenerateWalletsParallel() returned %d sleeves, expected 6", len(sleeves))
+	}
+	seen := make(map[string]bool)
+	for i, sleeve := range sleeves {
+		if sleeve == nil {
+			t.Fatalf("GenerateWalletsParallel() returned nil sleeve at %d", i)
+		}
+		if sleeve.GetWOTSParams() != wots.Level1 {
+			t.Fatalf("GenerateWalletsParallel() didn't use the provided spec")
+		}
+		if seen[sleeve.GetMnemonic()] {
+			t.Fatalf("GenerateWalletsParallel() returned duplicate wallets")
+		}
+		seen[sleeve.GetMnemonic()] = true
+	}
+
+	if sleeves, err := GenerateWalletsParallel(0, DefaultGenSpec(), 2); err != nil || len(sleeves) != 0 {
+		t.Fatalf("GenerateWalletsParallel() should return no sleeves for count 0")
+	}
+	if _, err := GenerateWalletsParallel(2, DefaultGenSpec(), 0); err == nil {
+		t.Fatalf("GenerateWalletsParallel() should return error for 0 workers")
+	}
+	if _, err := GenerateWalletsParallel(3, NewGenSpec(0, wots.ParamsEncodingLen), 2); err == nil {
+		t.Fatalf("GenerateWalletsParallel() should return error for invalid spec")
+	}
+}
+
+// Test deriving a network key from a textual path
+func TestSingleSeedSleeve_DeriveFromPathString(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	index := sleeve.GetDerivationIndex()
+
+	// Same path as the standard Ethereum key
+	err := sleeve.DeriveFromPathString("EthereumPath", fmt.Sprintf("m/44h/60h/0h/0h/%d", index), seed)
+	if err != nil {
+		t.Fatalf("DeriveFromPathString() returned error: %v", err)
+	}
+	keys := sleeve.GetAllNetworkKeys()
+	netKey := keys["EthereumPath"]
+	if !bytes.Equal(netKey.Key, keys["Ethereum"].Key) || netKey.Path != keys["Ethereum"].Path {
+		t.Fatalf("DeriveFromPathString() should match the standard Ethereum key")
+	}
+	if netKey.CoinType != CoinTypeEthereum || netKey.Account != 0 || netKey.Curve != CurveSecp256k1 {
+		t.Fatalf("DeriveFromPathString() didn't read the coin type and account from the path")
+	}
+	xpub, _ := sleeve.GetExtendedPublicKey("Ethereum")
+	if pathXpub, err := sleeve.GetExtendedPublicKey("EthereumPath"); err != nil || pathXpub != xpub {
+		t.Fatalf("DeriveFromPathString() key should have the same xpub as the standard key: %v", err)
+	}
+
+	// Standard BIP44 path with non-hardened change
+	if err := sleeve.DeriveFromPathString("Ledger", "m/44'/60'/0'/0/5", seed); err != nil {
+		t.Fatalf("DeriveFromPathString() returned error: %v", err)
+	}
+	if keys["Ledger"].Path != "m/44'/60'/0'/0/5" || bytes.Equal(keys["Ledger"].Key, netKey.Key) {
+		t.Fatalf("DeriveFromPathString() returned wrong key for m/44'/60'/0'/0/5")
+	}
+
+	// Errors
+	if err := sleeve.DeriveFromPathString("Bad", "44'/60'", seed); err == nil {
+		t.Fatalf("DeriveFromPathString() should return error for malformed path")
+	}
+	if err := sleeve.DeriveFromPathString("Bitcoin", "m/44'/60'/0'/0/0", seed); err == nil {
+		t.Fatalf("DeriveFromPathString() should reject shadowing a standard network")
+	}
+	if err := sleeve.DeriveFromPathString("Bad", "m/0", []byte{1}); err == nil {
+		t.Fatalf("DeriveFromPathString() should return error for invalid seed")
+	}
+}
+
+// Test that GetExtendedKey returns the key and chain code of the derived node
+func TestSingleSeedSleeve_GetExtendedKey(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+
+	privKey, chainCode, err := sleeve.GetExtendedKey("Ethereum")
+	if err != nil {
+		t.Fatalf("GetExtendedKey() returned error: %v", err)
+	}
+	expected, _ := sleeve.GetPrivateKey("Ethereum")
+	if !bytes.Equal(privKey, expected) {
+		t.Fatalf("GetExtendedKey() returned wrong private key")
+	}
+	if len(chainCode) != keySize {
+		t.Fatalf("GetExtendedKey() returned chain code of wrong size: %d", len(chainCode))
+	}
+
+	// Non-hardened child derived externally matches the textual path derivation
+	node := &Node{Key: privKey, Code: chainCode}
+	child, _ := node.Child(7)
+	path := fmt.Sprintf("%s/7", sleeve.GetAllNetworkKeys()["Ethereum"].Path)
+	if err := sleeve.DeriveFromPathString("EthereumChild", path, seed); err != nil {
+		t.Fatalf("DeriveFromPathString() returned error: %v", err)
+	}
+	expected, _ = sleeve.GetPrivateKey("EthereumChild")
+	if !bytes.Equal(child.Key, expected) {
+		t.Fatalf("Child derived from GetExtendedKey() doesn't match %s", path)
+	}
+
+	if _, _, err := sleeve.GetExtendedKey("Unknown"); err == nil {
+		t.Fatalf("GetExtendedKey() should return error for unknown network")
+	}
+}
+
+// Test that Wipe zeroizes all secret material
+func TestSingleSeedSleeve_Wipe(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	pk := append([]byte{}, sleeve.GetWOTSPublicKey()...)
+	seed := sleeve.seed
+	netKey := sleeve.GetAllNetworkKeys()["Ethereum"]
+	key, code, parent := netKey.Key, netKey.code, netKey.parentNode
+	wotsSeed, wotsCode := sleeve.wotsSeed, sleeve.wotsCode
+
+	sleeve.Wipe()
+
+	for name, b := range map[string][]byte{
+		"seed": seed, "key": key, "chain code": code, "parent key": parent.Key, "parent chain code": parent.Code,
+		"WOTS+ seed": wotsSeed, "WOTS+ chain code": wotsCode,
+	} {
+		if !bytes.Equal(b, make([]byte, len(b))) {
+			t.Fatalf("Wipe() didn't zeroize the %s", name)
+		}
+	}
+	if sleeve.GetMnemonic() != "" || len(sleeve.GetAllNetworkKeys()) != 0 || sleeve.StandardNetworkCount() != 0 {
+		t.Fatalf("Wipe() should clear the mnemonic and network keys")
+	}
+	if _, err := sleeve.GetPrivateKey("Ethereum"); err == nil {
+		t.Fatalf("GetPrivateKey() should return error after Wipe()")
+	}
+	if !bytes.Equal(sleeve.GetWOTSPublicKey(), pk) {
+		t.Fatalf("Wipe() should keep the WOTS+ public key")
+	}
+	if wotsSeed, wotsCode := sleeve.GetWOTSMaterial(); wotsSeed != nil || wotsCode != nil {
+		t.Fatalf("GetWOTSMaterial() should return nil after Wipe()")
+	}
+}
+
+func TestSingleSeedSleeve_Verify(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+
+	if err := sleeve.Verify(seed); err != nil {
+		t.Fatalf("Verify() returned error for the sleeve's own seed: %v", err)
+	}
+	otherSeed := mustSeed("hole define scout taxi help project army vocal sudden wealth volume fan " +
+		"pigeon raven hen spoil cup because crowd wage awkward public reform pluck")
+	if err := sleeve.Verify(otherSeed); err == nil {
+		t.Fatalf("Verify() should return error for a different seed")
+	}
+}
+
+func TestSingleSeedSleeve_RederiveAll(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+
+	// Keys from every derivation method are re-derived along their stored paths
+	_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+	_ = sleeve.DeriveNetworkKeyAt("Ethereum", CoinTypeEthereum, 3, seed)
+	_, _ = sleeve.DeriveAddressKey("Bitcoin", CoinTypeBitcoin, 2, true, seed)
+	_ = sleeve.DeriveFromPathString("Custom", "m/0'/1/2", seed)
+	if err := sleeve.RederiveAll(seed); err != nil {
+		t.Fatalf("RederiveAll() returned error: %v", err)
+	}
+
+	// Wrong seed
+	otherSeed := mustSeed("hole define scout taxi help project army vocal sudden wealth volume fan " +
+		"pigeon raven hen spoil cup because crowd wage awkward public reform pluck")
+	if err := sleeve.RederiveAll(otherSeed); err == nil {
+		t.Fatalf("RederiveAll() should return error for a different seed")
+	}
+
+	// Mutated key
+	sleeve.GetAllNetworkKeys()["Ethereum/3"].Key[0] ^= 0x01
+	err := sleeve.RederiveAll(seed)
+	if err == nil {
+		t.Fatalf("RederiveAll() should return error for a mutated key")
+	}
+	if !strings.Contains(err.Error(), "Ethereum/3") {
+		t.Fatalf("RederiveAll() error should name the mismatched network: %v", err)
+	}
+}
+
+func TestGenSpec_OnTiming(t *testing.T) {
+	var stages []string
+	spec := DefaultGenSpec()
+	spec.OnTiming = func(stage string, d time.Duration) {
+		if d < 0 {
+			t.Errorf("OnTiming() got negative duration for %s: %v", stage, d)
+		}
+		stages = append(stages, stage)
+	}
+
+	if _, err := NewSingleSeedSleeve(rand.Reader, "", spec); err != nil {
+		t.Fatalf("NewSingleSeedSleeve() returned error: %v", err)
+	}
+	expected := []string{"entropy", "seed", "wots", "networks"}
+	if strings.Join(stages, ",") != strings.Join(expected, ",") {
+		t.Fatalf("OnTiming() called for stages %v, expected %v", stages, expected)
+	}
+
+	// Seed constructor skips the entropy and seed stages
+	stages = nil
+	if _, err := NewSingleSeedSleeveFromSeed(mustSeed(testVectorMnemonic), spec); err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromSeed() returned error: %v", err)
+	}
+	if strings.Join(stages, ",") != "wots,networks" {
+		t.Fatalf("OnTiming() called for stages %v, expected [wots networks]", stages)
+	}
+}
+
+func TestNewSingleSeedSleeveFromMnemonic_Canonicalize(t *testing.T) {
+	expected, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	words := strings.Fields(testVectorMnemonic)
+
+	inputs := []string{
+		"  " + testVectorMnemonic + "  ",
+		strings.Join(words, "   "),
+		"\t" + strings.Join(words, " \n ") + "\n",
+		strings.ToUpper(testVectorMnemonic),
+	}
+	for _, input := range inputs {
+		sleeve, err := NewSingleSeedSleeveFromMnemonic(input, "", DefaultGenSpec())
+		if err != nil {
+			t.Fatalf("NewSingleSeedSleeveFromMnemonic(%q) returned error: %v", input, err)
+		}
+		if sleeve.GetMnemonic() != testVectorMnemonic {
+			t.Fatalf("GetMnemonic() returned non canonical mnemonic: %q", sleeve.GetMnemonic())
+		}
+		if !bytes.Equal(sleeve.GetWOTSPublicKey(), expected.GetWOTSPublicKey()) {
+			t.Fatalf("NewSingleSeedSleeveFromMnemonic(%q) generated a different sleeve", input)
+		}
+	}
+
+	// Invalid words are still rejected
+	words[0] = "notaword"
+	if _, err := NewSingleSeedSleeveFromMnemonic(" "+strings.Join(words, "  "), "", DefaultGenSpec()); err == nil {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonic() should return error for invalid mnemonic")
+	}
+}
+
+func TestSingleSeedSleeve_GetNetworkNames(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+	_ = sleeve.DeriveNetworkKey("Cardano", CoinTypeCardano, seed)
+
+	expected := "Bitcoin,Cardano,Ethereum,Polkadot,Solana"
+	if names := strings.Join(sleeve.GetNetworkNames(), ","); names != expected {
+		t.Fatalf("GetNetworkNames() returned %s, expected %s", names, expected)
+	}
+}
+
+func TestSingleSeedSleeve_WithAccount(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "pass", DefaultGenSpec())
+	expected, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "pass", NewGenSpec(3, wots.DefaultParams))
+
+	switched, err := sleeve.WithAccount(3)
+	if err != nil {
+		t.Fatalf("WithAccount() returned error: %v", err)
+	}
+	if !bytes.Equal(switched.GetWOTSPublicKey(), expected.GetWOTSPublicKey()) {
+		t.Fatalf("WithAccount() WOTS+ public key doesn't match a sleeve built for the account")
+	}
+	if switched.DescriptorChecksum() != expected.DescriptorChecksum() {
+		t.Fatalf("WithAccount() networks don't match a sleeve built for the account")
+	}
+	if switched.GetMnemonic() != testVectorMnemonic || !switched.HasPassphrase() {
+		t.Fatalf("WithAccount() should keep the mnemonic and passphrase flag")
+	}
+
+	// Original sleeve is unchanged
+	if bytes.Equal(sleeve.GetWOTSPublicKey(), switched.GetWOTSPublicKey()) {
+		t.Fatalf("WithAccount() should derive a different WOTS+ key")
+	}
+
+	if _, err := sleeve.WithAccount(1 << 31); err == nil {
+		t.Fatalf("WithAccount() should return error for account 2^31")
+	}
+	sleeve.Wipe()
+	if _, err := sleeve.WithAccount(1); err == nil {
+		t.Fatalf("WithAccount() should return error for a wiped sleeve")
+	}
+}
+
+func TestSingleSeedSleeve_WithWOTSLevel(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "pass", NewGenSpec(2, wots.Level0))
+	expected, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "pass", NewGenSpec(2, wots.Level2))
+
+	switched, err := sleeve.WithWOTSLevel(wots.Level2)
+	if err != nil {
+		t.Fatalf("WithWOTSLevel() returned error: %v", err)
+	}
+	if switched.GetWOTSParams() != wots.Level2 {
+		t.Fatalf("WithWOTSLevel() sleeve has level %s, expected %s", switched.GetWOTSParams(), wots.Level2)
+	}
+	if !bytes.Equal(switched.GetWOTSPublicKey(), expected.GetWOTSPublicKey()) {
+		t.Fatalf("WithWOTSLevel() WOTS+ public key doesn't match a sleeve built for the level")
+	}
+	if switched.GetDerivationIndex() != expected.GetDerivationIndex() {
+		t.Fatalf("WithWOTSLevel() derivation index doesn't match a sleeve built for the level")
+	}
+	if switched.DescriptorChecksum() != expected.DescriptorChecksum() {
+		t.Fatalf("WithWOTSLevel() networks don't match a sleeve built for the level")
+	}
+	if switched.GetMnemonic() != testVectorMnemonic || !switched.HasPassphrase() {
+		t.Fatalf("WithWOTSLevel() should keep the mnemonic and passphrase flag")
+	}
+
+	// Addresses change with the level, and the original sleeve is unchanged
+	before, _ := sleeve.GetAddress("Ethereum")
+	after, _ := switched.GetAddress("Ethereum")
+	if before == after {
+		t.Fatalf("WithWOTSLevel() should change the network addresses")
+	}
+	if sleeve.GetWOTSParams() != wots.Level0 {
+		t.Fatalf("WithWOTSLevel() changed the original sleeve")
+	}
+
+	if _, err := sleeve.WithWOTSLevel(wots.ParamsEncodingLen); err == nil {
+		t.Fatalf("WithWOTSLevel() should return error for ParamsEncodingLen")
+	}
+	sleeve.Wipe()
+	if _, err := sleeve.WithWOTSLevel(wots.Level1); err == nil {
+		t.Fatalf("WithWOTSLevel() should return error for a wiped sleeve")
+	}
+}
+
+func TestSingleSeedSleeve_Identicon(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	same, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	other, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "other", DefaultGenSpec())
+
+	icon := sleeve.Identicon()
+	if icon != same.Identicon() {
+		t.Fatalf("Identicon() isn't deterministic")
+	}
+	if icon == other.Identicon() {
+		t.Fatalf("Identicon() should differ for different wallets")
+	}
+	for row := range icon {
+		for col := range icon[row] {
+			if icon[row][col] >= IdenticonColors {
+				t.Fatalf("Identicon() cell (%d, %d) out of palette: %d", row, col, icon[row][col])
+			}
+			if icon[row][col] != icon[row][7-col] {
+				t.Fatalf("Identicon() row %d isn't mirrored", row)
+			}
+		}
+	}
+}
+
+func TestSingleSeedSleeve_SeedMismatch(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "pass", DefaultGenSpec())
+	seed, _ := bip39.NewSeedWithErrorChecking(testVectorMnemonic, "pass")
+	// Same mnemonic with the wrong passphrase
+	wrongSeed, _ := bip39.NewSeedWithErrorChecking(testVectorMnemonic, "")
+
+	if err := sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed); err != nil {
+		t.Fatalf("DeriveNetworkKey() returned error for the sleeve's seed: %v", err)
+	}
+	if err := sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, wrongSeed); !errors.Is(err, ErrSeedMismatch) {
+		t.Fatalf("DeriveNetworkKey() should return ErrSeedMismatch for a wrong seed, got %v", err)
+	}
+	if _, err := sleeve.DeriveAddressKey("Ethereum", CoinTypeEthereum, 1, false, wrongSeed); !errors.Is(err, ErrSeedMismatch) {
+		t.Fatalf("DeriveAddressKey() should return ErrSeedMismatch for a wrong seed, got %v", err)
+	}
+	if err := sleeve.DeriveFromPathString("Custom", "m/0'", wrongSeed); !errors.Is(err, ErrSeedMismatch) {
+		t.Fatalf("DeriveFromPathString() should return ErrSeedMismatch for a wrong seed, got %v", err)
+	}
+	if _, err := sleeve.DeriveSymmetricKey("app", 32, wrongSeed); !errors.Is(err, ErrSeedMismatch) {
+		t.Fatalf("DeriveSymmetricKey() should return ErrSeedMismatch for a wrong seed, got %v", err)
+	}
+	if _, err := sleeve.FirstAddresses(wrongSeed, []uint32{CoinTypeEthereum}, 1); !errors.Is(err, ErrSeedMismatch) {
+		t.Fatalf("FirstAddresses() should return ErrSeedMismatch for a wrong seed, got %v", err)
+	}
+
+	// Stored keys are untouched by the rejected derivations
+	if err := sleeve.RederiveAll(seed); err != nil {
+		t.Fatalf("RederiveAll() returned error after rejected derivations: %v", err)
+	}
+}
+
+func TestSingleSeedSleeve_GetPrivateKeyCopy(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	stored := append([]byte{}, sleeve.GetAllNetworkKeys()["Ethereum"].Key...)
+
+	// Mutating the returned key doesn't change the stored one
+	key, _ := sleeve.GetPrivateKey("Ethereum")
+	zero(key)
+	again, _ := sleeve.GetPrivateKey("Ethereum")
+	if !bytes.Equal(again, stored) {
+		t.Fatalf("GetPrivateKey() should return a copy of the stored key")
+	}
+
+	dst := make([]byte, keySize)
+	if err := sleeve.GetPrivateKeyInto("Ethereum", dst); err != nil {
+		t.Fatalf("GetPrivateKeyInto() returned error: %v", err)
+	}
+	if !bytes.Equal(dst, stored) {
+		t.Fatalf("GetPrivateKeyInto() copied the wrong key")
+	}
+	zero(dst)
+	if !bytes.Equal(sleeve.GetAllNetworkKeys()["Ethereum"].Key, stored) {
+		t.Fatalf("GetPrivateKeyInto() should copy the stored key")
+	}
+
+	if err := sleeve.GetPrivateKeyInto("Ethereum", make([]byte, keySize-1)); err == nil {
+		t.Fatalf("GetPrivateKeyInto() should return error for wrong destination size")
+	}
+	if err := sleeve.GetPrivateKeyInto("Unknown", dst); err == nil {
+		t.Fatalf("GetPrivateKeyInto() should return error for unknown network")
+	}
+}
+
+func TestSingleSeedSleeve_MergeNetworks(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	other, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+	_ = other.DeriveNetworkKey("Cardano", CoinTypeCardano, seed)
+	_ = other.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+
+	if err := sleeve.MergeNetworks(other); err != nil {
+		t.Fatalf("MergeNetworks() returned error: %v", err)
+	}
+	expected := "Bitcoin,Cardano,Ethereum,Polkadot,Solana"
+	if names := strings.Join(sleeve.GetNetworkNames(), ","); names != expected {
+		t.Fatalf("MergeNetworks() resulted in networks %s, expected %s", names, expected)
+	}
+	if err := sleeve.RederiveAll(seed); err != nil {
+		t.Fatalf("RederiveAll() returned error after merge: %v", err)
+	}
+
+	// Merged keys are copies
+	other.Wipe()
+	if err := sleeve.RederiveAll(seed); err != nil {
+		t.Fatalf("Wipe() of the merged sleeve changed the merged keys: %v", err)
+	}
+
+	// Conflicting entry
+	conflict, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	_ = conflict.DeriveNetworkKey("Solana", CoinTypeStellar, seed)
+	_ = conflict.DeriveNetworkKey("Tezos", CoinTypeTezos, seed)
+	if err := sleeve.MergeNetworks(conflict); err == nil {
+		t.Fatalf("MergeNetworks() should return error for conflicting networks")
+	}
+	if _, err := sleeve.GetPrivateKey("Tezos"); err == nil {
+		t.Fatalf("MergeNetworks() shouldn't copy networks when the merge fails")
+	}
+
+	// Different wallet
+	different, _ := NewSingleSeedSleeveFromSeed(seed, NewGenSpec(1, wots.DefaultParams))
+	if err := sleeve.MergeNetworks(different); err == nil {
+		t.Fatalf("MergeNetworks() should return error for a different wallet")
+	}
+}
+
+func TestGenerateWalletsWithProgress(t *testing.T) {
+	var calls []uint32
+	sleeves, err := GenerateWalletsWithProgress(3, DefaultGenSpec(), func(done, total uint32) {
+		if total != 3 {
+			t.Errorf("onProgress() got total %d, expected 3", total)
+		}
+		calls = append(calls, done)
+	})
+	if err != nil {
+		t.Fatalf("GenerateWalletsWithProgress() returned error: %v", err)
+	}
+	if len(sleeves) != 3 || fmt.Sprint(calls) != "[1 2 3]" {
+		t.Fatalf("GenerateWalletsWithProgress() generated %d wallets with progress %v", len(sleeves), calls)
+	}
+
+	// Nil callback
+	if sleeves, err = GenerateWalletsWithProgress(1, DefaultGenSpec(), nil); err != nil || len(sleeves) != 1 {
+		t.Fatalf("GenerateWalletsWithProgress() with nil callback failed: %v", err)
+	}
+}
+
+func TestSingleSeedSleeve_StandardDerivationErrors(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	if errs := sleeve.StandardDerivationErrors(); len(errs) != 0 {
+		t.Fatalf("StandardDerivationErrors() should be empty, got %v", errs)
+	}
+
+	// A standard network failing doesn't prevent using the others
+	spec := DefaultGenSpec()
+	spec.standards = []StandardNetwork{{"Ethereum", CoinTypeEthereum}, {"Broken", 1 << 31}}
+	sleeve, err := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", spec)
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonic() returned error: %v", err)
+	}
+	errs := sleeve.StandardDerivationErrors()
+	if len(errs) != 1 || errs["Broken"] == nil {
+		t.Fatalf("StandardDerivationErrors() should report the Broken network, got %v", errs)
+	}
+	if _, err := sleeve.GetPrivateKey("Ethereum"); err != nil {
+		t.Fatalf("GetPrivateKey() returned error for a derived standard network: %v", err)
+	}
+	if sleeve.StandardNetworkCount() != 1 {
+		t.Fatalf("StandardNetworkCount() should only count derived networks, got %d", sleeve.StandardNetworkCount())
+	}
+
+	// Wrong seed is still an error
+	if err := sleeve.DeriveStandardNetworks(mustSeed(wotsTestVectorMnemonic)); !errors.Is(err, ErrSeedMismatch) {
+		t.Fatalf("DeriveStandardNetworks() should return ErrSeedMismatch for a wrong seed, got %v", err)
+	}
+}
+
+func TestSingleSeedSleeve_WOTSPublicKeyEncodings(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(wotsTestVectorMnemonic, "", DefaultGenSpec())
+
+	if sleeve.GetWOTSPublicKeyHex() != wotsExpectedPubKeyHex {
+		t.Fatalf("GetWOTSPublicKeyHex() returned %s, expected %s", sleeve.GetWOTSPublicKeyHex(), wotsExpectedPubKeyHex)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(sleeve.GetWOTSPublicKeyBase64())
+	if err != nil || !bytes.Equal(decoded, sleeve.GetWOTSPublicKey()) {
+		t.Fatalf("GetWOTSPublicKeyBase64() doesn't encode the WOTS+ public key: %v", err)
+	}
+	expected := hex.EncodeToString(hasher.SHA3_256.Hash(sleeve.GetWOTSPublicKey())[:4])
+	if sleeve.GetWOTSPublicKeyFingerprint() != expected {
+		t.Fatalf("GetWOTSPublicKeyFingerprint() returned %s, expected %s", sleeve.GetWOTSPublicKeyFingerprint(), expected)
+	}
+}
+
+func TestNewWOTSOnlySleeve(t *testing.T) {
+	ent := make([]byte, EntropySize)
+	_, _ = rand.Read(ent)
+	spec := NewGenSpec(1, wots.Level1)
+
+	wotsOnly, err := NewWOTSOnlySleeve(bytes.NewReader(ent), "pass", spec)
+	if err != nil {
+		t.Fatalf("NewWOTSOnlySleeve() returned error: %v", err)
+	}
+	full, _ := NewSingleSeedSleeve(bytes.NewReader(ent), "pass", spec)
+
+	if wotsOnly.GetMnemonic() != full.GetMnemonic() ||
+		!bytes.Equal(wotsOnly.GetWOTSPublicKey(), full.GetWOTSPublicKey()) ||
+		wotsOnly.GetDerivationIndex() != full.GetDerivationIndex() {
+		t.Fatalf("NewWOTSOnlySleeve() should generate the same WOTS+ side as NewSingleSeedSleeve")
+	}
+	if len(wotsOnly.GetAllNetworkKeys()) != 0 {
+		t.Fatalf("NewWOTSOnlySleeve() shouldn't derive any network, got %d", len(wotsOnly.GetAllNetworkKeys()))
+	}
+
+	// Networks can still be derived later
+	if err := wotsOnly.DeriveNetworkKey("Ethereum", CoinTypeEthereum, wotsOnly.GetSeedUnsafe()); err != nil {
+		t.Fatalf("DeriveNetworkKey() returned error: %v", err)
+	}
+	got, _ := wotsOnly.GetPrivateKey("Ethereum")
+	expected, _ := full.GetPrivateKey("Ethereum")
+	if !bytes.Equal(got, expected) {
+		t.Fatalf("DeriveNetworkKey() on a WOTS-only sleeve returned a different key")
+	}
+}
+
+func TestGenSpec_IndexHardened(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+
+	// Off by default, reproducing the WOTS+ path, masked index and non-hardened address level
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	path, _ := DefaultGenSpec().PathFromSpec()
+	if path.String() != "m/44'/1955'/0'/0'/0'" {
+		t.Fatalf("PathFromSpec() returned wrong path %s for the default spec", path)
+	}
+	pkHash := hasher.SHA3_256.Hash(sleeve.GetWOTSPublicKey())
+	if sleeve.GetDerivationIndex() != binary.BigEndian.Uint32(pkHash[:4])&0x7FFFFFFF {
+		t.Fatalf("Default spec should mask the derivation index to 31 bits")
+	}
+	netKey := sleeve.GetAllNetworkKeys()["Ethereum"]
+	if netKey.HardenedAddressIndex || netKey.path[4] != sleeve.GetDerivationIndex() {
+		t.Fatalf("Default spec should derive a non-hardened address level, got path %s", netKey.Path)
+	}
+
+	// WOTS+ key at another nonce, with the full 32 bit index OR'd with the hardened bit
+	spec := DefaultGenSpec()
+	spec.IndexHardened = true
+	path, _ = spec.PathFromSpec()
+	if path.String() != "m/44'/1955'/0'/0'/1'" {
+		t.Fatalf("PathFromSpec() returned wrong path %s for IndexHardened", path)
+	}
+	hardened, err := NewSingleSeedSleeveFromSeed(seed, spec)
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromSeed() returned error: %v", err)
+	}
+	if bytes.Equal(hardened.GetWOTSPublicKey(), sleeve.GetWOTSPublicKey()) {
+		t.Fatalf("IndexHardened shouldn't share the WOTS+ key of the default spec")
+	}
+	pkHash = hasher.SHA3_256.Hash(hardened.GetWOTSPublicKey())
+	if hardened.GetDerivationIndex() != binary.BigEndian.Uint32(pkHash[:4])|firstHardened {
+		t.Fatalf("IndexHardened should use the full 32 bit derivation index with the hardened bit")
+	}
+	if err := hardened.Verify(seed); err != nil {
+		t.Fatalf("Verify() returned error for IndexHardened: %v", err)
+	}
+
+	// Hardened address level for every network key, at the derivation index itself
+	for name, netKey := range hardened.GetAllNetworkKeys() {
+		if !netKey.HardenedAddressIndex || netKey.path[4] != hardened.GetDerivationIndex() {
+			t.Fatalf("IndexHardened should derive %s at a hardened address level, got path %s", name, netKey.Path)
+		}
+	}
+	node, _ := deriveNodeAtPath(seed, networkPath(CoinTypeEthereum, 0, 0, hardened.GetDerivationIndex()))
+	got, _ := hardened.GetPrivateKey("Ethereum")
+	if !bytes.Equal(got, node.Key) {
+		t.Fatalf("IndexHardened key doesn't match the key at its hardened path")
+	}
+	addrKey, _ := hardened.DeriveAddressKey("Ethereum", CoinTypeEthereum, 1, false, seed)
+	if !addrKey.HardenedAddressIndex {
+		t.Fatalf("IndexHardened should harden address keys too")
+	}
+
+	// First addresses follow the hardened derivation, and no xpub is available
+	addrs, err := hardened.FirstAddresses(seed, []uint32{CoinTypeEthereum}, 2)
+	if err != nil {
+		t.Fatalf("FirstAddresses() returned error: %v", err)
+	}
+	ethAddr, _ := hardened.GetAddress("Ethereum")
+	addr1, _ := hardened.GetAddress(addressKeyName("Ethereum", 1, false))
+	if addrs[CoinTypeEthereum][0] != ethAddr || addrs[CoinTypeEthereum][1] != addr1 {
+		t.Fatalf("FirstAddresses() doesn't match the hardened address keys")
+	}
+	if _, err := hardened.GetExtendedPublicKey("Ethereum"); err == nil {
+		t.Fatalf("GetExtendedPublicKey() should return error for hardened address keys")
+	}
+}
+
+func TestSingleSeedSleeve_ZeroDerivationIndex(t *testing.T) {
+	// A zero index needs a WOTS+ public key whose hash starts with 31 zero bits, so entropy
+	// producing one can't be searched for in a test. Set the index of a sleeve to 0 instead
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, NewGenSpec(0, wots.DefaultParams))
+	sleeve.derivationIndex = 0
+	if !sleeve.HasDerivationIndex() {
+		t.Fatalf("HasDerivationIndex() should be true for a zero index")
+	}
+
+	if err := sleeve.DeriveNetworkKey("Ethereum", CoinTypeEthereum, seed); err != nil {
+		t.Fatalf("DeriveNetworkKey() returned error with a zero index: %v", err)
+	}
+	netKey := sleeve.GetAllNetworkKeys()["Ethereum"]
+	if netKey.Path != "m/44'/60'/0'/0'/0" {
+		t.Fatalf("DeriveNetworkKey() with a zero index returned wrong path %s", netKey.Path)
+	}
+	node, _ := deriveNodeAtPath(seed, networkPath(CoinTypeEthereum, 0, 0, 0))
+	if !bytes.Equal(netKey.Key, node.Key) {
+		t.Fatalf("DeriveNetworkKey() with a zero index returned wrong key")
+	}
+	if _, err := sleeve.FirstAddresses(seed, []uint32{CoinTypeEthereum}, 1); err != nil {
+		t.Fatalf("FirstAddresses() returned error with a zero index: %v", err)
+	}
+
+	// A sleeve not created by a constructor has no index, rather than index 0
+	var empty SingleSeedSleeve
+	if empty.HasDerivationIndex() {
+		t.Fatalf("HasDerivationIndex() should be false for a zero value sleeve")
+	}
+	if err := empty.DeriveNetworkKey("Ethereum", CoinTypeEthereum, seed); err == nil {
+		t.Fatalf("DeriveNetworkKey() should return error for a sleeve without derivation index")
+	}
+	if _, err := empty.FirstAddresses(seed, []uint32{CoinTypeEthereum}, 1); err == nil {
+		t.Fatalf("FirstAddresses() should return error for a sleeve without derivation index")
+	}
+}
+
+func TestSingleSeedSleeve_MnemonicChecksumWord(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	if word := sleeve.GetMnemonicChecksumWord(); word != "length" {
+		t.Fatalf("GetMnemonicChecksumWord() returned %q, expected %q", word, "length")
+	}
+	for _, word := range []string{"length", " Length ", "LENGTH"} {
+		if !sleeve.VerifyLastWord(word) {
+			t.Fatalf("VerifyLastWord(%q) should return true", word)
+		}
+	}
+	for _, word := range []string{"", "lengths", "delay", "hamster"} {
+		if sleeve.VerifyLastWord(word) {
+			t.Fatalf("VerifyLastWord(%q) should return false", word)
+		}
+	}
+
+	// No mnemonic
+	fromSeed, _ := NewSingleSeedSleeveFromSeed(mustSeed(testVectorMnemonic), DefaultGenSpec())
+	if fromSeed.GetMnemonicChecksumWord() != "" || fromSeed.VerifyLastWord("") || fromSeed.VerifyLastWord("length") {
+		t.Fatalf("Sleeve without mnemonic shouldn't have a checksum word")
+	}
+	sleeve.Wipe()
+	if sleeve.VerifyLastWord("length") {
+		t.Fatalf("VerifyLastWord() should return false after Wipe")
+	}
+}
+
+func TestSingleSeedSleeve_ExportAccountWatchBundle(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, NewGenSpec(2, wots.DefaultParams))
+	_ = sleeve.DeriveNetworkKey("Dogecoin", CoinTypeDogecoin, seed)
+
+	bundle, err := sleeve.ExportAccountWatchBundle([]uint32{CoinTypeBitcoin, CoinTypeEthereum, CoinTypeDogecoin})
+	if err != nil {
+		t.Fatalf("ExportAccountWatchBundle() returned error: %v", err)
+	}
+	master, _ := NewMasterNode(seed)
+	fingerprint, _ := master.Fingerprint()
+	for name, coinType := range map[string]uint32{"Bitcoin": CoinTypeBitcoin, "Ethereum": CoinTypeEthereum, "Dogecoin": CoinTypeDogecoin} {
+		xpub, _ := sleeve.GetExtendedPublicKey(name)
+		expected := fmt.Sprintf("[%x/44'/%d'/0'/0']%s", fingerprint, coinType, xpub)
+		if bundle[coinType] != expected {
+			t.Fatalf("ExportAccountWatchBundle() returned %s for %s, expected %s", bundle[coinType], name, expected)
+		}
+	}
+
+	// Unsupported coin types and sleeves
+	if _, err := sleeve.ExportAccountWatchBundle([]uint32{CoinTypeSolana}); err == nil {
+		t.Fatalf("ExportAccountWatchBundle() should return error for ed25519 coin types")
+	}
+	if _, err := sleeve.ExportAccountWatchBundle([]uint32{1 << 31}); err == nil {
+		t.Fatalf("ExportAccountWatchBundle() should return error for invalid coin types")
+	}
+	spec := DefaultGenSpec()
+	spec.IndexHardened = true
+	hardened, _ := NewSingleSeedSleeveFromSeed(seed, spec)
+	if _, err := hardened.ExportAccountWatchBundle([]uint32{CoinTypeEthereum}); err == nil {
+		t.Fatalf("ExportAccountWatchBundle() should return error for hardened address indexes")
+	}
+	sleeve.Wipe()
+	if _, err := sleeve.ExportAccountWatchBundle([]uint32{CoinTypeEthereum}); err == nil {
+		t.Fatalf("ExportAccountWatchBundle() should return error after Wipe")
+	}
+}
+
+func TestSingleSeedSleeve_ConfirmMnemonic(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+
+	// Whitespace and case differences still match
+	inputs := []string{
+		testVectorMnemonic,
+		"  " + strings.ToUpper(testVectorMnemonic) + "\n",
+		strings.Join(strings.Fields(testVectorMnemonic), "\t  "),
+	}
+	for _, input := range inputs {
+		if !sleeve.ConfirmMnemonic(input) {
+			t.Fatalf("ConfirmMnemonic(%q) should return true", input)
+		}
+	}
+
+	// Different words don't
+	words := strings.Fields(testVectorMnemonic)
+	words[len(words)-1], words[0] = words[0], words[len(words)-1]
+	for _, input := range []string{"", strings.Join(words, " "), wotsTestVectorMnemonic, strings.Join(words[1:], " ")} {
+		if sleeve.ConfirmMnemonic(input) {
+			t.Fatalf("ConfirmMnemonic(%q) should return false", input)
+		}
+	}
+
+	sleeve.Wipe()
+	if sleeve.ConfirmMnemonic(testVectorMnemonic) || sleeve.ConfirmMnemonic("") {
+		t.Fatalf("ConfirmMnemonic() should return false after Wipe")
+	}
+}
+
+func TestMnemonicFromReader(t *testing.T) {
+	// The mnemonic previewed is the one the sleeve gets from the same entropy
+	ent := make([]byte, EntropySize)
+	for i := range ent {
+		ent[i] = byte(i * 7)
+	}
+	mnemonic, err := MnemonicFromReader(bytes.NewReader(ent), 256)
+	if err != nil {
+		t.Fatalf("MnemonicFromReader() returned error: %v", err)
+	}
+	sleeve, err := NewSingleSeedSleeve(bytes.NewReader(ent), "", DefaultGenSpec())
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeve() returned error: %v", err)
+	}
+	if mnemonic != sleeve.GetMnemonic() {
+		t.Fatalf("MnemonicFromReader() returned %q, expected %q", mnemonic, sleeve.GetMnemonic())
+	}
+	finalized, err := NewSingleSeedSleeveFromMnemonic(mnemonic, "", DefaultGenSpec())
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonic() returned error: %v", err)
+	}
+	if finalized.GetWOTSPublicKeyHex() != sleeve.GetWOTSPublicKeyHex() {
+		t.Fatalf("finalized sleeve doesn't match the sleeve created from the entropy")
+	}
+
+	// Every BIP39 size is accepted
+	for bits := 128; bits <= 256; bits += 32 {
+		mnemonic, err := MnemonicFromReader(rand.Reader, bits)
+		if err != nil {
+			t.Fatalf("MnemonicFromReader() returned error for %d bits: %v", bits, err)
+		}
+		if words := len(strings.Fields(mnemonic)); words != bits/32*3 {
+			t.Fatalf("MnemonicFromReader() returned %d words for %d bits", words, bits)
+		}
+	}
+
+	for _, bits := range []int{0, 96, 127, 129, 144, 288, -128} {
+		if _, err := MnemonicFromReader(rand.Reader, bits); !errors.Is(err, ErrInvalidBIP39Entropy) {
+			t.Fatalf("MnemonicFromReader() should return ErrInvalidBIP39Entropy for %d bits, got %v", bits, err)
+		}
+	}
+
+	// Reader errors are propagated, including short reads
+	if _, err := MnemonicFromReader(bytes.NewReader(ent[:20]), 256); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("MnemonicFromReader() should return the reader error for short entropy, got %v", err)
+	}
+	readErr := errors.New("reader failed")
+	if _, err := MnemonicFromReader(iotest.ErrReader(readErr), 256); !errors.Is(err, readErr) {
+		t.Fatalf("MnemonicFromReader() should return the reader error, got %v", err)
+	}
+
+	// Broken entropy fails the health check
+	if _, err := MnemonicFromReader(bytes.NewReader(make([]byte, EntropySize)), 256); err == nil {
+		t.Fatalf("MnemonicFromReader() should return error for all-zero entropy")
+	}
+}
+
+func TestSingleSeedSleeve_WOTSHashFunc(t *testing.T) {
+	enc := wots.DefaultParams.WithHashFunc(wots.HashBLAKE2B_256)
+	sleeve, err := NewSingleSeedSleeveFromMnemonic(wotsTestVectorMnemonic, "", NewGenSpec(0, enc))
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonic() returned error: %v", err)
+	}
+	if sleeve.GetWOTSPublicKeyHex() == wotsExpectedPubKeyHex {
+		t.Fatalf("WOTS+ public key should change with the hash function")
+	}
+
+	// The descriptor records the hash function, so recovery gives the same key
+	desc, err := sleeve.ExportDescriptor()
+	if err != nil {
+		t.Fatalf("ExportDescriptor() returned error: %v", err)
+	}
+	if desc.Params != "Level0+BLAKE2B_256" {
+		t.Fatalf("Descriptor() returned params %s, expected Level0+BLAKE2B_256", desc.Params)
+	}
+	parsed, err := parseParamsEncoding(desc.Params)
+	if err != nil || parsed != enc {
+		t.Fatalf("parseParamsEncoding(%s) returned %d, %v, expected %d", desc.Params, parsed, err, enc)
+	}
+	recovered, _ := NewSingleSeedSleeveFromMnemonic(wotsTestVectorMnemonic, "", NewGenSpec(0, parsed))
+	if recovered.GetWOTSPublicKeyHex() != sleeve.GetWOTSPublicKeyHex() {
+		t.Fatalf("recovered sleeve has a different WOTS+ public key")
+	}
+}
+
+// Test checking derivation parameters against derived network keys
+func TestSingleSeedSleeve_WouldCollide(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	index := sleeve.GetDerivationIndex()
+
+	if name, collides := sleeve.WouldCollide(CoinTypeEthereum, 0, 0, index); !collides || name != "Ethereum" {
+		t.Fatalf("WouldCollide() should return Ethereum for its parameters, got %q, %v", name, collides)
+	}
+	notColliding := [][4]uint32{
+		{CoinTypeEthereum, 1, 0, index},
+		{CoinTypeEthereum, 0, 1, index},
+		{CoinTypeEthereum, 0, 0, index + 1},
+		{CoinTypeSolana, 0, 0, index},
+	}
+	for _, p := range notColliding {
+		if name, collides := sleeve.WouldCollide(p[0], p[1], p[2], p[3]); collides {
+			t.Fatalf("WouldCollide(%v) shouldn't collide, got %s", p, name)
+		}
+	}
+
+	// Address keys are found by their full address level element
+	if _, err := sleeve.DeriveAddressKey("eth", CoinTypeEthereum, 1, false, seed); err != nil {
+		t.Fatalf("DeriveAddressKey() returned error: %v", err)
+	}
+	if name, collides := sleeve.WouldCollide(CoinTypeEthereum, 0, 0, index+1); !collides || name != "eth#1" {
+		t.Fatalf("WouldCollide() should return the address key, got %q, %v", name, collides)
+	}
+
+	// Nothing is derived
+	keys := len(sleeve.GetAllNetworkKeys())
+	sleeve.WouldCollide(CoinTypeSolana, 0, 0, index)
+	if len(sleeve.GetAllNetworkKeys()) != keys {
+		t.Fatalf("WouldCollide() shouldn't derive network keys")
+	}
+}
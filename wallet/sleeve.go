@@ -7,19 +7,86 @@
 package wallet
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/tyler-smith/go-bip39"
 	"github.com/xx-labs/sleeve/hasher"
 	"github.com/xx-labs/sleeve/wots"
+	"golang.org/x/crypto/hkdf"
 )
 
 const EntropySize = 32
 const MnemonicWords = 24
+const SeedSize = 64
+
+var (
+	// Returned for entropy whose length isn't a BIP39 length (16, 20, 24, 28 or 32 bytes)
+	ErrInvalidBIP39Entropy = errors.New("entropy length is not a valid BIP39 length")
+	// Returned for valid BIP39 entropy that is shorter than the EntropySize Sleeve requires
+	ErrEntropyTooSmallForSleeve = errors.New("entropy is too small for Sleeve")
+	// Returned when a seed passed to a derivation method isn't the sleeve's seed,
+	// e.g., it was computed from the mnemonic with the wrong passphrase
+	ErrSeedMismatch = errors.New("seed doesn't match the sleeve's mnemonic and passphrase")
+	// Returned by methods needing private keys or the seed on watch-only sleeves, see ImportPublicOnly
+	ErrWatchOnly = errors.New("watch-only sleeve has no private keys")
+	// Returned by methods needing the WOTS+ secret key once the sleeve was wiped, see Wipe
+	ErrWiped = errors.New("sleeve was wiped, its secret keys are no longer available")
+	// Returned when deriving network keys with a sleeve that wasn't created by a constructor
+	errNoDerivationIndex = errors.New("sleeve has no derivation index - create it with a constructor")
+)
+
+// Domain separation prefix for the stored seed hash
+const seedHashPrefix = "xx network sleeve seed hash"
+
+// Compute the hash of a seed stored to check seeds passed to derivation methods
+func hashSeed(seed []byte) []byte {
+	h := hasher.SHA3_256.New()
+	h.Write([]byte(seedHashPrefix))
+	h.Write(seed)
+	return h.Sum(nil)
+}
+
+// Check a seed passed to a derivation method is the sleeve's seed
+func (s *SingleSeedSleeve) checkSeed(seed []byte) error {
+	if s.watchOnly {
+		return ErrWatchOnly
+	}
+	if !hmac.Equal(hashSeed(seed), s.seedHash) {
+		return ErrSeedMismatch
+	}
+	return nil
+}
+
+// Check entropy has a BIP39 length, and is long enough for Sleeve
+// All WOTS+ levels require the full EntropySize, as the mnemonic must have MnemonicWords words
+func checkEntropySize(ent []byte) error {
+	n := len(ent)
+	if n < 16 || n > 32 || n%4 != 0 {
+		return fmt.Errorf("%w: got %d bytes, expected 16, 20, 24, 28 or 32", ErrInvalidBIP39Entropy, n)
+	}
+	if n < EntropySize {
+		return fmt.Errorf("%w: got %d bytes, expected %d", ErrEntropyTooSmallForSleeve, n, EntropySize)
+	}
+	return nil
+}
+
+// Domain separation prefix for the passphrase check value
+const passphraseCheckPrefix = "xx network sleeve passphrase check"
 
 ///////////////////////////////////////////////////////////////////////
 // SLEEVE WALLET
@@ -72,6 +139,25 @@ type Sleeve struct {
 type GenSpec struct {
 	account uint32
 	params  wots.ParamsEncoding
+	// Skip the health check on entropy read from a CSPRNG (checked by default)
+	skipEntropyCheck bool
+	// Optional hook called by the constructors with the time taken by each stage:
+	// "entropy", "seed", "wots" and "networks". Not called when nil
+	OnTiming func(stage string, d time.Duration)
+	// Networks derived automatically by single-seed constructors, nil for the default list
+	standards []StandardNetwork
+	// Don't derive the standard networks in single-seed constructors
+	lazyDerivation bool
+	// Use the full 32 bits of the WOTS+ public key hash OR'd with the hardened bit as the derivation index,
+	// so every network key of single-seed sleeves is at a hardened address level, for experimental schemes.
+	// Off by default, which masks the index to 31 bits and derives it non-hardened as existing wallets do.
+	// The WOTS+ key is derived at another nonce, see PathFromSpec
+	IndexHardened bool
+	// Derive network keys of single-seed sleeves under the spec's account, i.e., at
+	// m/44'/{coin}'/{account}'/0'/{index}. Off by default, which derives them under account 0'
+	// whatever the spec's account, as wallets created before this option do. The account
+	// always selects the WOTS+ key, and with it the derivation index
+	AccountInNetworkPaths bool
 }
 
 func DefaultGenSpec() GenSpec {
@@ -88,10 +174,70 @@ func NewGenSpec(account uint32, params wots.ParamsEncoding) GenSpec {
 	}
 }
 
+// Get the account level of the network paths of sleeves generated with the spec
+// It's 0 unless AccountInNetworkPaths is set, since network keys of existing wallets are derived
+// under account 0' whatever their account, which only selects the WOTS+ key
+func (g GenSpec) NetworkAccount() uint32 {
+	if g.AccountInNetworkPaths {
+		return g.account
+	}
+	return 0
+}
+
+// Nonce of the WOTS+ path of IndexHardened specs
+const indexHardenedNonce = 1
+
+// Get the path of the WOTS+ key: m/44'/1955'/account'/params'/nonce'
+// The nonce is 0, or 1 for IndexHardened specs, so the two modes never share a WOTS+ key
+// whose one-time signatures would be reserved under two different derivation indexes
 func (g GenSpec) PathFromSpec() (Path, error) {
+	if g.IndexHardened {
+		return NewPath(g.account, uint32(g.params), indexHardenedNonce)
+	}
 	return NewPath(g.account, uint32(g.params), 0)
 }
 
+// Compute the derivation index that binds network keys to a WOTS+ public key from its SHA3_256 hash
+// The first 4 bytes are masked to 31 bits for non-hardened derivation by default,
+// or used in full OR'd with the hardened bit for IndexHardened specs
+func (g GenSpec) derivationIndex(pkHash []byte) uint32 {
+	index := binary.BigEndian.Uint32(pkHash[:4])
+	if g.IndexHardened {
+		return index | firstHardened
+	}
+	return index & 0x7FFFFFFF
+}
+
+// Get the standard networks of single-seed sleeves generated with the spec
+func (g GenSpec) standardNetworkList() []StandardNetwork {
+	if g.standards != nil {
+		return g.standards
+	}
+	return standardNetworks
+}
+
+// Return a copy of the spec with the entropy health check enabled or disabled
+// The check is enabled by default and only applies to entropy read from a CSPRNG
+func (g GenSpec) WithEntropyCheck(enabled bool) GenSpec {
+	g.skipEntropyCheck = !enabled
+	return g
+}
+
+// Start timing a stage, only reading the clock when a timing hook is set
+func (g GenSpec) startTiming() time.Time {
+	if g.OnTiming == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// Report the time taken by a stage to the timing hook, if set
+func (g GenSpec) reportTiming(stage string, start time.Time) {
+	if g.OnTiming != nil {
+		g.OnTiming(stage, time.Since(start))
+	}
+}
+
 ///////////////////////////////////////////////////////////////////////
 // CONSTRUCTORS
 
@@ -111,16 +257,15 @@ func NewSleeve(csprng io.Reader, passphrase string, spec GenSpec) (*Sleeve, erro
 // Create a sleeve with provided entropy, passphrase and using the given generation spec
 // Entropy must have 32 bytes
 func NewSleeveFromEntropy(ent []byte, passphrase string, spec GenSpec) (*Sleeve, error) {
-	// 1. Generate BIP39 mnemonic from entropy
-	// (fails if entropy is not 16, 20, 24, 28 or 32 bytes)
-	mnem, err := bip39.NewMnemonic(ent)
-	if err != nil {
+	// 1. Validate entropy is valid for BIP39 and has Sleeve required size of EntropySize
+	if err := checkEntropySize(ent); err != nil {
 		return nil, err
 	}
 
-	// 2. Validate entropy has Sleeve required size of EntropySize
-	if len(ent) != EntropySize {
-		return nil, errors.New("provided entropy is of incorrect size")
+	// 2. Generate BIP39 mnemonic from entropy
+	mnem, err := bip39.NewMnemonic(ent)
+	if err != nil {
+		return nil, err
 	}
 
 	// 3. Get Sleeve from mnemonic
@@ -213,6 +358,11 @@ func generateSleeve(secretSeed, publicSeed []byte, params *wots.Params) []byte {
 	pk := wotsKey.ComputePK()
 
 	// 3. Derive Sleeve secret key and return output
+	return sleeveOutput(secretSeed, pk)
+}
+
+// Compute the Sleeve output entropy from the secret seed and the WOTS+ public key
+func sleeveOutput(secretSeed, pk []byte) []byte {
 	secretKey := hasher.SHA3_256.Hash(append([]byte("xx network sleeve"), secretSeed...))
 	return hasher.SHA3_256.Hash(append(secretKey, pk...))
 }
@@ -232,41 +382,222 @@ func generateSleeve(secretSeed, publicSeed []byte, params *wots.Params) []byte {
 
 	Path structure:
 	- Quantum path: m/44'/1955'/0'/0'/0' (unchanged)
-	- Network paths: m/44'/{coin}'/0'/0/{wots_index}
+	- Network paths: m/44'/{coin}'/0'/0'/{wots_index}
 	  where {wots_index} = first_4_bytes(SHA3_256(WOTS_PK))
+	  The account level is 0' whatever the account of the WOTS+ key,
+	  unless GenSpec.AccountInNetworkPaths is set
 
 	This approach supports any BIP44-compliant network automatically.
 */
 
 // Network coin type constants for BIP44 derivation
 const (
-	CoinTypeBitcoin  uint32 = 0
-	CoinTypeEthereum uint32 = 60
-	CoinTypePolkadot uint32 = 354
-	CoinTypeLitecoin uint32 = 2
-	CoinTypeCardano  uint32 = 1815
+	CoinTypeBitcoin   uint32 = 0
+	CoinTypeEthereum  uint32 = 60
+	CoinTypePolkadot  uint32 = 354
+	CoinTypeLitecoin  uint32 = 2
+	CoinTypeDogecoin  uint32 = 3
+	CoinTypeDash      uint32 = 5
+	CoinTypeCardano   uint32 = 1815
+	CoinTypeSolana    uint32 = 501
+	CoinTypeStellar   uint32 = 148
+	CoinTypeTezos     uint32 = 1729
+	CoinTypeAvalanche uint32 = 9000
+	CoinTypePolygon   uint32 = 966
+	CoinTypeFantom    uint32 = 1007
+	CoinTypeEOS       uint32 = 194
+	// Ethereum Classic, an EVM chain signing transactions with its own chain ID
+	CoinTypeEthereumClassic uint32 = 61
+	// Zcash, only its transparent addresses are supported
+	CoinTypeZcash uint32 = 133
+	// Near Protocol, an ed25519 chain whose implicit accounts are the hex public key
+	CoinTypeNear uint32 = 397
+	// Cosmos Hub, a secp256k1 chain
+	CoinTypeCosmos uint32 = 118
+	// BNB Smart Chain wallets commonly use Ethereum's coin type
+	CoinTypeBSC uint32 = CoinTypeEthereum
+)
+
+// Curve identifies the elliptic curve a network key is used on
+// Keys are derived with BIP32, so for curves other than secp256k1 the 32 byte key
+// is used as the seed for that curve, except for Tezos and Near keys, which are
+// derived with SLIP-0010 for ed25519 as their wallets do
+type Curve uint8
+
+const (
+	CurveSecp256k1 Curve = iota
+	CurveEd25519
+	CurveSr25519
 )
 
+// Returns the string representation of the curve
+func (c Curve) String() string {
+	switch c {
+	case CurveSecp256k1:
+		return "secp256k1"
+	case CurveEd25519:
+		return "ed25519"
+	case CurveSr25519:
+		return "sr25519"
+	default:
+		return "UNKNOWN CURVE"
+	}
+}
+
+// Get the curve used by the network with the given coin type
+// Networks not explicitly listed default to secp256k1
+func CurveForCoinType(coinType uint32) Curve {
+	switch coinType {
+	case CoinTypePolkadot:
+		return CurveSr25519
+	case CoinTypeSolana, CoinTypeStellar, CoinTypeTezos, CoinTypeCardano, CoinTypeNear:
+		return CurveEd25519
+	default:
+		return CurveSecp256k1
+	}
+}
+
+// DerivationScheme selects the path layout a network key is derived at, to match
+// the wallet the key is imported into
+type DerivationScheme uint8
+
+const (
+	// The sleeve's path, bound to the WOTS+ key: m/44'/coin'/account'/0'/index
+	SchemeBIP44 DerivationScheme = iota
+	// Ledger Live's path, with the account hardened: m/44'/coin'/account'/0/0
+	SchemeLedgerLive
+	// MetaMask's path, with the account as address index: m/44'/coin'/0'/0/account
+	SchemeMetaMask
+)
+
+func (d DerivationScheme) String() string {
+	switch d {
+	case SchemeBIP44:
+		return "BIP44"
+	case SchemeLedgerLive:
+		return "LedgerLive"
+	case SchemeMetaMask:
+		return "MetaMask"
+	default:
+		return "UNKNOWN SCHEME"
+	}
+}
+
+// Parse the name of a derivation scheme, as returned by String
+func ParseDerivationScheme(s string) (DerivationScheme, error) {
+	for d := SchemeBIP44; d <= SchemeMetaMask; d++ {
+		if strings.EqualFold(s, d.String()) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown derivation scheme %q", s)
+}
+
+// Get the path of a key of a scheme other than SchemeBIP44, whose paths don't depend on the WOTS+ key
+// These keys aren't bound to the sleeve's WOTS+ key, they're only meant for wallets with fixed paths
+func (d DerivationScheme) path(coinType, account uint32) (Path, error) {
+	switch d {
+	case SchemeLedgerLive:
+		return Path{purpose, coinType | firstHardened, account | firstHardened, 0, 0}, nil
+	case SchemeMetaMask:
+		return Path{purpose, coinType | firstHardened, firstHardened, 0, account}, nil
+	default:
+		return nil, fmt.Errorf("derivation scheme %s has no fixed path", d)
+	}
+}
+
 // NetworkKey represents a derived key for a specific network
 type NetworkKey struct {
 	Network  string // Network name (e.g., "Bitcoin", "Ethereum")
 	CoinType uint32 // BIP44 coin type
+	Account  uint32 // BIP44 account
+	Curve    Curve  // Curve the key is used on
 	Path     string // Full derivation path
 	Key      []byte // Derived private key
+	// Address index, added to the WOTS-derived index at the last path level
+	AddressIndex uint32
+	// Whether the address level is hardened
+	// Hardened address keys can't be derived from an extended public key
+	HardenedAddressIndex bool
+	// Address and WIF encoding params overriding the defaults of the coin type, nil if not set
+	AddressParams *AddressParams
+	// Path layout the key was derived with, SchemeBIP44 for the sleeve's own path
+	Scheme DerivationScheme
+	// Structured derivation path
+	path Path
+	// BIP32 chain code of the derived key
+	code []byte
+	// Parent node of the derived key, used for the extended public key
+	parentNode *Node
+	// Fingerprint of the parent of parentNode
+	parentNodeFingerprint []byte
+	// Public key of watch-only keys, which have no private key
+	publicKey []byte
+}
+
+// InvalidCoinTypeError is returned when deriving a network key with a coin type >= 2^31
+// BIP44 coin types are always hardened, so they must fit in 31 bits
+type InvalidCoinTypeError struct {
+	CoinType uint32
+}
+
+func (e *InvalidCoinTypeError) Error() string {
+	return fmt.Sprintf("invalid coin type %d: BIP44 coin types are hardened and must be less than 2^31", e.CoinType)
+}
+
+// Build the BIP44 path used to derive a network key
+// m/44'/{coinType}'/{account}'/{change}'/{index}
+func networkPath(coinType, account, change, index uint32) Path {
+	return Path{purpose, coinType | firstHardened, account | firstHardened, change | firstHardened, index}
+}
+
+// Get the path and curve that would be used to derive a network key, without any key material
+// This mirrors the derivation done by DeriveNetworkKey, so it can be used to confirm
+// a derivation scheme without handling the mnemonic or seed. account is the account level
+// of the path: use GenSpec.NetworkAccount for the keys of a sleeve, which are under account 0'
+// unless GenSpec.AccountInNetworkPaths is set
+func PreviewDerivation(coinType, account, change, index uint32) (path string, curve string) {
+	return networkPath(coinType, account, change, index).String(), CurveForCoinType(coinType).String()
 }
 
 // SingleSeedSleeve represents a Sleeve wallet using single seed generation
 type SingleSeedSleeve struct {
 	// Input mnemonic: the single phrase users need to backup
+	// Empty if the sleeve was created directly from a seed
 	mnemonic string
+	// BIP32 master seed derived from the mnemonic and passphrase
+	seed []byte
+	// Hash of the seed, kept after Wipe, to check seeds passed to derivation methods
+	seedHash []byte
+	// Whether a non-empty passphrase was used (the passphrase itself is never stored)
+	hasPassphrase bool
+	// Generation spec used to create the sleeve
+	spec GenSpec
 	// WOTS+ keypair for quantum security
 	wotsKey *wots.Key
+	// BIP32 key and chain code of the quantum path node the WOTS+ key is generated from
+	wotsSeed []byte
+	wotsCode []byte
 	// WOTS+ public key (cached)
 	wotsPK []byte
 	// Derivation index calculated from WOTS public key
 	derivationIndex uint32
+	// Whether derivationIndex was computed, since 0 is a valid index
+	indexComputed bool
 	// Derived network keys
 	networkKeys map[string]*NetworkKey
+	// Names of the networks derived automatically as standard networks
+	standardNetworks map[string]bool
+	// Errors of the standard networks that couldn't be derived, by name
+	standardErrors map[string]error
+	// Optional store tracking use of the one-time WOTS+ key, consulted by Sign
+	wotsStore WOTSStateStore
+	// External signers used instead of local keys, by network name
+	externalSigners map[string]Signer
+	// Index of the next address returned by NextAddress, by network name
+	nextIndex map[string]uint32
+	// Whether the sleeve was imported with ImportPublicOnly, without seed or private keys
+	watchOnly bool
 }
 
 ///////////////////////////////////////////////////////////////////////
@@ -274,27 +605,48 @@ type SingleSeedSleeve struct {
 
 // Create a single-seed sleeve reading entropy from the provided CSPRNG
 func NewSingleSeedSleeve(csprng io.Reader, passphrase string, spec GenSpec) (*SingleSeedSleeve, error) {
-	// 1. Read EntropySize bytes of entropy from csprng
-	ent := make([]byte, EntropySize)
-	if n, err := csprng.Read(ent); n != EntropySize || err != nil {
-		return nil, errors.New("couldn't read enough bytes of entropy from provided reader")
-	}
+	return NewSingleSeedSleeveWithOptions(csprng, WithPassphrase(passphrase), withGenSpec(spec))
+}
 
-	// 2. Get sleeve from entropy
-	return NewSingleSeedSleeveFromEntropy(ent, passphrase, spec)
+// Create a single-seed sleeve with only the WOTS+ side, reading entropy from the provided CSPRNG
+// The mnemonic, WOTS+ key and derivation index are computed, but no network keys are derived,
+// for protocols that only need the post-quantum identity. Networks can still be derived later,
+// e.g., with DeriveNetworkKey or DeriveStandardNetworks
+func NewWOTSOnlySleeve(csprng io.Reader, passphrase string, spec GenSpec) (*SingleSeedSleeve, error) {
+	return NewSingleSeedSleeveWithOptions(csprng, WithPassphrase(passphrase), withGenSpec(spec), WithLazyDerivation())
+}
+
+// Read bits of entropy from the provided CSPRNG and return its BIP39 mnemonic, without creating a sleeve
+// UIs can show and confirm the mnemonic before the expensive WOTS+ generation, done afterwards
+// with NewSingleSeedSleeveFromMnemonic. Bits must be a BIP39 size: 128, 160, 192, 224 or 256,
+// but sleeves require EntropySize*8 bits, i.e., MnemonicWords words. Errors reading the CSPRNG
+// are returned, and the entropy goes through the same health check as NewSingleSeedSleeve
+func MnemonicFromReader(csprng io.Reader, bits int) (string, error) {
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", fmt.Errorf("%w: got %d bits, expected 128, 160, 192, 224 or 256", ErrInvalidBIP39Entropy, bits)
+	}
+	ent := make([]byte, bits/8)
+	defer zero(ent)
+	if _, err := io.ReadFull(csprng, ent); err != nil {
+		return "", fmt.Errorf("couldn't read enough bytes of entropy from provided reader: %w", err)
+	}
+	if err := checkEntropyHealth(ent); err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(ent)
 }
 
 // Create a single-seed sleeve with provided entropy
 func NewSingleSeedSleeveFromEntropy(ent []byte, passphrase string, spec GenSpec) (*SingleSeedSleeve, error) {
-	// 1. Generate BIP39 mnemonic from entropy
-	mnem, err := bip39.NewMnemonic(ent)
-	if err != nil {
+	// 1. Validate entropy is valid for BIP39 and has Sleeve required size of EntropySize
+	if err := checkEntropySize(ent); err != nil {
 		return nil, err
 	}
 
-	// 2. Validate entropy has required size
-	if len(ent) != EntropySize {
-		return nil, errors.New("provided entropy is of incorrect size")
+	// 2. Generate BIP39 mnemonic from entropy
+	mnem, err := bip39.NewMnemonic(ent)
+	if err != nil {
+		return nil, err
 	}
 
 	// 3. Get Sleeve from mnemonic
@@ -302,15 +654,168 @@ func NewSingleSeedSleeveFromEntropy(ent []byte, passphrase string, spec GenSpec)
 }
 
 // Create a single-seed sleeve with provided mnemonic and passphrase
+// As in BIP39, an empty passphrase means no passphrase
 func NewSingleSeedSleeveFromMnemonic(mnemonic, passphrase string, spec GenSpec) (*SingleSeedSleeve, error) {
-	// 1. Validate mnemonic has MnemonicWords words
+	// 1. Validate mnemonic has MnemonicWords words, and canonicalize it
+	mnemonic, err := canonicalMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Generate single-seed sleeve
+	return generateSingleSeedSleeveFromMnemonic(mnemonic, passphrase, spec, true)
+}
+
+// Create a single-seed sleeve with provided mnemonic and passphrase, WITHOUT checking the BIP39 checksum
+// UNSAFE: for recovery of legacy wallets whose mnemonics have invalid checksums only.
+// Without the checksum, a mistyped or swapped word silently recovers a different wallet.
+// The mnemonic must still have MnemonicWords words from the BIP39 English wordlist.
+// Use NewSingleSeedSleeveFromMnemonic for anything else
+func NewSingleSeedSleeveFromMnemonicUnchecked(mnemonic, passphrase string, spec GenSpec) (*SingleSeedSleeve, error) {
+	// 1. Validate mnemonic has MnemonicWords words, and canonicalize it
+	mnemonic, err := canonicalMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validate the words, as bip39.NewSeed hashes any string
+	for i, word := range strings.Fields(mnemonic) {
+		if _, ok := bip39.GetWordIndex(word); !ok {
+			return nil, fmt.Errorf("mnemonic word %d isn't a BIP39 word", i+1)
+		}
+	}
+
+	// 3. Generate single-seed sleeve
+	return generateSingleSeedSleeveFromMnemonic(mnemonic, passphrase, spec, false)
+}
+
+// Check a mnemonic has MnemonicWords words, and canonicalize it: single spaces between words, lowercase
+// Stray whitespace or capitals would otherwise fail validation, or change the seed
+func canonicalMnemonic(mnemonic string) (string, error) {
 	words := strings.Fields(mnemonic)
 	if len(words) != MnemonicWords {
-		return nil, errors.New("mnemonic has invalid number of words")
+		return "", errors.New("mnemonic has invalid number of words")
+	}
+	return strings.ToLower(strings.Join(words, " ")), nil
+}
+
+// Create a single-seed sleeve directly from a BIP32 master seed, skipping BIP39
+// Useful for interoperability with tools that hold a seed rather than a mnemonic
+// Seed must have SeedSize bytes. The resulting sleeve has an empty mnemonic
+func NewSingleSeedSleeveFromSeed(seed []byte, spec GenSpec) (*SingleSeedSleeve, error) {
+	// 1. Validate seed has required size
+	if len(seed) != SeedSize {
+		return nil, fmt.Errorf("provided seed is of incorrect size: got %d, expected %d", len(seed), SeedSize)
 	}
 
 	// 2. Generate single-seed sleeve
-	return generateSingleSeedSleeveFromMnemonic(mnemonic, passphrase, spec)
+	return generateSingleSeedSleeveFromSeed("", seed, spec)
+}
+
+// Create a new single-seed sleeve for a different account, from this sleeve's seed
+// The WOTS+ key and standard networks are derived again for the new account, while
+// the WOTS+ params, mnemonic and passphrase are kept. Networks derived by the user
+// aren't carried over. Fails if the sleeve was wiped
+func (s *SingleSeedSleeve) WithAccount(account uint32) (*SingleSeedSleeve, error) {
+	if account >= firstHardened {
+		return nil, fmt.Errorf("invalid account %d: must be less than 2^31", account)
+	}
+	if s.seed == nil {
+		return nil, errors.New("sleeve seed is not available - was the sleeve wiped?")
+	}
+	spec := s.spec
+	spec.account = account
+	sleeve, err := generateSingleSeedSleeveFromSeed(s.mnemonic, s.seed, spec)
+	if err != nil {
+		return nil, err
+	}
+	sleeve.hasPassphrase = s.hasPassphrase
+	sleeve.wotsStore = s.wotsStore
+	return sleeve, nil
+}
+
+// Create a new single-seed sleeve with a different WOTS+ security level, from this sleeve's seed
+// The WOTS+ key, derivation index and standard networks are derived again, while the account,
+// mnemonic and passphrase are kept. The level is part of the quantum path, so the WOTS+ public
+// key changes, and with it the derivation index and every network address. Networks derived by
+// the user aren't carried over. Fails for an unknown level, or if the sleeve was wiped
+func (s *SingleSeedSleeve) WithWOTSLevel(level wots.ParamsEncoding) (*SingleSeedSleeve, error) {
+	if wots.DecodeParams(level) == nil {
+		return nil, fmt.Errorf("unknown WOTS+ params encoding %d", level)
+	}
+	if s.seed == nil {
+		return nil, errors.New("sleeve seed is not available - was the sleeve wiped?")
+	}
+	spec := s.spec
+	spec.params = level
+	sleeve, err := generateSingleSeedSleeveFromSeed(s.mnemonic, s.seed, spec)
+	if err != nil {
+		return nil, err
+	}
+	sleeve.hasPassphrase = s.hasPassphrase
+	sleeve.wotsStore = s.wotsStore
+	return sleeve, nil
+}
+
+// Generate total single-seed sleeves with no passphrase, one after the other
+// onProgress, if not nil, is called after each sleeve with the number generated so far,
+// e.g., to render a progress bar. Returns the first error encountered
+func GenerateWalletsWithProgress(total uint32, spec GenSpec, onProgress func(done, total uint32)) ([]*SingleSeedSleeve, error) {
+	sleeves := make([]*SingleSeedSleeve, total)
+	for i := uint32(0); i < total; i++ {
+		var err error
+		sleeves[i], err = NewSingleSeedSleeve(rand.Reader, "", spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate wallet %d: %v", i, err)
+		}
+		if onProgress != nil {
+			onProgress(i+1, total)
+		}
+	}
+	return sleeves, nil
+}
+
+// Generate count single-seed sleeves with no passphrase, using a pool of workers
+// Each sleeve reads its own entropy from crypto/rand, and the output order is stable:
+// the i-th sleeve is the i-th generation job. Returns the first error encountered
+func GenerateWalletsParallel(count uint32, spec GenSpec, workers int) ([]*SingleSeedSleeve, error) {
+	if workers < 1 {
+		return nil, fmt.Errorf("invalid number of workers: %d", workers)
+	}
+
+	sleeves := make([]*SingleSeedSleeve, count)
+	errs := make([]error, count)
+	jobs := make(chan uint32)
+	var wg sync.WaitGroup
+	var failed int32
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// Skip remaining jobs once a generation failed
+				if atomic.LoadInt32(&failed) != 0 {
+					continue
+				}
+				sleeves[i], errs[i] = NewSingleSeedSleeve(rand.Reader, "", spec)
+				if errs[i] != nil {
+					atomic.StoreInt32(&failed, 1)
+				}
+			}
+		}()
+	}
+	for i := uint32(0); i < count; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate wallet %d: %v", i, err)
+		}
+	}
+	return sleeves, nil
 }
 
 ///////////////////////////////////////////////////////////////////////
@@ -321,23 +826,195 @@ func (s *SingleSeedSleeve) GetMnemonic() string {
 	return s.mnemonic
 }
 
+// Check a mnemonic entered by the user is the sleeve's mnemonic, in constant time
+// Whitespace between words and case are normalized, as done by NewSingleSeedSleeveFromMnemonic,
+// so this is safer than comparing GetMnemonic() with == in UIs. Always false if the sleeve has no mnemonic
+func (s *SingleSeedSleeve) ConfirmMnemonic(input string) bool {
+	if s.mnemonic == "" {
+		return false
+	}
+	normalized := strings.ToLower(strings.Join(strings.Fields(input), " "))
+	return subtle.ConstantTimeCompare([]byte(normalized), []byte(s.mnemonic)) == 1
+}
+
+// Get the last word of the mnemonic, which includes the BIP39 checksum
+// Onboarding flows can ask users to re-enter it, to confirm they wrote the phrase down.
+// Empty if the sleeve has no mnemonic, i.e., it was created from a seed or wiped
+func (s *SingleSeedSleeve) GetMnemonicChecksumWord() string {
+	words := strings.Fields(s.mnemonic)
+	if len(words) == 0 {
+		return ""
+	}
+	return words[len(words)-1]
+}
+
+// Check a word entered by the user is the last word of the mnemonic, in constant time
+// Surrounding spaces and case are ignored. Always false if the sleeve has no mnemonic
+func (s *SingleSeedSleeve) VerifyLastWord(word string) bool {
+	expected := s.GetMnemonicChecksumWord()
+	if expected == "" {
+		return false
+	}
+	word = strings.ToLower(strings.TrimSpace(word))
+	return subtle.ConstantTimeCompare([]byte(word), []byte(expected)) == 1
+}
+
+// Check if a non-empty passphrase was used to create the sleeve
+// If so, the passphrase is required, together with the mnemonic, for recovery
+// Always false for sleeves created directly from a seed
+func (s *SingleSeedSleeve) HasPassphrase() bool {
+	return s.hasPassphrase
+}
+
+// Get a copy of the BIP32 master seed used to derive all keys
+// UNSAFE: the seed gives full control over every derived key, handle with care
+func (s *SingleSeedSleeve) GetSeedUnsafe() []byte {
+	seed := make([]byte, len(s.seed))
+	copy(seed, s.seed)
+	return seed
+}
+
 // Get the WOTS+ public key (quantum-secure address)
 func (s *SingleSeedSleeve) GetWOTSPublicKey() []byte {
 	return s.wotsPK
 }
 
+// Get the WOTS+ public key hex encoded
+func (s *SingleSeedSleeve) GetWOTSPublicKeyHex() string {
+	return hex.EncodeToString(s.wotsPK)
+}
+
+// Get the WOTS+ public key standard base64 encoded
+func (s *SingleSeedSleeve) GetWOTSPublicKeyBase64() string {
+	return base64.StdEncoding.EncodeToString(s.wotsPK)
+}
+
+// Get the hex encoded first 4 bytes of SHA3_256(WOTS_PK), the same as Fingerprint
+func (s *SingleSeedSleeve) GetWOTSPublicKeyFingerprint() string {
+	return s.Fingerprint()
+}
+
+// Get the WOTS+ params encoding used to generate the sleeve
+func (s *SingleSeedSleeve) GetWOTSParams() wots.ParamsEncoding {
+	return s.spec.params
+}
+
 // Get the derivation index calculated from WOTS public key
+// The index can legitimately be 0, use HasDerivationIndex to check it was computed
+// It has the hardened bit set for sleeves generated with IndexHardened
 func (s *SingleSeedSleeve) GetDerivationIndex() uint32 {
 	return s.derivationIndex
 }
 
+// Check whether the derivation index was computed from the WOTS+ public key
+// This is true for all sleeves created by the constructors, even if the index is 0
+func (s *SingleSeedSleeve) HasDerivationIndex() bool {
+	return s.indexComputed
+}
+
 // Get a private key for a specific network by name
+// The returned slice is a copy owned by the caller, who should zero it after use
 func (s *SingleSeedSleeve) GetPrivateKey(network string) ([]byte, error) {
+	if s.watchOnly {
+		return nil, ErrWatchOnly
+	}
 	key, exists := s.networkKeys[network]
 	if !exists {
 		return nil, fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
 	}
-	return key.Key, nil
+	privKey := make([]byte, len(key.Key))
+	copy(privKey, key.Key)
+	return privKey, nil
+}
+
+// Copy the private key for a specific network into dst, without allocating
+// dst must have the size of the key, so callers can manage and wipe the buffer themselves
+func (s *SingleSeedSleeve) GetPrivateKeyInto(network string, dst []byte) error {
+	if s.watchOnly {
+		return ErrWatchOnly
+	}
+	key, exists := s.networkKeys[network]
+	if !exists {
+		return fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	if len(dst) != len(key.Key) {
+		return fmt.Errorf("destination has size %d, expected %d", len(dst), len(key.Key))
+	}
+	copy(dst, key.Key)
+	return nil
+}
+
+// Get the private key and BIP32 chain code for a specific network by name
+// Together they allow deriving non-hardened children of the key externally
+// Returned slices are copies, so they aren't affected by Wipe
+func (s *SingleSeedSleeve) GetExtendedKey(network string) (privKey, chainCode []byte, err error) {
+	if s.watchOnly {
+		return nil, nil, ErrWatchOnly
+	}
+	key, exists := s.networkKeys[network]
+	if !exists {
+		return nil, nil, fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	return append([]byte{}, key.Key...), append([]byte{}, key.code...), nil
+}
+
+// Zeroize all secret material held by the sleeve: the seed, WOTS+ secret key,
+// and every network private key and chain code. Network keys are removed and
+// the mnemonic is cleared, so the sleeve can't be used to derive keys afterwards.
+// Public data, like the WOTS+ public key and derivation index, is kept
+func (s *SingleSeedSleeve) Wipe() {
+	zero(s.seed)
+	s.seed = nil
+	s.mnemonic = ""
+	if s.wotsKey != nil {
+		s.wotsKey.Wipe()
+	}
+	zero(s.wotsSeed)
+	zero(s.wotsCode)
+	s.wotsSeed, s.wotsCode = nil, nil
+	for name, netKey := range s.networkKeys {
+		zero(netKey.Key)
+		zero(netKey.code)
+		if netKey.parentNode != nil {
+			zero(netKey.parentNode.Key)
+			zero(netKey.parentNode.Code)
+		}
+		delete(s.networkKeys, name)
+	}
+	for name := range s.standardNetworks {
+		delete(s.standardNetworks, name)
+	}
+}
+
+// Set all bytes of b to zero
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// Get the full BIP32 derivation path used for a network, including the account
+// This is the path to verify derivation against, e.g. in a hardware wallet
+func (s *SingleSeedSleeve) GetFullPath(network string) (string, error) {
+	key, exists := s.networkKeys[network]
+	if !exists {
+		return "", fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	return key.path.String(), nil
+}
+
+// Check whether derivation parameters map to the path of an already derived network key,
+// returning the name it's stored under, so UIs can warn before deriving a duplicate under another name
+// The parameters are those of PreviewDerivation: index is the full address level element, e.g.
+// GetDerivationIndex() for the sleeve's own network keys. Nothing is derived or stored
+func (s *SingleSeedSleeve) WouldCollide(coinType, account, change, index uint32) (existing string, collides bool) {
+	path := networkPath(coinType, account, change, index)
+	for _, name := range s.GetNetworkNames() {
+		if s.networkKeys[name].path.Equal(path) {
+			return name, true
+		}
+	}
+	return "", false
 }
 
 // Get all derived network keys
@@ -345,19 +1022,650 @@ func (s *SingleSeedSleeve) GetAllNetworkKeys() map[string]*NetworkKey {
 	return s.networkKeys
 }
 
+// Get a short fingerprint identifying the wallet
+// The fingerprint is the hex encoding of the first 4 bytes of SHA3_256(WOTS_PK)
+// and is safe to share, since it is derived only from public data. Unlike DescriptorChecksum,
+// it only covers the WOTS+ public key of CanonicalBytes, so it doesn't change as networks are derived
+func (s *SingleSeedSleeve) Fingerprint() string {
+	return hex.EncodeToString(hasher.SHA3_256.Hash(s.wotsPK)[:4])
+}
+
+// Number of colors in the identicon palette, cells hold indexes 0 to IdenticonColors-1
+const IdenticonColors = 16
+
+// Domain separation prefix for the identicon
+const identiconPrefix = "xx network sleeve identicon"
+
+// Get an 8x8 grid of palette indexes identifying the wallet, derived only from
+// its fingerprint, so UIs can render the same icon for the same wallet
+// Rows are mirrored left to right, which makes icons easier to recognize
+func (s *SingleSeedSleeve) Identicon() [8][8]byte {
+	h := hasher.SHA3_256.New()
+	h.Write([]byte(identiconPrefix))
+	h.Write([]byte(s.Fingerprint()))
+	sum := h.Sum(nil)
+
+	// One nibble of the hash per cell of the left half
+	var grid [8][8]byte
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 4; col++ {
+			b := sum[row*2+col/2]
+			if col%2 == 0 {
+				b >>= 4
+			}
+			grid[row][col] = b % IdenticonColors
+			grid[row][7-col] = grid[row][col]
+		}
+	}
+	return grid
+}
+
+// Get a short check value of the passphrase used to create the sleeve
+// The WOTS+ public key depends on the passphrase, so this value changes whenever
+// the passphrase does. Storing it lets users confirm they re-entered the same
+// passphrase on recovery, without revealing anything about the passphrase itself
+func (s *SingleSeedSleeve) PassphraseCheck() string {
+	return hex.EncodeToString(hasher.SHA3_256.Hash(append([]byte(passphraseCheckPrefix), s.wotsPK...))[:4])
+}
+
+// Get a checksum over the wallet descriptor: account, params, WOTS+ public key,
+// derivation index and every derived network (name, coin type and path)
+// The checksum is the hex encoding of the first 4 bytes of SHA3_256 over CanonicalBytes,
+// and changes whenever any of those values change.
+// Users can store it alongside the mnemonic to confirm a recovered wallet matches
+func (s *SingleSeedSleeve) DescriptorChecksum() string {
+	return hex.EncodeToString(hasher.SHA3_256.Hash(s.CanonicalBytes())[:4])
+}
+
+// Get the names of all derived networks, sorted
+// Cheaper than GetAllNetworkKeys when only the names are needed, e.g., to list networks in a UI
+func (s *SingleSeedSleeve) GetNetworkNames() []string {
+	names := make([]string, 0, len(s.networkKeys))
+	for name := range s.networkKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Write data to w prefixed by its 4 byte big endian length
+func writeLenPrefixed(w io.Writer, data []byte) {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, uint32(len(data)))
+	w.Write(buf)
+	w.Write(data)
+}
+
+// Get a non-secret summary of the sleeve, for logging and debugging
+// The mnemonic and private keys are never included
+func (s *SingleSeedSleeve) String() string {
+	names := s.GetNetworkNames()
+
+	pkHex := s.GetWOTSPublicKeyHex()
+	if len(pkHex) > 16 {
+		pkHex = pkHex[:16] + "..."
+	}
+
+	str := fmt.Sprintf("fingerprint: %s\n", s.Fingerprint())
+	str += fmt.Sprintf("WOTS+ params: %s\n", s.spec.params)
+	str += fmt.Sprintf("WOTS+ public key: %s\n", pkHex)
+	str += fmt.Sprintf("WOTS-derived index: %d\n", s.derivationIndex)
+	str += fmt.Sprintf("networks: %s", strings.Join(names, ", "))
+	return str
+}
+
+// Get the number of network keys that were auto-derived as standard networks
+func (s *SingleSeedSleeve) StandardNetworkCount() int {
+	return len(s.standardNetworks)
+}
+
+// Get only the network keys that were manually derived by the user,
+// i.e., excluding the auto-derived standard networks
+func (s *SingleSeedSleeve) GetUserNetworkKeys() map[string]*NetworkKey {
+	keys := make(map[string]*NetworkKey)
+	for name, key := range s.networkKeys {
+		if !s.standardNetworks[name] {
+			keys[name] = key
+		}
+	}
+	return keys
+}
+
 // Get the WOTS+ key for signing (if needed in future)
 func (s *SingleSeedSleeve) GetWOTSKey() *wots.Key {
 	return s.wotsKey
 }
 
+// Get copies of the seed and chain code the WOTS+ key is generated from
+// wots.NewKeyFromSeed(params, seed, code) recomputes the WOTS+ key, to audit the public key
+// without reimplementing the quantum path. Network keys can't be derived from these
+func (s *SingleSeedSleeve) GetWOTSMaterial() (seed, code []byte) {
+	if s.wotsSeed == nil {
+		return nil, nil
+	}
+	seed = make([]byte, len(s.wotsSeed))
+	code = make([]byte, len(s.wotsCode))
+	copy(seed, s.wotsSeed)
+	copy(code, s.wotsCode)
+	return seed, code
+}
+
+// Set the store Sign uses to enforce one-time use of the WOTS+ key
+// Sleeves built from the same mnemonic and spec should share a store
+func (s *SingleSeedSleeve) SetWOTSStateStore(store WOTSStateStore) {
+	s.wotsStore = store
+}
+
+// Sign a message with the one-time WOTS+ key
+// If a WOTSStateStore is set, the key is reserved in it first, and
+// signing fails if it was already used. Without a store, use isn't tracked.
+// Returns ErrWiped once the sleeve was wiped, without reserving the key
+func (s *SingleSeedSleeve) Sign(msg []byte) ([]byte, error) {
+	if s.watchOnly {
+		return nil, ErrWatchOnly
+	}
+	if s.wotsSeed == nil {
+		return nil, ErrWiped
+	}
+	if s.wotsStore != nil {
+		if err := s.wotsStore.Reserve(s.wotsPK, s.derivationIndex); err != nil {
+			return nil, err
+		}
+	}
+	return s.wotsKey.Sign(msg), nil
+}
+
+///////////////////////////////////////////////////////////////////////
+// SYMMETRIC KEY DERIVATION
+
+// Maximum output length of HKDF-SHA256
+const maxSymmetricKeyLen = 255 * sha256.Size
+
+// Derive a symmetric key of the given length for an application context
+// Uses HKDF-SHA256 with the seed as input key material and the context as info,
+// so different contexts give independent keys and the same context reproduces the key
+func (s *SingleSeedSleeve) DeriveSymmetricKey(context string, length int, seed []byte) ([]byte, error) {
+	if context == "" {
+		return nil, errors.New("context can't be empty")
+	}
+	if length <= 0 || length > maxSymmetricKeyLen {
+		return nil, fmt.Errorf("invalid key length %d: must be between 1 and %d", length, maxSymmetricKeyLen)
+	}
+	if len(seed) == 0 {
+		return nil, errors.New("seed can't be empty")
+	}
+	if err := s.checkSeed(seed); err != nil {
+		return nil, err
+	}
+	key := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, seed, nil, []byte(context)), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
 ///////////////////////////////////////////////////////////////////////
 // NETWORK KEY DERIVATION
 
 // Derive a key for a specific network using its coin type
+// Returns an error if network is a standard network name used with a different coin type,
+// use ReplaceNetworkKey to intentionally override a standard network
 func (s *SingleSeedSleeve) DeriveNetworkKey(network string, coinType uint32, seed []byte) error {
-	// Derive to m/44'/{coinType}'/0'/0 using manual BIP32 derivation
+	if expected, ok := s.standardCoinType(network); ok && expected != coinType {
+		return fmt.Errorf("network %s is a standard network with coin type %d, got coin type %d - "+
+			"use ReplaceNetworkKey to override it", network, expected, coinType)
+	}
+	return s.deriveNetworkKey(network, network, coinType, s.networkAccount(), 0, false, seed)
+}
+
+// Derive a key for a Bitcoin family network, encoded with the given address params
+// GetAddress and GetWIF use the params instead of the defaults of the coin type,
+// so forks that only differ in version bytes or HRP are supported without a new encoder
+func (s *SingleSeedSleeve) DeriveNetworkKeyWithParams(network string, coinType uint32, params AddressParams,
+	seed []byte) error {
+	if err := params.validate(); err != nil {
+		return err
+	}
+	if err := s.DeriveNetworkKey(network, coinType, seed); err != nil {
+		return err
+	}
+	s.networkKeys[network].AddressParams = &params
+	return nil
+}
+
+// Derive the key for an address index of a network, under the sleeve's account
+// The address level of the path is the WOTS-derived index plus addressIndex (mod 2^31),
+// hardened if hardenedAddressIndex is set, so address 0 non-hardened is the key from DeriveNetworkKey.
+// That key is stored under the network name, while other address keys are stored
+// under "network#addressIndex", with a trailing "'" when hardened, e.g., "Ethereum#2'"
+func (s *SingleSeedSleeve) DeriveAddressKey(network string, coinType, addressIndex uint32,
+	hardenedAddressIndex bool, seed []byte) (*NetworkKey, error) {
+	if expected, ok := s.standardCoinType(network); ok && expected != coinType {
+		return nil, fmt.Errorf("network %s is a standard network with coin type %d, got coin type %d - "+
+			"use ReplaceNetworkKey to override it", network, expected, coinType)
+	}
+	name := addressKeyName(network, addressIndex, hardenedAddressIndex)
+	err := s.deriveNetworkKey(name, network, coinType, s.networkAccount(), addressIndex, hardenedAddressIndex, seed)
+	if err != nil {
+		return nil, err
+	}
+	return s.networkKeys[name], nil
+}
+
+// Get the next unused address of a network and its address index, then advance the network's counter
+// Counters start at 0, the address of the network key itself, and count addresses after the
+// network key's own address level, which keeps its hardening. They're saved in the descriptor
+// and restored by ImportDescriptor, so addresses aren't reused across sessions.
+// Fails if the sleeve was wiped or the network's address format isn't supported
+func (s *SingleSeedSleeve) NextAddress(network string) (index uint32, address string, err error) {
+	if s.watchOnly {
+		return 0, "", ErrWatchOnly
+	}
+	netKey, exists := s.networkKeys[network]
+	if !exists {
+		return 0, "", fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	if s.seed == nil {
+		return 0, "", errors.New("sleeve seed is not available - was the sleeve wiped?")
+	}
+	index = s.nextIndex[network]
+	if index >= firstHardened {
+		return 0, "", fmt.Errorf("network %s has no more addresses: address index must be less than 2^31", network)
+	}
+
+	// Replace the address level of the network key's path
+	path := append(Path{}, netKey.path...)
+	last := len(path) - 1
+	child := (path[last] + index) &^ firstHardened
+	if path[last] >= firstHardened {
+		child |= firstHardened
+	}
+	path[last] = child
+	node, err := deriveNetworkNode(s.seed, netKey.CoinType, path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer zero(node.Key)
+
+	addrKey := &NetworkKey{
+		Network:       network,
+		CoinType:      netKey.CoinType,
+		Curve:         netKey.Curve,
+		Key:           node.Key,
+		AddressParams: netKey.AddressParams,
+	}
+	if address, err = networkAddress(addrKey); err != nil {
+		return 0, "", err
+	}
+	if address == "" {
+		return 0, "", fmt.Errorf("address format for coin type %d isn't supported", netKey.CoinType)
+	}
+	s.setNextAddressIndex(network, index+1)
+	return index, address, nil
+}
+
+// Set the index of the next address returned by NextAddress for a network
+func (s *SingleSeedSleeve) setNextAddressIndex(network string, index uint32) {
+	if s.nextIndex == nil {
+		s.nextIndex = make(map[string]uint32)
+	}
+	s.nextIndex[network] = index
+}
+
+// Get the name the key for an address index of a network is stored under
+func addressKeyName(network string, addressIndex uint32, hardened bool) string {
+	if addressIndex == 0 && !hardened {
+		return network
+	}
+	if hardened {
+		return fmt.Sprintf("%s#%d'", network, addressIndex)
+	}
+	return fmt.Sprintf("%s#%d", network, addressIndex)
+}
+
+// Get the extended public key (xpub) of the change level node of a network
+// Address keys of the network are its non-hardened children, at the WOTS-derived
+// index plus the address index, so watch-only wallets can derive their public keys.
+// Returns an error for networks derived with a hardened address index, e.g., fully hardened
+// networks, since those keys can't be derived from an xpub, and for networks not on secp256k1
+func (s *SingleSeedSleeve) GetExtendedPublicKey(network string) (string, error) {
+	netKey, exists := s.networkKeys[network]
+	if !exists {
+		return "", fmt.Errorf("network %s not found - call DeriveNetworkKey first", network)
+	}
+	// Watch-only keys have no chain code
+	if s.watchOnly {
+		return "", ErrWatchOnly
+	}
+	if netKey.HardenedAddressIndex {
+		return "", fmt.Errorf("network %s uses a hardened address index, "+
+			"which can't be derived from an extended public key", network)
+	}
+	if netKey.Curve != CurveSecp256k1 {
+		return "", fmt.Errorf("network %s uses %s, extended public keys are only supported for secp256k1",
+			network, netKey.Curve)
+	}
+	depth := len(netKey.path) - 1
+	childNumber := uint32(0)
+	if depth > 0 {
+		childNumber = netKey.path[depth-1]
+	}
+	return netKey.parentNode.ExtendedPublicKey(uint8(depth), netKey.parentNodeFingerprint, childNumber)
+}
+
+// Export the extended public keys (xpubs) of several coin types, to watch them without private keys,
+// e.g., to hand an accountant a bundle covering all chains of the wallet
+// Each xpub is that of the change level node m/44'/coin'/account'/0' under the sleeve's account,
+// as returned by GetExtendedPublicKey, with its key origin: "[fingerprint/44'/coin'/account'/0']xpub...",
+// where fingerprint is the hex encoded master key fingerprint. Address keys are its non-hardened
+// children at GetDerivationIndex() plus the address index. Only secp256k1 coin types are supported,
+// and sleeves generated with IndexHardened have no xpubs, since their address keys are hardened
+func (s *SingleSeedSleeve) ExportAccountWatchBundle(coinTypes []uint32) (map[uint32]string, error) {
+	if s.seed == nil {
+		return nil, errors.New("sleeve was wiped, extended public keys can't be derived")
+	}
+	if s.spec.IndexHardened {
+		return nil, errors.New("sleeve uses hardened address indexes, " +
+			"which can't be derived from extended public keys")
+	}
+	master, err := NewMasterNode(s.seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create master node: %v", err)
+	}
+	masterFingerprint, err := master.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := make(map[uint32]string, len(coinTypes))
+	for _, coinType := range coinTypes {
+		if coinType >= firstHardened {
+			return nil, &InvalidCoinTypeError{CoinType: coinType}
+		}
+		if curve := CurveForCoinType(coinType); curve != CurveSecp256k1 {
+			return nil, fmt.Errorf("coin type %d uses %s, extended public keys are only supported for secp256k1",
+				coinType, curve)
+		}
+		path := networkPath(coinType, s.networkAccount(), 0, 0)
+		path = path[:len(path)-1]
+		accountNode, err := deriveNodeAtPath(s.seed, path[:len(path)-1])
+		if err != nil {
+			return nil, err
+		}
+		accountFingerprint, err := accountNode.Fingerprint()
+		if err != nil {
+			return nil, err
+		}
+		changeNode, err := accountNode.HardenedChild(path[len(path)-1])
+		if err != nil {
+			return nil, err
+		}
+		xpub, err := changeNode.ExtendedPublicKey(uint8(len(path)), accountFingerprint, path[len(path)-1])
+		if err != nil {
+			return nil, err
+		}
+		bundle[coinType] = fmt.Sprintf("[%x/%s]%s", masterFingerprint, strings.TrimPrefix(path.String(), "m/"), xpub)
+	}
+	return bundle, nil
+}
+
+// Derive a key for a network from a textual BIP32 path, e.g. m/44'/60'/0'/0/5
+// Hardened elements are marked with a trailing ', h or H, see ParsePath.
+// The key is stored under the network name, with the coin type and account
+// read from the path when it follows BIP44 (m/44'/coin'/account'/...).
+// Tezos and Near paths are derived with SLIP-0010, so they must be fully hardened,
+// e.g., m/44'/1729'/0'/0' as Tezos wallets use
+func (s *SingleSeedSleeve) DeriveFromPathString(network, pathStr string, seed []byte) error {
+	path, err := ParsePath(pathStr)
+	if err != nil {
+		return err
+	}
+	if len(path) > maxPathDepth {
+		return fmt.Errorf("path %q is too deep: %d elements, maximum %d", pathStr, len(path), maxPathDepth)
+	}
+
+	// Get coin type and account from BIP44 paths
+	var coinType, account uint32
+	if path[0] == purpose && len(path) > 1 {
+		coinType = path[1] &^ firstHardened
+		if len(path) > 2 {
+			account = path[2] &^ firstHardened
+		}
+	}
+	if expected, ok := s.standardCoinType(network); ok && expected != coinType {
+		return fmt.Errorf("network %s is a standard network with coin type %d, got coin type %d - "+
+			"use ReplaceNetworkKey to override it", network, expected, coinType)
+	}
+	if err := s.checkSeed(seed); err != nil {
+		return err
+	}
+
+	node, parent, parentFingerprint, err := walkNetworkPath(seed, coinType, path)
+	if err != nil {
+		return err
+	}
+
+	s.networkKeys[network] = &NetworkKey{
+		Network:  network,
+		CoinType: coinType,
+		Account:  account,
+		Curve:    CurveForCoinType(coinType),
+		Path:     path.String(),
+		Key:      node.Key,
+
+		HardenedAddressIndex: path[len(path)-1] >= firstHardened,
+
+		path:                  path,
+		code:                  node.Code,
+		parentNode:            parent,
+		parentNodeFingerprint: parentFingerprint,
+	}
+	return nil
+}
+
+// Derive the node at a path from a seed, with its parent node and the parent's parent
+// fingerprint needed for the extended public key
+func walkPath(seed []byte, path Path) (node, parent *Node, parentFingerprint []byte, err error) {
+	node, err = NewMasterNode(seed)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create master node: %v", err)
+	}
+	parent = node
+	parentFingerprint = make([]byte, 4)
+	for i, idx := range path {
+		if i > 0 {
+			if parentFingerprint, err = parent.Fingerprint(); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		parent = node
+		if idx >= firstHardened {
+			node, err = node.HardenedChild(idx)
+		} else {
+			node, err = node.Child(idx)
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to derive element %d of path %s: %v", i+1, path, err)
+		}
+	}
+	return node, parent, parentFingerprint, nil
+}
+
+// Derive a key for a specific network under the given BIP44 account
+// Keys for the sleeve's own account are stored under the network name, as with DeriveNetworkKey,
+// while keys for other accounts are stored under "network/account", e.g., "Ethereum/1",
+// so keys for the same network under different accounts can coexist.
+// All accounts use the index derived from the sleeve's WOTS+ key
+func (s *SingleSeedSleeve) DeriveNetworkKeyAt(network string, coinType, account uint32, seed []byte) error {
+	return s.DeriveNetworkKeyAtWithOptions(network, coinType, account, NetworkKeyOptions{}, seed)
+}
+
+// Options for DeriveNetworkKeyAtWithOptions
+type NetworkKeyOptions struct {
+	// Harden every level of the path, including change and address index,
+	// so no key of the network can be derived from an exported xpub, preventing
+	// xpub-based address linking. The change level of sleeve paths is always hardened,
+	// so this hardens the address level: m/44'/coin'/account'/0'/index'
+	FullyHardened bool
+	// Path layout to derive the key at, SchemeBIP44 (the sleeve's path) by default
+	Scheme DerivationScheme
+}
+
+// Derive a key for a specific network under the given BIP44 account, as DeriveNetworkKeyAt does
+// Fully hardened keys are stored under the name DeriveAddressKey uses for a hardened address 0,
+// "network#0'", followed by "/account" for other accounts, so they don't replace the standard key.
+// GetExtendedPublicKey refuses fully hardened networks, since they have no usable xpub.
+// Keys of other schemes are stored under "network@scheme", e.g., "Ethereum@LedgerLive",
+// followed by "/account" for other accounts. They can't be fully hardened
+func (s *SingleSeedSleeve) DeriveNetworkKeyAtWithOptions(network string, coinType, account uint32,
+	opts NetworkKeyOptions, seed []byte) error {
+	if account >= firstHardened {
+		return fmt.Errorf("invalid account %d: must be less than 2^31", account)
+	}
+	if expected, ok := s.standardCoinType(network); ok && expected != coinType {
+		return fmt.Errorf("network %s is a standard network with coin type %d, got coin type %d - "+
+			"use ReplaceNetworkKey to override it", network, expected, coinType)
+	}
+	if opts.Scheme != SchemeBIP44 {
+		if opts.FullyHardened {
+			return fmt.Errorf("%s keys can't be fully hardened, their path is fixed by the wallet", opts.Scheme)
+		}
+		return s.deriveSchemeKey(s.schemeKeyName(network, account, opts.Scheme), network, coinType, account,
+			opts.Scheme, seed)
+	}
+	name := network
+	// IndexHardened sleeves harden every address key, without a hardened name
+	if opts.FullyHardened && !s.spec.IndexHardened {
+		name = addressKeyName(network, 0, true)
+	}
+	if account != s.networkAccount() {
+		name = fmt.Sprintf("%s/%d", name, account)
+	}
+	return s.deriveNetworkKey(name, network, coinType, account, 0, opts.FullyHardened, seed)
+}
+
+// Get the name a key of a derivation scheme other than SchemeBIP44 is stored under
+func (s *SingleSeedSleeve) schemeKeyName(network string, account uint32, scheme DerivationScheme) string {
+	name := fmt.Sprintf("%s@%s", network, scheme)
+	if account != s.networkAccount() {
+		name = fmt.Sprintf("%s/%d", name, account)
+	}
+	return name
+}
+
+// Derive a key at the path of a derivation scheme other than SchemeBIP44, and store it under the given name
+func (s *SingleSeedSleeve) deriveSchemeKey(name, network string, coinType, account uint32,
+	scheme DerivationScheme, seed []byte) error {
+	if coinType >= firstHardened {
+		return &InvalidCoinTypeError{CoinType: coinType}
+	}
+	path, err := scheme.path(coinType, account)
+	if err != nil {
+		return err
+	}
+	if err := s.checkSeed(seed); err != nil {
+		return err
+	}
+	node, parent, parentFingerprint, err := walkNetworkPath(seed, coinType, path)
+	if err != nil {
+		return err
+	}
+	s.networkKeys[name] = &NetworkKey{
+		Network:  network,
+		CoinType: coinType,
+		Account:  account,
+		Curve:    CurveForCoinType(coinType),
+		Path:     path.String(),
+		Key:      node.Key,
+		Scheme:   scheme,
+
+		path:                  path,
+		code:                  node.Code,
+		parentNode:            parent,
+		parentNodeFingerprint: parentFingerprint,
+	}
+	return nil
+}
+
+// Get a private key for a specific network under the given BIP44 account
+func (s *SingleSeedSleeve) GetPrivateKeyAt(network string, account uint32) ([]byte, error) {
+	return s.GetPrivateKey(s.networkKeyName(network, account))
+}
+
+// Get the name a network key for the given account is stored under
+func (s *SingleSeedSleeve) networkKeyName(network string, account uint32) string {
+	if account == s.networkAccount() {
+		return network
+	}
+	return fmt.Sprintf("%s/%d", network, account)
+}
+
+// Derive a key for a network, replacing any existing entry with the same name
+// Unlike DeriveNetworkKey, this allows overriding a standard network with a different coin type
+func (s *SingleSeedSleeve) ReplaceNetworkKey(network string, coinType uint32, seed []byte) error {
+	if err := s.deriveNetworkKey(network, network, coinType, s.networkAccount(), 0, false, seed); err != nil {
+		return err
+	}
+	if expected, ok := s.standardCoinType(network); ok && expected != coinType {
+		delete(s.standardNetworks, network)
+	}
+	return nil
+}
+
+// Get the account level of the sleeve's own network keys, see GenSpec.NetworkAccount
+// Keys of other accounts are stored as "network/account"
+func (s *SingleSeedSleeve) networkAccount() uint32 {
+	return s.spec.NetworkAccount()
+}
+
+// Derive a key for a specific network, account and address index, and store it under the given name
+func (s *SingleSeedSleeve) deriveNetworkKey(name, network string, coinType, account, addressIndex uint32,
+	hardenedAddressIndex bool, seed []byte) error {
+	// Reject coin types that would overflow when hardened
+	if coinType >= firstHardened {
+		return &InvalidCoinTypeError{CoinType: coinType}
+	}
+	if !s.indexComputed {
+		return errNoDerivationIndex
+	}
+	if err := s.checkSeed(seed); err != nil {
+		return err
+	}
+	// Sleeves generated with IndexHardened only have hardened address keys,
+	// as do SLIP-0010 coin types, which have no non-hardened derivation
+	hardenedAddressIndex = hardenedAddressIndex || s.spec.IndexHardened || usesSLIP10(coinType)
+
+	// Derive to m/44'/{coinType}'/{account}'/0'/{index} using manual BIP32 derivation
 	// ComputeNode is designed for the quantum path (5 hardened elements)
-	// Network paths require 4 hardened + 1 non-hardened element
+	// Network paths require 4 hardened + 1 address level element, non-hardened by default
+	index := (s.derivationIndex + addressIndex) &^ firstHardened
+	if hardenedAddressIndex {
+		index |= firstHardened
+	}
+	path := networkPath(coinType, account, 0, index)
+
+	// SLIP-0010 keys have no extended public key, so only the key itself is kept
+	if usesSLIP10(coinType) {
+		finalNode, err := deriveEd25519NodeAtPath(seed, path)
+		if err != nil {
+			return fmt.Errorf("failed to derive SLIP-0010 key: %w", err)
+		}
+		s.networkKeys[name] = &NetworkKey{
+			Network:  network,
+			CoinType: coinType,
+			Account:  account,
+			Curve:    CurveForCoinType(coinType),
+			Path:     path.String(),
+			Key:      finalNode.Key,
+
+			AddressIndex:         addressIndex,
+			HardenedAddressIndex: hardenedAddressIndex,
+
+			path: path,
+			code: finalNode.Code,
+		}
+		return nil
+	}
 
 	// 1. Create master node
 	node, err := NewMasterNode(seed)
@@ -366,79 +1674,319 @@ func (s *SingleSeedSleeve) DeriveNetworkKey(network string, coinType uint32, see
 	}
 
 	// 2. Derive m/44'
-	err = node.ComputeHardenedChild(0x8000002C)
+	err = node.ComputeHardenedChild(path[0])
 	if err != nil {
 		return fmt.Errorf("failed to derive purpose: %v", err)
 	}
 
 	// 3. Derive m/44'/{coinType}'
-	err = node.ComputeHardenedChild(coinType | firstHardened)
+	err = node.ComputeHardenedChild(path[1])
 	if err != nil {
 		return fmt.Errorf("failed to derive coin type: %v", err)
 	}
 
-	// 4. Derive m/44'/{coinType}'/0'
-	err = node.ComputeHardenedChild(0x80000000)
+	// 4. Derive m/44'/{coinType}'/{account}'
+	err = node.ComputeHardenedChild(path[2])
 	if err != nil {
 		return fmt.Errorf("failed to derive account: %v", err)
 	}
 
-	// 5. Derive m/44'/{coinType}'/0'/0'
-	err = node.ComputeHardenedChild(0x80000000)
+	accountFingerprint, err := node.Fingerprint()
+	if err != nil {
+		return fmt.Errorf("failed to compute account fingerprint: %v", err)
+	}
+
+	// 5. Derive m/44'/{coinType}'/{account}'/0'
+	err = node.ComputeHardenedChild(path[3])
 	if err != nil {
 		return fmt.Errorf("failed to derive change: %v", err)
 	}
 
-	// 6. Extend with WOTS-derived index (non-hardened)
-	finalNode, err := node.Child(s.derivationIndex)
+	// 6. Extend with WOTS-derived index (non-hardened unless requested)
+	var finalNode *Node
+	if hardenedAddressIndex {
+		finalNode, err = node.HardenedChild(path[4])
+	} else {
+		finalNode, err = node.Child(path[4])
+	}
 	if err != nil {
-		return fmt.Errorf("failed to derive final key with WOTS index: %v", err)
+		return fmt.Errorf("failed to derive final key with WOTS index: %w", err)
 	}
 
 	// Store the network key
-	fullPath := fmt.Sprintf("m/44'/%d'/0'/0/%d", coinType, s.derivationIndex)
-	s.networkKeys[network] = &NetworkKey{
+	s.networkKeys[name] = &NetworkKey{
 		Network:  network,
 		CoinType: coinType,
-		Path:     fullPath,
+		Account:  account,
+		Curve:    CurveForCoinType(coinType),
+		Path:     path.String(),
 		Key:      finalNode.Key,
+
+		AddressIndex:         addressIndex,
+		HardenedAddressIndex: hardenedAddressIndex,
+
+		path:                  path,
+		code:                  finalNode.Code,
+		parentNode:            node,
+		parentNodeFingerprint: accountFingerprint,
 	}
 
 	return nil
 }
 
-// Derive keys for common networks (Bitcoin, Ethereum, Polkadot)
+// Copy the network keys of another sleeve of the same wallet into this sleeve
+// Fails if the sleeves have different WOTS+ public keys, i.e., are different wallets,
+// or if both have a network with the same name but a different key or path.
+// Nothing is copied if the merge fails
+func (s *SingleSeedSleeve) MergeNetworks(other *SingleSeedSleeve) error {
+	if !bytes.Equal(s.wotsPK, other.wotsPK) {
+		return errors.New("can't merge networks of a different wallet: WOTS+ public keys don't match")
+	}
+	for _, name := range other.GetNetworkNames() {
+		mine, exists := s.networkKeys[name]
+		if !exists {
+			continue
+		}
+		theirs := other.networkKeys[name]
+		if mine.Path != theirs.Path || !hmac.Equal(mine.Key, theirs.Key) {
+			return fmt.Errorf("can't merge network %s: keys don't match (%s and %s)", name, mine.Path, theirs.Path)
+		}
+	}
+	for name, netKey := range other.networkKeys {
+		if _, exists := s.networkKeys[name]; !exists {
+			s.networkKeys[name] = netKey.clone()
+		}
+	}
+	return nil
+}
+
+// Deep copy a network key, so wiping one sleeve doesn't affect another
+func (k *NetworkKey) clone() *NetworkKey {
+	c := *k
+	c.Key = append([]byte{}, k.Key...)
+	c.code = append([]byte{}, k.code...)
+	c.path = append(Path{}, k.path...)
+	c.parentNodeFingerprint = append([]byte{}, k.parentNodeFingerprint...)
+	if k.AddressParams != nil {
+		params := *k.AddressParams
+		c.AddressParams = &params
+	}
+	if k.parentNode != nil {
+		c.parentNode = &Node{Key: append([]byte{}, k.parentNode.Key...), Code: append([]byte{}, k.parentNode.Code...)}
+	}
+	return &c
+}
+
+// Derive a key for a specific network and return it
+// The key is stored as with DeriveNetworkKey, so GetPrivateKey returns it afterwards
+func (s *SingleSeedSleeve) DeriveAndGetKey(network string, coinType uint32, seed []byte) ([]byte, error) {
+	if err := s.DeriveNetworkKey(network, coinType, seed); err != nil {
+		return nil, err
+	}
+	return s.GetPrivateKey(network)
+}
+
+// StandardNetwork describes a network that is derived automatically
+type StandardNetwork struct {
+	Name     string
+	CoinType uint32
+}
+
+// Networks derived automatically when a single-seed sleeve is constructed
+var standardNetworks = []StandardNetwork{
+	{"Bitcoin", CoinTypeBitcoin},
+	{"Ethereum", CoinTypeEthereum},
+	{"Polkadot", CoinTypePolkadot},
+}
+
+// Get the coin type of one of the sleeve's standard networks by name
+func (s *SingleSeedSleeve) standardCoinType(network string) (uint32, bool) {
+	for _, net := range s.spec.standardNetworkList() {
+		if net.Name == network {
+			return net.CoinType, true
+		}
+	}
+	return 0, false
+}
+
+// Maximum number of address indexes tried for a standard network, when derived keys are invalid
+const maxStandardDerivationAttempts = 4
+
+// Derive keys for the standard networks: common networks (Bitcoin, Ethereum, Polkadot)
+// unless others were chosen with WithStandardNetworks
+// Each network is derived independently, so one failing doesn't prevent using the others.
+// Failures are reported by StandardDerivationErrors. Only a wrong seed returns an error
 func (s *SingleSeedSleeve) DeriveStandardNetworks(seed []byte) error {
-	networks := []struct {
-		name     string
-		coinType uint32
-	}{
-		{"Bitcoin", CoinTypeBitcoin},
-		{"Ethereum", CoinTypeEthereum},
-		{"Polkadot", CoinTypePolkadot},
+	if err := s.checkSeed(seed); err != nil {
+		return err
+	}
+	s.standardErrors = make(map[string]error)
+	for _, net := range s.spec.standardNetworkList() {
+		if err := s.deriveStandardNetwork(net, seed); err != nil {
+			s.standardErrors[net.Name] = fmt.Errorf("failed to derive %s key: %v", net.Name, err)
+			continue
+		}
+		s.standardNetworks[net.Name] = true
 	}
 
-	for _, net := range networks {
-		if err := s.DeriveNetworkKey(net.name, net.coinType, seed); err != nil {
-			return fmt.Errorf("failed to derive %s key: %v", net.name, err)
+	return nil
+}
+
+// Derive a standard network key, moving on to the next address index
+// when the derived key is invalid, as BIP32 specifies
+func (s *SingleSeedSleeve) deriveStandardNetwork(net StandardNetwork, seed []byte) error {
+	var err error
+	for i := uint32(0); i < maxStandardDerivationAttempts; i++ {
+		err = s.deriveNetworkKey(net.Name, net.Name, net.CoinType, s.networkAccount(), i, false, seed)
+		if !errors.Is(err, errZeroKey) {
+			return err
 		}
 	}
+	return err
+}
 
+// Get the errors of the standard networks that couldn't be derived, by network name
+// Empty when all standard networks were derived
+func (s *SingleSeedSleeve) StandardDerivationErrors() map[string]error {
+	errs := make(map[string]error, len(s.standardErrors))
+	for name, err := range s.standardErrors {
+		errs[name] = err
+	}
+	return errs
+}
+
+// Check that the sleeve's WOTS+ key and derivation index match the given seed
+// Network keys are not checked, see RederiveAll
+func (s *SingleSeedSleeve) Verify(seed []byte) error {
+	path, err := s.spec.PathFromSpec()
+	if err != nil {
+		return err
+	}
+	params := wots.DecodeParams(s.spec.params)
+	if params == nil {
+		return errors.New("unknown WOTS+ params encoding")
+	}
+	quantumNode, err := ComputeNode(seed, path)
+	if err != nil {
+		return err
+	}
+	wotsPK := wots.NewKeyFromSeed(params, quantumNode.Key, quantumNode.Code).ComputePK()
+	if !bytes.Equal(wotsPK, s.wotsPK) {
+		return errors.New("WOTS+ public key does not match seed")
+	}
+	pkHash := hasher.SHA3_256.Hash(wotsPK)
+	if !s.indexComputed || s.spec.derivationIndex(pkHash) != s.derivationIndex {
+		return errors.New("derivation index does not match WOTS+ public key")
+	}
+	return nil
+}
+
+// Re-derive every stored network key from the seed and check it matches
+// Each key is derived from scratch along its stored path, and the stored
+// keys are left untouched, so a mismatch points to a mutated key or a wrong seed
+func (s *SingleSeedSleeve) RederiveAll(seed []byte) error {
+	for _, name := range s.GetNetworkNames() {
+		netKey := s.networkKeys[name]
+		node, err := deriveNetworkNode(seed, netKey.CoinType, netKey.path)
+		if err != nil {
+			return fmt.Errorf("failed to re-derive %s key: %v", name, err)
+		}
+		if !bytes.Equal(node.Key, netKey.Key) || !bytes.Equal(node.Code, netKey.code) {
+			return fmt.Errorf("network %s key does not match re-derived key at %s", name, netKey.path)
+		}
+	}
 	return nil
 }
 
+// Derive the node at any BIP32 path, hardened or not, from a seed
+func deriveNodeAtPath(seed []byte, path Path) (*Node, error) {
+	node, err := NewMasterNode(seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create master node: %v", err)
+	}
+	for i, idx := range path {
+		if idx >= firstHardened {
+			node, err = node.HardenedChild(idx)
+		} else {
+			node, err = node.Child(idx)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive element %d of path %s: %v", i+1, path, err)
+		}
+	}
+	return node, nil
+}
+
 ///////////////////////////////////////////////////////////////////////
 // PRIVATE - SINGLE SEED GENERATION
 
+// Maximum number of consecutive identical bytes allowed in entropy
+// Based on the SP 800-90B repetition count test, with cutoff 1 + ceil(40/8),
+// so a working 8 bits per byte source fails with probability below 2^-40
+const entropyRepetitionCutoff = 6
+
+// Health check on entropy read from a CSPRNG, to detect a broken source
+// Rejects all-zero entropy, long runs of the same byte, and entropy that
+// is a short pattern repeated over its whole length
+func checkEntropyHealth(ent []byte) error {
+	// 1. Repetition count test (also catches all-zero entropy)
+	run := 1
+	for i := 1; i < len(ent); i++ {
+		if ent[i] == ent[i-1] {
+			run++
+			if run >= entropyRepetitionCutoff {
+				return errors.New("entropy failed health check: too many repeated bytes")
+			}
+		} else {
+			run = 1
+		}
+	}
+
+	// 2. Reject entropy made of a repeating pattern of up to half its length
+	for period := 1; period <= len(ent)/2; period++ {
+		repeating := true
+		for i := period; i < len(ent); i++ {
+			if ent[i] != ent[i-period] {
+				repeating = false
+				break
+			}
+		}
+		if repeating {
+			return errors.New("entropy failed health check: repeating pattern")
+		}
+	}
+	return nil
+}
+
 // Generate the single-seed sleeve according to the generation spec
-func generateSingleSeedSleeveFromMnemonic(mnemonic, passphrase string, spec GenSpec) (*SingleSeedSleeve, error) {
-	// 1. Generate seed from mnemonic (validates the mnemonic)
-	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+func generateSingleSeedSleeveFromMnemonic(mnemonic, passphrase string, spec GenSpec,
+	checksum bool) (*SingleSeedSleeve, error) {
+	// 1. Generate seed from mnemonic (validates the mnemonic, unless checksum is false)
+	start := spec.startTiming()
+	var seed []byte
+	if checksum {
+		var err error
+		if seed, err = bip39.NewSeedWithErrorChecking(mnemonic, passphrase); err != nil {
+			return nil, err
+		}
+	} else {
+		seed = bip39.NewSeed(mnemonic, passphrase)
+	}
+	spec.reportTiming("seed", start)
+
+	// 2. Generate single-seed sleeve from seed
+	sleeve, err := generateSingleSeedSleeveFromSeed(mnemonic, seed, spec)
 	if err != nil {
 		return nil, err
 	}
+	sleeve.hasPassphrase = passphrase != ""
+	return sleeve, nil
+}
 
-	// 2. Get path and wots params from GenSpec
+// Generate the single-seed sleeve from a BIP32 master seed according to the generation spec
+// The mnemonic is only stored, and can be empty if the seed wasn't generated with BIP39
+func generateSingleSeedSleeveFromSeed(mnemonic string, seed []byte, spec GenSpec) (*SingleSeedSleeve, error) {
+	// 1. Get path and wots params from GenSpec
 	path, err := spec.PathFromSpec()
 	if err != nil {
 		return nil, err
@@ -448,36 +1996,51 @@ func generateSingleSeedSleeveFromMnemonic(mnemonic, passphrase string, spec GenS
 		return nil, errors.New("unknown WOTS+ params encoding")
 	}
 
-	// 3. Derive quantum path using BIP32: m/44'/1955'/0'/0'/0'
+	// 2. Derive quantum path using BIP32: m/44'/1955'/0'/0'/0'
+	start := spec.startTiming()
 	quantumNode, err := ComputeNode(seed, path)
 	if err != nil {
 		return nil, err
 	}
 
-	// 4. Generate WOTS+ keypair (unchanged from original Sleeve)
+	// 3. Generate WOTS+ keypair (unchanged from original Sleeve)
 	wotsKey := wots.NewKeyFromSeed(params, quantumNode.Key, quantumNode.Code)
 	wotsPK := wotsKey.ComputePK()
+	spec.reportTiming("wots", start)
 
-	// 5. Calculate derivation index from WOTS public key
+	// 4. Calculate derivation index from WOTS public key
 	// Hash the WOTS PK and extract 31 bits to create a deterministic index
 	// that binds the network keys to the quantum-secure WOTS keypair
 	pkHash := hasher.SHA3_256.Hash(wotsPK)
-	// Mask to 31 bits to ensure index < 2^31 (BIP32 non-hardened requirement)
-	derivationIndex := binary.BigEndian.Uint32(pkHash[:4]) & 0x7FFFFFFF
+	// Masked to 31 bits to ensure index < 2^31 (BIP32 non-hardened requirement), unless IndexHardened
+	derivationIndex := spec.derivationIndex(pkHash)
 
-	// 6. Create single-seed sleeve structure
+	// 5. Create single-seed sleeve structure
+	seedCopy := make([]byte, len(seed))
+	copy(seedCopy, seed)
 	sleeve := &SingleSeedSleeve{
-		mnemonic:        mnemonic,
-		wotsKey:         wotsKey,
-		wotsPK:          wotsPK,
-		derivationIndex: derivationIndex,
-		networkKeys:     make(map[string]*NetworkKey),
+		mnemonic:         mnemonic,
+		seed:             seedCopy,
+		seedHash:         hashSeed(seed),
+		spec:             spec,
+		wotsKey:          wotsKey,
+		wotsSeed:         quantumNode.Key,
+		wotsCode:         quantumNode.Code,
+		wotsPK:           wotsPK,
+		derivationIndex:  derivationIndex,
+		indexComputed:    true,
+		networkKeys:      make(map[string]*NetworkKey),
+		standardNetworks: make(map[string]bool),
 	}
 
-	// 7. Automatically derive keys for standard networks
-	err = sleeve.DeriveStandardNetworks(seed)
-	if err != nil {
-		return nil, err
+	// 6. Automatically derive keys for standard networks, unless lazy derivation was chosen
+	if !spec.lazyDerivation {
+		start = spec.startTiming()
+		err = sleeve.DeriveStandardNetworks(seed)
+		if err != nil {
+			return nil, err
+		}
+		spec.reportTiming("networks", start)
 	}
 
 	return sleeve, nil
@@ -2,13 +2,13 @@ package wallet
 
 import (
 	"crypto/rand"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/xx-labs/sleeve/wots"
+	"runtime"
 	"testing"
 )
 
 func generateECDSAFromPriv(priv []byte) {
-	_, err := crypto.ToECDSA(priv)
+	_, err := secp256k1PrivateKey(priv)
 	if err != nil {
 		panic(err)
 	}
@@ -52,3 +52,105 @@ func BenchmarkSleeve_GenerateSleeve(b *testing.B) {
 		generateSleeveECDSA(seed, pSeed)
 	}
 }
+
+const benchWallets = 16
+
+func BenchmarkGenerateWallets_SequentialLevel3(b *testing.B) {
+	spec := NewGenSpec(0, wots.Level3)
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < benchWallets; i++ {
+			if _, err := NewSingleSeedSleeve(rand.Reader, "", spec); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkGenerateWallets_ParallelLevel3(b *testing.B) {
+	spec := NewGenSpec(0, wots.Level3)
+	for n := 0; n < b.N; n++ {
+		if _, err := GenerateWalletsParallel(benchWallets, spec, runtime.NumCPU()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewSingleSeedSleeve(b *testing.B) {
+	spec := DefaultGenSpec()
+	for n := 0; n < b.N; n++ {
+		if _, err := NewSingleSeedSleeve(rand.Reader, "", spec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewWOTSOnlySleeve(b *testing.B) {
+	spec := DefaultGenSpec()
+	for n := 0; n < b.N; n++ {
+		if _, err := NewWOTSOnlySleeve(rand.Reader, "", spec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Number of hashes signed by the signing benchmarks
+const benchHashes = 64
+
+func benchmarkSigningSleeve(b *testing.B) (*SingleSeedSleeve, [][]byte) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, err := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed); err != nil {
+		b.Fatal(err)
+	}
+	hashes := make([][]byte, benchHashes)
+	for i := range hashes {
+		hashes[i] = keccak256([]byte{byte(i)})
+	}
+	return sleeve, hashes
+}
+
+// Sign the hashes one by one with SignWithNetwork
+// Compare with benchmarkSignBatch using -benchmem: batching saves most allocations for ed25519,
+// but only the per-call ones for secp256k1, whose signing allocates in btcec
+func benchmarkSignLoop(b *testing.B, network string) {
+	sleeve, hashes := benchmarkSigningSleeve(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, hash := range hashes {
+			if _, err := sleeve.SignWithNetwork(network, hash); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func benchmarkSignBatch(b *testing.B, network string) {
+	sleeve, hashes := benchmarkSigningSleeve(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := sleeve.SignBatch(network, hashes); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSignWithNetwork_Loop(b *testing.B) {
+	benchmarkSignLoop(b, "Ethereum")
+}
+
+func BenchmarkSignBatch(b *testing.B) {
+	benchmarkSignBatch(b, "Ethereum")
+}
+
+func BenchmarkSignWithNetwork_LoopEd25519(b *testing.B) {
+	benchmarkSignLoop(b, "Solana")
+}
+
+func BenchmarkSignBatch_Ed25519(b *testing.B) {
+	benchmarkSignBatch(b, "Solana")
+}
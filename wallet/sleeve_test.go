@@ -57,7 +57,7 @@ func TestNewSleeveFromEntropy(t *testing.T) {
 
 	_, err := NewSleeveFromEntropy(ent, "", DefaultGenSpec())
 
-	if err == nil {
+	if !errors.Is(err, ErrInvalidBIP39Entropy) {
 		t.Fatalf("NewSleeveFromEntropy() should return error when provided entropy doesn't meet BIP39 standard")
 	}
 
@@ -66,7 +66,7 @@ func TestNewSleeveFromEntropy(t *testing.T) {
 
 	_, err = NewSleeveFromEntropy(ent, "", DefaultGenSpec())
 
-	if err == nil {
+	if !errors.Is(err, ErrEntropyTooSmallForSleeve) {
 		t.Fatalf("NewSleeveFromEntropy() should return error when provided entropy is of incorrect size")
 	}
 }
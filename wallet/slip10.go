@@ -0,0 +1,95 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"crypto/hmac"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/xx-labs/sleeve/hasher"
+)
+
+// SLIP-0010 key of the master node HMAC for ed25519
+const slip10Ed25519Seed = "ed25519 seed"
+
+// Check whether keys of a coin type are derived with SLIP-0010 for ed25519, as the chain's wallets do
+// Every level of their paths is hardened, since SLIP-0010 has no non-hardened ed25519 derivation.
+// Other ed25519 coin types, like Solana, keep using the BIP32 key as the ed25519 seed,
+// so sleeves created before Tezos and Near were added keep their keys
+func usesSLIP10(coinType uint32) bool {
+	return coinType == CoinTypeTezos || coinType == CoinTypeNear
+}
+
+// Derive the SLIP-0010 ed25519 master node from a seed
+// Unlike BIP32, every 32 byte key is a valid ed25519 seed, so no key is rejected
+func NewEd25519MasterNode(seed []byte) (*Node, error) {
+	if len(seed) < minSeedSize || len(seed) > maxSeedSize {
+		return nil, errors.New("NewEd25519MasterNode: invalid seed size")
+	}
+	h := hmac.New(hasher.SHA2_512.New, []byte(slip10Ed25519Seed))
+	h.Write(seed)
+	aux := h.Sum(nil)
+	return &Node{Key: aux[:keySize], Code: aux[keySize:]}, nil
+}
+
+// Compute the SLIP-0010 ed25519 hardened child node with given index
+// Returns a new Node without mutating the parent. Only hard derivations are
+// defined for ed25519, so idx must be >= 2^31
+func (n *Node) Ed25519HardenedChild(idx uint32) (*Node, error) {
+	if idx < firstHardened {
+		return nil, errors.New("child index must be >= 2^31, ed25519 only has hardened derivation")
+	}
+	idxBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(idxBytes, idx)
+
+	// Data: 0x00 || key || ser32(idx), and the child key is the left half of the HMAC as is
+	h := hmac.New(hasher.SHA2_512.New, n.Code)
+	h.Write([]byte{0x00})
+	h.Write(n.Key)
+	h.Write(idxBytes)
+	aux := h.Sum(nil)
+	return &Node{Key: aux[:keySize], Code: aux[keySize:]}, nil
+}
+
+// Derive the SLIP-0010 ed25519 node at a path from a seed, every element must be hardened
+func deriveEd25519NodeAtPath(seed []byte, path Path) (*Node, error) {
+	node, err := NewEd25519MasterNode(seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create master node: %v", err)
+	}
+	for i, idx := range path {
+		child, err := node.Ed25519HardenedChild(idx)
+		zero(node.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive element %d of path %s: %v", i+1, path, err)
+		}
+		node = child
+	}
+	return node, nil
+}
+
+// Derive the node of a network key at a path from a seed,
+// with SLIP-0010 for coin types using it and BIP32 otherwise
+func deriveNetworkNode(seed []byte, coinType uint32, path Path) (*Node, error) {
+	if usesSLIP10(coinType) {
+		return deriveEd25519NodeAtPath(seed, path)
+	}
+	return deriveNodeAtPath(seed, path)
+}
+
+// Derive the node of a network key at a path from a seed, with its parent node and the parent's
+// parent fingerprint needed for the extended public key, see walkPath
+// SLIP-0010 nodes have no extended public key, so no parent node is returned for them
+func walkNetworkPath(seed []byte, coinType uint32, path Path) (node, parent *Node, parentFingerprint []byte, err error) {
+	if usesSLIP10(coinType) {
+		node, err = deriveEd25519NodeAtPath(seed, path)
+		return node, nil, nil, err
+	}
+	return walkPath(seed, path)
+}
@@ -0,0 +1,70 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// Mnemonic of the SLIP-0010 wallet vectors, as used by most ed25519 wallets' tests
+const slip10VectorMnemonic = "abandon abandon abandon abandon abandon abandon " +
+	"abandon abandon abandon abandon abandon about"
+
+func TestDeriveEd25519NodeAtPath(t *testing.T) {
+	// SLIP-0010 test vector 1 for ed25519
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	vectors := []struct {
+		path, key, code, pub string
+	}{
+		{"m", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+			"90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+			"a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed"},
+		{"m/0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+			"8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+			"8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c"},
+		{"m/0'/1'", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+			"a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14",
+			"1932a5270f335bed617d5b935c80aedb1a35bd9fc1e31acafd5372c30f5c1187"},
+		{"m/0'/1'/2'", "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9",
+			"2e69929e00b5ab250f49c3fb1c12f252de4fed2c1db88387094a0f8c4c9ccd6c",
+			"ae98736566d30ed0e9d2f4486a64bc95740d89c7db33f52121f8ea8f76ff0fc1"},
+	}
+	for _, v := range vectors {
+		path, _ := ParsePath(v.path)
+		node, err := deriveEd25519NodeAtPath(seed, path)
+		if err != nil {
+			t.Fatalf("deriveEd25519NodeAtPath(%s) returned error: %v", v.path, err)
+		}
+		pub := ed25519.NewKeyFromSeed(node.Key).Public().(ed25519.PublicKey)
+		if hex.EncodeToString(node.Key) != v.key || hex.EncodeToString(node.Code) != v.code ||
+			hex.EncodeToString(pub) != v.pub {
+			t.Fatalf("deriveEd25519NodeAtPath(%s) returned wrong node. Got key %x, code %x, public key %x",
+				v.path, node.Key, node.Code, pub)
+		}
+	}
+
+	// Solana wallets derive the same keys, e.g., Phantom's first account
+	path, _ := ParsePath("m/44'/501'/0'/0'")
+	node, _ := deriveEd25519NodeAtPath(mustSeed(slip10VectorMnemonic), path)
+	expected := "HAgk14JpMQLgt6rVgv7cBQFJWFto5Dqxi472uT3DKpqk"
+	if addr := base58.Encode(ed25519.NewKeyFromSeed(node.Key).Public().(ed25519.PublicKey)); addr != expected {
+		t.Fatalf("deriveEd25519NodeAtPath() returned wrong Solana key. Got %s, expected %s", addr, expected)
+	}
+
+	// Errors
+	path, _ = ParsePath("m/44'/1729'/0'/0")
+	if _, err := deriveEd25519NodeAtPath(seed, path); err == nil {
+		t.Fatalf("deriveEd25519NodeAtPath() should return error for a non-hardened path")
+	}
+	if _, err := NewEd25519MasterNode(make([]byte, 8)); err == nil {
+		t.Fatalf("NewEd25519MasterNode() should return error for a short seed")
+	}
+}
@@ -0,0 +1,174 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/xx-labs/sleeve/wots"
+	"sort"
+)
+
+// Canonical single-seed sleeve test vectors, see VerifyTestVectors
+//
+//go:embed testvectors.json
+var testVectorsJSON []byte
+
+// A single-seed sleeve test vector
+// Networks without an address format have an empty address
+type testVector struct {
+	Mnemonic      string                       `json:"Mnemonic"`
+	Passphrase    string                       `json:"Passphrase"`
+	Account       uint32                       `json:"Account"`
+	Params        string                       `json:"Params"`
+	WOTSPublicKey string                       `json:"WOTSPublicKey"`
+	WOTSIndex     uint32                       `json:"WOTSIndex"`
+	Networks      map[string]testVectorNetwork `json:"Networks"`
+}
+
+// Expected public key and address of a standard network in a test vector
+type testVectorNetwork struct {
+	PublicKey string `json:"PublicKey"`
+	Address   string `json:"Address"`
+}
+
+// Result of one known-answer check of an embedded test vector
+type KATResult struct {
+	Vector  int    // Index of the test vector
+	Params  string // WOTS+ parameter set of the vector
+	Account uint32 // Account of the vector
+	// What was checked: "Sleeve" for the generation itself, "WOTSPublicKey", "WOTSIndex",
+	// or the name of a standard network for its public key and address
+	Check  string
+	Passed bool
+	Err    error // Why the check failed, nil if it passed
+}
+
+// Names of the checks of a test vector, besides its networks
+const (
+	katCheckSleeve = "Sleeve"
+	katCheckWOTSPK = "WOTSPublicKey"
+	katCheckIndex  = "WOTSIndex"
+)
+
+// Run the known-answer tests of the canonical test vectors embedded in the library,
+// returning one result per check: WOTS+ public key, derivation index, and the public key
+// and address of each standard network, for every WOTS+ parameter set
+// Integrators can run them at startup to assert their build derives the same keys.
+// Checks after a failed sleeve generation are skipped, and results are in a fixed order
+func RunKAT() []KATResult {
+	var vectors []testVector
+	if err := json.Unmarshal(testVectorsJSON, &vectors); err != nil {
+		return []KATResult{{Vector: -1, Check: katCheckSleeve,
+			Err: fmt.Errorf("failed to decode test vectors: %v", err)}}
+	}
+	return runTestVectors(vectors)
+}
+
+// Check the current code reproduces the canonical test vectors embedded in the
+// library, covering every WOTS+ parameter set: WOTS+ public key, derivation index,
+// and the public key and address of each standard network
+// Any error means the derivation changed, and wallets generated with a previous
+// version would no longer be recovered to the same keys
+func VerifyTestVectors() error {
+	var vectors []testVector
+	if err := json.Unmarshal(testVectorsJSON, &vectors); err != nil {
+		return fmt.Errorf("failed to decode test vectors: %v", err)
+	}
+	return verifyTestVectors(vectors)
+}
+
+// Check the given test vectors are reproduced, returning the first failed check
+func verifyTestVectors(vectors []testVector) error {
+	for _, result := range runTestVectors(vectors) {
+		if !result.Passed {
+			return fmt.Errorf("test vector %d (%s, account %d): %w",
+				result.Vector, result.Params, result.Account, result.Err)
+		}
+	}
+	return nil
+}
+
+// Run the checks of the given test vectors
+func runTestVectors(vectors []testVector) []KATResult {
+	var results []KATResult
+	for i, vector := range vectors {
+		for _, result := range vector.run() {
+			result.Vector, result.Params, result.Account = i, vector.Params, vector.Account
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Generate the sleeve of a test vector and compare it to the expected values
+func (v testVector) run() []KATResult {
+	check := func(name string, err error) KATResult {
+		return KATResult{Check: name, Passed: err == nil, Err: err}
+	}
+	params, err := parseParamsEncoding(v.Params)
+	if err != nil {
+		return []KATResult{check(katCheckSleeve, err)}
+	}
+	sleeve, err := NewSingleSeedSleeveFromMnemonic(v.Mnemonic, v.Passphrase, NewGenSpec(v.Account, params))
+	if err != nil {
+		return []KATResult{check(katCheckSleeve, fmt.Errorf("failed to generate sleeve: %v", err))}
+	}
+	defer sleeve.Wipe()
+
+	var pkErr, indexErr error
+	if pk := sleeve.GetWOTSPublicKeyHex(); pk != v.WOTSPublicKey {
+		pkErr = fmt.Errorf("WOTS+ public key mismatch: got %s, expected %s", pk, v.WOTSPublicKey)
+	}
+	if index := sleeve.GetDerivationIndex(); index != v.WOTSIndex {
+		indexErr = fmt.Errorf("derivation index mismatch: got %d, expected %d", index, v.WOTSIndex)
+	}
+	results := []KATResult{check(katCheckSleeve, nil), check(katCheckWOTSPK, pkErr), check(katCheckIndex, indexErr)}
+
+	names := make([]string, 0, len(v.Networks))
+	for name := range v.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		results = append(results, check(name, v.Networks[name].verify(sleeve, name)))
+	}
+	return results
+}
+
+// Compare the public key and address of a network of the sleeve to the expected values
+func (expected testVectorNetwork) verify(sleeve *SingleSeedSleeve, name string) error {
+	pub, err := sleeve.GetPublicKey(name)
+	if err != nil {
+		return err
+	}
+	if hex.EncodeToString(pub) != expected.PublicKey {
+		return fmt.Errorf("%s public key mismatch: got %x, expected %s", name, pub, expected.PublicKey)
+	}
+	addr, err := sleeve.GetAddress(name)
+	if err != nil {
+		return err
+	}
+	if addr != expected.Address {
+		return fmt.Errorf("%s address mismatch: got %q, expected %q", name, addr, expected.Address)
+	}
+	return nil
+}
+
+// Get the WOTS+ parameter set encoding from its string representation, including its HashFunc
+func parseParamsEncoding(s string) (wots.ParamsEncoding, error) {
+	for level := wots.ParamsEncoding(0); level < wots.ParamsEncodingLen; level++ {
+		for h := wots.HashFunc(0); h < wots.HashFuncLen; h++ {
+			if enc := level.WithHashFunc(h); enc.String() == s {
+				return enc, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unknown WOTS+ parameter set %q", s)
+}
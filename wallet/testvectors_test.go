@@ -0,0 +1,110 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"encoding/json"
+	"github.com/xx-labs/sleeve/wots"
+	"testing"
+)
+
+func TestVerifyTestVectors(t *testing.T) {
+	if err := VerifyTestVectors(); err != nil {
+		t.Fatalf("VerifyTestVectors() returned error: %v", err)
+	}
+
+	// Every WOTS+ parameter set is covered, and the vectors agree with the WOTS+ test vector
+	var vectors []testVector
+	if err := json.Unmarshal(testVectorsJSON, &vectors); err != nil {
+		t.Fatalf("Failed to decode test vectors: %v", err)
+	}
+	covered := make(map[string]bool)
+	for _, vector := range vectors {
+		covered[vector.Params] = true
+		if vector.Mnemonic == wotsTestVectorMnemonic && vector.Params == wots.Level0.String() &&
+			vector.Passphrase == "" && vector.Account == 0 && vector.WOTSPublicKey != wotsExpectedPubKeyHex {
+			t.Fatalf("Test vector WOTS+ public key doesn't match the WOTS+ test vector")
+		}
+	}
+	for enc := wots.ParamsEncoding(0); enc < wots.ParamsEncodingLen; enc++ {
+		if !covered[enc.String()] {
+			t.Fatalf("No test vector for WOTS+ parameter set %s", enc)
+		}
+	}
+}
+
+func TestVerifyTestVectors_Mismatch(t *testing.T) {
+	var vectors []testVector
+	_ = json.Unmarshal(testVectorsJSON, &vectors)
+
+	// Changed WOTS+ public key
+	modified := append([]testVector{}, vectors...)
+	modified[0].WOTSPublicKey = wotsExpectedPubKeyHex[:62] + "00"
+	if err := verifyTestVectors(modified); err == nil {
+		t.Fatalf("verifyTestVectors() should return error for a modified WOTS+ public key")
+	}
+
+	// Changed address
+	modified = append([]testVector{}, vectors...)
+	modified[0].Networks = map[string]testVectorNetwork{
+		"Ethereum": {PublicKey: vectors[0].Networks["Ethereum"].PublicKey, Address: "0x00"},
+	}
+	if err := verifyTestVectors(modified); err == nil {
+		t.Fatalf("verifyTestVectors() should return error for a modified address")
+	}
+
+	// Unknown parameter set
+	modified = append([]testVector{}, vectors...)
+	modified[0].Params = "Level9"
+	if err := verifyTestVectors(modified); err == nil {
+		t.Fatalf("verifyTestVectors() should return error for an unknown parameter set")
+	}
+}
+
+func TestRunKAT(t *testing.T) {
+	var vectors []testVector
+	_ = json.Unmarshal(testVectorsJSON, &vectors)
+	results := RunKAT()
+
+	// Every check of every vector passes
+	expected := 0
+	for _, vector := range vectors {
+		expected += 3 + len(vector.Networks)
+	}
+	if len(results) != expected {
+		t.Fatalf("RunKAT() returned %d results, expected %d", len(results), expected)
+	}
+	for _, result := range results {
+		if !result.Passed || result.Err != nil {
+			t.Fatalf("RunKAT() check %s of vector %d failed: %v", result.Check, result.Vector, result.Err)
+		}
+	}
+
+	// Failed checks are reported individually
+	modified := append([]testVector{}, vectors...)
+	modified[0].WOTSIndex++
+	results = runTestVectors(modified[:1])
+	failed := 0
+	for _, result := range results {
+		if !result.Passed {
+			failed++
+			if result.Check != katCheckIndex || result.Err == nil {
+				t.Fatalf("runTestVectors() failed check %s, expected %s", result.Check, katCheckIndex)
+			}
+		}
+	}
+	if failed != 1 {
+		t.Fatalf("runTestVectors() returned %d failed checks, expected 1", failed)
+	}
+
+	// A vector whose sleeve can't be generated has a single failed check
+	modified[0].Params = "Level9"
+	results = runTestVectors(modified[:1])
+	if len(results) != 1 || results[0].Passed || results[0].Check != katCheckSleeve {
+		t.Fatalf("runTestVectors() should return a single failed %s check, got %+v", katCheckSleeve, results)
+	}
+}
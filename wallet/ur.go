@@ -0,0 +1,177 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+)
+
+///////////////////////////////////////////////////////////////////////
+// UNIFORM RESOURCES (UR)
+/*
+	Wallet descriptors can be exported as a single-part Uniform Resource
+	(BCR-2020-005), to move public wallet data between an air-gapped signer
+	and an online watcher running this package by QR code:
+
+		ur:sleeve-descriptor/<bytewords>
+
+	The payload is the JSON descriptor wrapped in a CBOR byte string, followed
+	by its CRC32 checksum, encoded with minimal Bytewords (BCR-2020-012), i.e.,
+	the first and last letters of the word for each byte.
+
+	sleeve-descriptor is a private UR type, not a registered one like
+	crypto-hdkey or crypto-account, which can't hold the WOTS+ public key or
+	non-BIP32 keys. Other UR wallets can read the QR code, but not its payload.
+*/
+
+// Private UR type of exported wallet descriptors
+const urDescriptorType = "sleeve-descriptor"
+
+// Bytewords, one 4 letter word per byte value
+var bytewords = strings.Fields(
+	"able acid also apex aqua arch atom aunt away axis back bald barn belt beta bias " +
+		"blue body brag brew bulb buzz calm cash cats chef city claw code cola cook cost " +
+		"crux curl cusp cyan dark data days deli dice diet door down draw drop drum dull " +
+		"duty each easy echo edge epic even exam exit eyes fact fair fern figs film fish " +
+		"fizz flap flew flux foxy free frog fuel fund gala game gear gems gift girl glow " +
+		"good gray grim guru gush gyro half hang hard hawk heat help high hill holy hope " +
+		"horn huts iced idea idle inch inky into iris iron item jade jazz join jolt jowl " +
+		"judo jugs jump junk jury keep keno kept keys kick kiln king kite kiwi knob lamb " +
+		"lava lazy leaf legs liar limp lion list logo loud love luau luck lung main many " +
+		"math maze memo menu meow mild mint miss monk nail navy need news next noon note " +
+		"numb obey oboe omit onyx open oval owls paid part peck play plus poem pool pose " +
+		"puff puma purr quad quiz race ramp real redo rich road rock roof ruby ruin runs " +
+		"rust safe saga scar sets silk skew slot soap solo song stub surf swan taco task " +
+		"taxi tent tied time tiny toil tomb toys trip tuna twin ugly undo unit urge user " +
+		"vast very veto vial vibe view visa void vows wall wand warm wasp wave waxy webs " +
+		"what when whiz wolf work yank yawn yell yoga yurt zaps zero zest zinc zone zoom")
+
+// Minimal bytewords to byte value
+var bytewordsMinimal = func() map[string]byte {
+	m := make(map[string]byte, len(bytewords))
+	for i, w := range bytewords {
+		m[w[:1]+w[3:]] = byte(i)
+	}
+	return m
+}()
+
+// Export the wallet descriptor, including addresses, as a sleeve-descriptor Uniform Resource
+// The UR can only be decoded back to the descriptor with DecodeURDescriptor, other UR wallets
+// don't know its private type
+func (s *SingleSeedSleeve) ExportUR() (string, error) {
+	desc, err := s.ExportDescriptor()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(desc)
+	if err != nil {
+		return "", err
+	}
+	return "ur:" + urDescriptorType + "/" + encodeBytewordsMinimal(cborByteString(data)), nil
+}
+
+// Decode a wallet descriptor exported with ExportUR
+// URs are case insensitive, as QR codes use upper case
+func DecodeURDescriptor(ur string) (WalletDescriptor, error) {
+	ur = strings.ToLower(strings.TrimSpace(ur))
+	prefix := "ur:" + urDescriptorType + "/"
+	if !strings.HasPrefix(ur, prefix) {
+		return WalletDescriptor{}, fmt.Errorf("not a %s UR", urDescriptorType)
+	}
+	payload, err := decodeBytewordsMinimal(ur[len(prefix):])
+	if err != nil {
+		return WalletDescriptor{}, err
+	}
+	data, err := parseCBORByteString(payload)
+	if err != nil {
+		return WalletDescriptor{}, err
+	}
+	var desc WalletDescriptor
+	if err := json.Unmarshal(data, &desc); err != nil {
+		return WalletDescriptor{}, fmt.Errorf("invalid descriptor: %v", err)
+	}
+	return desc, nil
+}
+
+// Encode data followed by its CRC32 checksum as minimal bytewords
+func encodeBytewordsMinimal(data []byte) string {
+	checksum := make([]byte, 4)
+	binary.BigEndian.PutUint32(checksum, crc32.ChecksumIEEE(data))
+	var sb strings.Builder
+	for _, b := range append(data, checksum...) {
+		w := bytewords[b]
+		sb.WriteString(w[:1] + w[3:])
+	}
+	return sb.String()
+}
+
+// Decode minimal bytewords and check the trailing CRC32 checksum
+func decodeBytewordsMinimal(str string) ([]byte, error) {
+	if len(str)%2 != 0 || len(str) < 10 {
+		return nil, errors.New("invalid bytewords length")
+	}
+	data := make([]byte, len(str)/2)
+	for i := range data {
+		b, ok := bytewordsMinimal[str[2*i:2*i+2]]
+		if !ok {
+			return nil, fmt.Errorf("invalid byteword %q", str[2*i:2*i+2])
+		}
+		data[i] = b
+	}
+	body, checksum := data[:len(data)-4], data[len(data)-4:]
+	if binary.BigEndian.Uint32(checksum) != crc32.ChecksumIEEE(body) {
+		return nil, errors.New("invalid bytewords checksum")
+	}
+	return body, nil
+}
+
+// Wrap data in a CBOR byte string (major type 2)
+func cborByteString(data []byte) []byte {
+	n := len(data)
+	var header []byte
+	switch {
+	case n < 24:
+		header = []byte{0x40 | byte(n)}
+	case n <= 0xFF:
+		header = []byte{0x58, byte(n)}
+	case n <= 0xFFFF:
+		header = []byte{0x59, byte(n >> 8), byte(n)}
+	default:
+		header = []byte{0x5A, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(header[1:], uint32(n))
+	}
+	return append(header, data...)
+}
+
+// Get the data of a CBOR byte string, which must be the whole input
+func parseCBORByteString(cbor []byte) ([]byte, error) {
+	if len(cbor) == 0 || cbor[0]>>5 != 2 {
+		return nil, errors.New("payload is not a CBOR byte string")
+	}
+	var n, headerLen int
+	switch info := cbor[0] & 0x1F; {
+	case info < 24:
+		n, headerLen = int(info), 1
+	case info == 24 && len(cbor) >= 2:
+		n, headerLen = int(cbor[1]), 2
+	case info == 25 && len(cbor) >= 3:
+		n, headerLen = int(binary.BigEndian.Uint16(cbor[1:3])), 3
+	case info == 26 && len(cbor) >= 5:
+		n, headerLen = int(binary.BigEndian.Uint32(cbor[1:5])), 5
+	default:
+		return nil, errors.New("unsupported CBOR byte string length")
+	}
+	if len(cbor) != headerLen+n {
+		return nil, fmt.Errorf("CBOR byte string has length %d, got %d bytes", n, len(cbor)-headerLen)
+	}
+	return cbor[headerLen:], nil
+}
@@ -0,0 +1,87 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeBytewordsMinimal(t *testing.T) {
+	// Test vector from BCR-2020-012: "able acid also lava zoom jade need echo taxi"
+	data := []byte{0x00, 0x01, 0x02, 0x80, 0xff}
+	encoded := encodeBytewordsMinimal(data)
+	if encoded != "aeadaolazmjendeoti" {
+		t.Fatalf("encodeBytewordsMinimal() returned %s, expected aeadaolazmjendeoti", encoded)
+	}
+	decoded, err := decodeBytewordsMinimal(encoded)
+	if err != nil || !bytes.Equal(decoded, data) {
+		t.Fatalf("decodeBytewordsMinimal() returned %x: %v", decoded, err)
+	}
+
+	// Wrong checksum and invalid words
+	for _, str := range []string{"aeadaolazmjendeota", "aeadaolazmjendeo", "xxadaolazmjendeoti"} {
+		if _, err := decodeBytewordsMinimal(str); err == nil {
+			t.Fatalf("decodeBytewordsMinimal(%s) should return error", str)
+		}
+	}
+}
+
+func TestCBORByteString(t *testing.T) {
+	for _, n := range []int{0, 23, 24, 255, 256, 70000} {
+		data := bytes.Repeat([]byte{0xAB}, n)
+		parsed, err := parseCBORByteString(cborByteString(data))
+		if err != nil || !bytes.Equal(parsed, data) {
+			t.Fatalf("CBOR byte string of length %d doesn't round trip: %v", n, err)
+		}
+	}
+	if _, err := parseCBORByteString([]byte{0x45, 1, 2}); err == nil {
+		t.Fatalf("parseCBORByteString() should return error for truncated input")
+	}
+	if _, err := parseCBORByteString([]byte{0x60}); err == nil {
+		t.Fatalf("parseCBORByteString() should return error for a text string")
+	}
+}
+
+func TestSingleSeedSleeve_ExportUR(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromSeed(seed, DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+
+	ur, err := sleeve.ExportUR()
+	if err != nil {
+		t.Fatalf("ExportUR() returned error: %v", err)
+	}
+	if !strings.HasPrefix(ur, "ur:sleeve-descriptor/") {
+		t.Fatalf("ExportUR() returned wrong prefix: %s", ur[:24])
+	}
+
+	expected, _ := sleeve.ExportDescriptor()
+	for _, str := range []string{ur, strings.ToUpper(ur)} {
+		desc, err := DecodeURDescriptor(str)
+		if err != nil {
+			t.Fatalf("DecodeURDescriptor() returned error: %v", err)
+		}
+		if !reflect.DeepEqual(desc, expected) {
+			t.Fatalf("DecodeURDescriptor() doesn't round trip the descriptor")
+		}
+	}
+
+	// Corrupted payload and other UR types
+	corrupted := ur[:len(ur)-2] + "ae"
+	if ur[len(ur)-2:] == "ae" {
+		corrupted = ur[:len(ur)-2] + "ad"
+	}
+	if _, err := DecodeURDescriptor(corrupted); err == nil {
+		t.Fatalf("DecodeURDescriptor() should return error for corrupted UR")
+	}
+	if _, err := DecodeURDescriptor("ur:bytes/aeadaolazmjendeoti"); err == nil {
+		t.Fatalf("DecodeURDescriptor() should return error for other UR types")
+	}
+}
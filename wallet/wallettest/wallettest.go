@@ -0,0 +1,25 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2020 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+// Package wallettest provides deterministic Sleeve wallet fixtures for tests
+package wallettest
+
+import (
+	"fmt"
+
+	"github.com/xx-labs/sleeve/wallet"
+)
+
+// Create a single-seed sleeve from the given entropy and generation spec, with no passphrase
+// The same entropy and spec always produce the same sleeve
+// Panics on error, so it should only be used in tests
+func TestSleeve(entropy []byte, spec wallet.GenSpec) *wallet.SingleSeedSleeve {
+	sleeve, err := wallet.NewSingleSeedSleeveFromEntropy(entropy, "", spec)
+	if err != nil {
+		panic(fmt.Sprintf("wallettest: couldn't create test sleeve: %s", err))
+	}
+	return sleeve
+}
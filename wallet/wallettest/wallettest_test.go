@@ -0,0 +1,40 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2020 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallettest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/xx-labs/sleeve/wallet"
+)
+
+func TestTestSleeve(t *testing.T) {
+	ent := bytes.Repeat([]byte{0x42}, wallet.EntropySize)
+
+	// Test same entropy produces same sleeve
+	sleeve1 := TestSleeve(ent, wallet.DefaultGenSpec())
+	sleeve2 := TestSleeve(ent, wallet.DefaultGenSpec())
+
+	if sleeve1.GetMnemonic() != sleeve2.GetMnemonic() {
+		t.Fatalf("TestSleeve() should be deterministic")
+	}
+
+	if !bytes.Equal(sleeve1.GetWOTSPublicKey(), sleeve2.GetWOTSPublicKey()) {
+		t.Fatalf("TestSleeve() should be deterministic")
+	}
+}
+
+func TestTestSleeve_Panics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("TestSleeve() should panic with invalid entropy")
+		}
+	}()
+
+	TestSleeve(make([]byte, wallet.EntropySize-1), wallet.DefaultGenSpec())
+}
@@ -0,0 +1,184 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/xx-labs/sleeve/hasher"
+	"github.com/xx-labs/sleeve/wots"
+)
+
+// Public wallet exported by ExportPublicOnly: the wallet descriptor, whether address keys
+// are all hardened, and whether network keys are under the wallet's account, which set the
+// names keys are stored under
+type publicWallet struct {
+	WalletDescriptor
+	IndexHardened         bool `json:"IndexHardened,omitempty"`
+	AccountInNetworkPaths bool `json:"AccountInNetworkPaths,omitempty"`
+}
+
+// Export the public wallet as JSON, for portfolio trackers and other watch-only uses
+// It holds the wallet descriptor: WOTS+ public key, derivation index, and the coin type,
+// path, public key and address of every derived network. There's no mnemonic, seed,
+// chain code or private key in it, so it can't recover the wallet or sign.
+// ImportPublicOnly reads it back as a watch-only sleeve
+func (s *SingleSeedSleeve) ExportPublicOnly() ([]byte, error) {
+	desc, err := s.ExportDescriptor()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(publicWallet{WalletDescriptor: desc, IndexHardened: s.spec.IndexHardened,
+		AccountInNetworkPaths: s.spec.AccountInNetworkPaths})
+}
+
+// Import a public wallet exported by ExportPublicOnly as a watch-only sleeve
+// Public keys, addresses and descriptors of its networks are available as in the original
+// sleeve, but methods needing private keys or the seed, like GetPrivateKey, signing and
+// deriving networks, return ErrWatchOnly. External signers can still be set to sign.
+// Fails if any field is invalid, or if an address doesn't match its public key
+func ImportPublicOnly(data []byte) (*SingleSeedSleeve, error) {
+	var pw publicWallet
+	if err := json.Unmarshal(data, &pw); err != nil {
+		return nil, fmt.Errorf("failed to decode public wallet: %v", err)
+	}
+	d := pw.WalletDescriptor
+
+	// 1. Check the WOTS+ public key and its derivation index
+	if d.Account >= firstHardened {
+		return nil, fmt.Errorf("invalid account %d: must be less than 2^31", d.Account)
+	}
+	params, err := parseParamsEncoding(d.Params)
+	if err != nil {
+		return nil, err
+	}
+	wotsPK, err := hex.DecodeString(d.WOTSPublicKey)
+	if err != nil || len(wotsPK) != wots.PKSize {
+		return nil, errors.New("invalid WOTS+ public key")
+	}
+	spec := NewGenSpec(d.Account, params)
+	spec.IndexHardened = pw.IndexHardened
+	spec.AccountInNetworkPaths = pw.AccountInNetworkPaths
+	pkHash := hasher.SHA3_256.Hash(wotsPK)
+	if spec.derivationIndex(pkHash) != d.WOTSIndex {
+		return nil, errors.New("derivation index does not match WOTS+ public key")
+	}
+	sleeve := &SingleSeedSleeve{
+		spec:             spec,
+		wotsPK:           wotsPK,
+		derivationIndex:  d.WOTSIndex,
+		indexComputed:    true,
+		networkKeys:      make(map[string]*NetworkKey),
+		standardNetworks: make(map[string]bool),
+		watchOnly:        true,
+	}
+
+	// 2. Store the public keys of the networks
+	for _, net := range d.Networks {
+		name, netKey, err := sleeve.watchOnlyKey(net)
+		if err != nil {
+			return nil, fmt.Errorf("network %s: %v", net.Network, err)
+		}
+		if _, exists := sleeve.networkKeys[name]; exists {
+			return nil, fmt.Errorf("network %s is listed twice", name)
+		}
+		sleeve.networkKeys[name] = netKey
+		sleeve.setNextAddressIndex(name, net.NextAddressIndex)
+		if coinType, ok := sleeve.standardCoinType(name); ok && coinType == net.CoinType {
+			sleeve.standardNetworks[name] = true
+		}
+	}
+	return sleeve, nil
+}
+
+// Build the watch-only key of a network descriptor, and get the name it's stored under
+func (s *SingleSeedSleeve) watchOnlyKey(net NetworkDescriptor) (string, *NetworkKey, error) {
+	scheme := SchemeBIP44
+	if net.Scheme != "" {
+		var err error
+		if scheme, err = ParseDerivationScheme(net.Scheme); err != nil {
+			return "", nil, err
+		}
+	}
+	if net.CoinType >= firstHardened {
+		return "", nil, &InvalidCoinTypeError{CoinType: net.CoinType}
+	}
+	curve := CurveForCoinType(net.CoinType)
+	if net.Curve != curve.String() {
+		return "", nil, fmt.Errorf("invalid curve %q: coin type %d uses %s", net.Curve, net.CoinType, curve)
+	}
+	path, err := ParsePath(net.Path)
+	if err != nil {
+		return "", nil, err
+	}
+	pub, err := hex.DecodeString(net.PublicKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid public key: %v", err)
+	}
+	if err := checkPublicKey(curve, pub); err != nil {
+		return "", nil, err
+	}
+	if net.AddressParams != nil {
+		if err := net.AddressParams.validate(); err != nil {
+			return "", nil, err
+		}
+	}
+
+	netKey := &NetworkKey{
+		Network:  net.Network,
+		CoinType: net.CoinType,
+		Account:  net.Account,
+		Curve:    curve,
+		Path:     path.String(),
+		Scheme:   scheme,
+
+		AddressIndex:         net.AddressIndex,
+		HardenedAddressIndex: net.HardenedAddressIndex,
+
+		path:      path,
+		publicKey: pub,
+	}
+	if net.AddressParams != nil {
+		params := *net.AddressParams
+		netKey.AddressParams = &params
+	}
+
+	// Addresses are computed from the public keys, so they must match
+	addr, err := networkAddress(netKey)
+	if err != nil {
+		return "", nil, err
+	}
+	if addr != net.Address {
+		return "", nil, fmt.Errorf("address %q doesn't match the public key, expected %q", net.Address, addr)
+	}
+	return s.descriptorKeyName(net, scheme), netKey, nil
+}
+
+// Check a public key is valid on a curve, encoded as returned by GetPublicKey
+func checkPublicKey(curve Curve, pub []byte) error {
+	switch curve {
+	case CurveSecp256k1:
+		if len(pub) != 33 {
+			return fmt.Errorf("invalid secp256k1 public key size %d, expected 33", len(pub))
+		}
+		if _, err := btcec.ParsePubKey(pub, btcec.S256()); err != nil {
+			return fmt.Errorf("invalid secp256k1 public key: %v", err)
+		}
+	case CurveEd25519, CurveSr25519:
+		if len(pub) != ed25519.PublicKeySize {
+			return fmt.Errorf("invalid %s public key size %d, expected %d", curve, len(pub), ed25519.PublicKeySize)
+		}
+	default:
+		return fmt.Errorf("unknown curve %s", curve)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/xx-labs/sleeve/wots"
+)
+
+func TestSingleSeedSleeve_ExportPublicOnly(t *testing.T) {
+	seed := mustSeed(testVectorMnemonic)
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	_ = sleeve.DeriveNetworkKey("Solana", CoinTypeSolana, seed)
+	_ = sleeve.DeriveNetworkKeyAt("Ethereum", CoinTypeEthereum, 2, seed)
+	_, _ = sleeve.DeriveAddressKey("Ethereum", CoinTypeEthereum, 3, true, seed)
+	_ = sleeve.DeriveNetworkKeyAtWithOptions("Ethereum", CoinTypeEthereum, 1,
+		NetworkKeyOptions{Scheme: SchemeLedgerLive}, seed)
+	_, _, _ = sleeve.NextAddress("Solana")
+
+	data, err := sleeve.ExportPublicOnly()
+	if err != nil {
+		t.Fatalf("ExportPublicOnly() returned error: %v", err)
+	}
+
+	// No recovery material
+	if strings.Contains(string(data), strings.Fields(testVectorMnemonic)[0]) {
+		t.Fatalf("ExportPublicOnly() leaks the mnemonic")
+	}
+	for name, netKey := range sleeve.GetAllNetworkKeys() {
+		if strings.Contains(string(data), hex.EncodeToString(netKey.Key)) ||
+			strings.Contains(string(data), hex.EncodeToString(netKey.code)) {
+			t.Fatalf("ExportPublicOnly() leaks the %s private key or chain code", name)
+		}
+	}
+
+	// The watch-only sleeve has the same public wallet
+	watch, err := ImportPublicOnly(data)
+	if err != nil {
+		t.Fatalf("ImportPublicOnly() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(watch.GetNetworkNames(), sleeve.GetNetworkNames()) {
+		t.Fatalf("ImportPublicOnly() has networks %v, expected %v", watch.GetNetworkNames(), sleeve.GetNetworkNames())
+	}
+	expected, _ := sleeve.ExportDescriptor()
+	got, err := watch.ExportDescriptor()
+	if err != nil {
+		t.Fatalf("ExportDescriptor() returned error for a watch-only sleeve: %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("ImportPublicOnly() descriptor doesn't match the original")
+	}
+	if watch.DescriptorChecksum() != sleeve.DescriptorChecksum() || watch.Fingerprint() != sleeve.Fingerprint() {
+		t.Fatalf("ImportPublicOnly() checksum or fingerprint doesn't match the original")
+	}
+	ethAddr, _ := sleeve.GetEthereumAddress("Ethereum")
+	if addr, err := watch.GetEthereumAddress("Ethereum"); err != nil || addr != ethAddr {
+		t.Fatalf("GetEthereumAddress() returned %s for a watch-only sleeve, expected %s: %v", addr, ethAddr, err)
+	}
+	compressed, uncompressed, _ := sleeve.GetPublicKeyFormats("Bitcoin")
+	c, u, err := watch.GetPublicKeyFormats("Bitcoin")
+	if err != nil || !bytes.Equal(c, compressed) || !bytes.Equal(u, uncompressed) {
+		t.Fatalf("GetPublicKeyFormats() doesn't match the original for a watch-only sleeve: %v", err)
+	}
+	if len(watch.GetUserNetworkKeys()) != len(sleeve.GetUserNetworkKeys()) {
+		t.Fatalf("ImportPublicOnly() should keep the standard networks apart from user networks")
+	}
+
+	// Nothing needing private keys works
+	hash := keccak256([]byte("message"))
+	if _, err := watch.GetPrivateKey("Ethereum"); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("GetPrivateKey() should return ErrWatchOnly, got %v", err)
+	}
+	if _, _, err := watch.GetExtendedKey("Ethereum"); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("GetExtendedKey() should return ErrWatchOnly, got %v", err)
+	}
+	if _, err := watch.GetWIF("Bitcoin"); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("GetWIF() should return ErrWatchOnly, got %v", err)
+	}
+	if _, err := watch.SignWithNetwork("Ethereum", hash); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("SignWithNetwork() should return ErrWatchOnly, got %v", err)
+	}
+	if _, err := watch.SignECDSA("Ethereum", hash, SigFormatCompact); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("SignECDSA() should return ErrWatchOnly, got %v", err)
+	}
+	if _, err := watch.Sign(hash); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("Sign() should return ErrWatchOnly, got %v", err)
+	}
+	if _, err := watch.GetExtendedPublicKey("Bitcoin"); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("GetExtendedPublicKey() should return ErrWatchOnly, got %v", err)
+	}
+	if _, _, err := watch.NextAddress("Solana"); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("NextAddress() should return ErrWatchOnly, got %v", err)
+	}
+	if err := watch.DeriveNetworkKey("Dogecoin", CoinTypeDogecoin, seed); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("DeriveNetworkKey() should return ErrWatchOnly, got %v", err)
+	}
+
+	// External signers still sign
+	hsmKey, _ := btcec.NewPrivateKey(btcec.S256())
+	watch.SetExternalSigner("Ethereum", &fakeHSM{key: hsmKey})
+	if _, err := watch.SignWithNetwork("Ethereum", hash); err != nil {
+		t.Fatalf("SignWithNetwork() returned error with an external signer: %v", err)
+	}
+}
+
+func TestSingleSeedSleeve_ExportPublicOnly_AccountInNetworkPaths(t *testing.T) {
+	spec := NewGenSpec(2, wots.Level0)
+	spec.AccountInNetworkPaths = true
+	sleeve, err := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", spec)
+	if err != nil {
+		t.Fatalf("NewSingleSeedSleeveFromMnemonic() returned error: %v", err)
+	}
+	data, err := sleeve.ExportPublicOnly()
+	if err != nil {
+		t.Fatalf("ExportPublicOnly() returned error: %v", err)
+	}
+	watch, err := ImportPublicOnly(data)
+	if err != nil {
+		t.Fatalf("ImportPublicOnly() returned error: %v", err)
+	}
+	expected, _ := sleeve.GetFullPath("Ethereum")
+	if got, err := watch.GetFullPath("Ethereum"); err != nil || got != expected {
+		t.Fatalf("ImportPublicOnly() sleeve has path %s, expected %s (err %v)", got, expected, err)
+	}
+	if !strings.Contains(expected, "/60'/2'/0'/") {
+		t.Fatalf("GetFullPath() returned %s, expected network keys under account 2'", expected)
+	}
+}
+
+func TestImportPublicOnly_Invalid(t *testing.T) {
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	data, _ := sleeve.ExportPublicOnly()
+	ethAddr, _ := sleeve.GetAddress("Ethereum")
+	polkadotAddr, _ := sleeve.GetAddress("Polkadot")
+	polkadotPub, _ := sleeve.GetPublicKey("Polkadot")
+
+	invalid := map[string][]byte{
+		"malformed JSON":         data[:len(data)-1],
+		"wrong address":          bytes.Replace(data, []byte(ethAddr), []byte(polkadotAddr), 1),
+		"wrong derivation index": bytes.Replace(data, []byte(`"WOTSIndex":`), []byte(`"WOTSIndex":1`), 1),
+		"unknown params":         bytes.Replace(data, []byte(`"Level0"`), []byte(`"Level9"`), 1),
+		"wrong curve":            bytes.Replace(data, []byte(`"sr25519"`), []byte(`"ed25519"`), 1),
+		"invalid public key":     bytes.Replace(data, []byte(hex.EncodeToString(polkadotPub)), []byte("00"), 1),
+	}
+	for name, modified := range invalid {
+		if bytes.Equal(modified, data) {
+			t.Fatalf("Test data wasn't modified for %s", name)
+		}
+		if _, err := ImportPublicOnly(modified); err == nil {
+			t.Fatalf("ImportPublicOnly() should return error for %s", name)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/xx-labs/sleeve/hasher"
+)
+
+// Returned when a one-time WOTS+ key was already used to sign
+var ErrWOTSKeyReused = errors.New("WOTS+ key was already used to sign")
+
+// WOTSStateStore tracks which one-time WOTS+ keys have been used to sign
+// Keys are identified by their WOTS+ public key. The sleeve's derivation index is passed too,
+// but it only has 31 bits, so different wallets sharing a store collide on it after about
+// 2^15.5 wallets, and it must not be used alone to identify keys.
+// This is why Reserve takes the public key, and isn't a Reserve(index uint32) error scoped to
+// one wallet: a single store can be shared by every wallet of an application, where a store
+// per wallet would leave reuse undetected if the wrong store were set on a sleeve.
+// Applications can back this with persistent storage, and share it between
+// sleeve instances, so a key is never used twice even across restarts
+type WOTSStateStore interface {
+	// Reserve marks the key with the given WOTS+ public key and derivation index as used
+	// It must return an error, e.g. ErrWOTSKeyReused, if the key was already reserved
+	Reserve(wotsPK []byte, index uint32) error
+}
+
+// MemoryWOTSStateStore is an in-memory WOTSStateStore, safe for concurrent use
+// Keys are stored by the SHA3-256 hash of their WOTS+ public key and their index.
+// Reservations are lost when the process exits
+type MemoryWOTSStateStore struct {
+	mu   sync.Mutex
+	used map[wotsStateKey]bool
+}
+
+// Key of a reservation in a MemoryWOTSStateStore
+type wotsStateKey struct {
+	pkHash [32]byte
+	index  uint32
+}
+
+func NewMemoryWOTSStateStore() *MemoryWOTSStateStore {
+	return &MemoryWOTSStateStore{used: make(map[wotsStateKey]bool)}
+}
+
+func (m *MemoryWOTSStateStore) Reserve(wotsPK []byte, index uint32) error {
+	key := wotsStateKey{index: index}
+	copy(key.pkHash[:], hasher.SHA3_256.Hash(wotsPK))
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.used[key] {
+		return ErrWOTSKeyReused
+	}
+	m.used[key] = true
+	return nil
+}
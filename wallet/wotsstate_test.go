@@ -0,0 +1,84 @@
+////////////////////////////////////////////////////////////////////////////////////////////
+// Copyright © 2021 xx network SEZC                                                       //
+//                                                                                        //
+// Use of this source code is governed by a license that can be found in the LICENSE file //
+////////////////////////////////////////////////////////////////////////////////////////////
+
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/xx-labs/sleeve/wots"
+)
+
+func TestMemoryWOTSStateStore_Reserve(t *testing.T) {
+	store := NewMemoryWOTSStateStore()
+	pk := bytes.Repeat([]byte{1}, wots.PKSize)
+	if err := store.Reserve(pk, 7); err != nil {
+		t.Fatalf("Reserve() returned error for unused index: %v", err)
+	}
+	if err := store.Reserve(pk, 8); err != nil {
+		t.Fatalf("Reserve() returned error for unused index: %v", err)
+	}
+	if err := store.Reserve(pk, 7); !errors.Is(err, ErrWOTSKeyReused) {
+		t.Fatalf("Reserve() should return ErrWOTSKeyReused for used index, got %v", err)
+	}
+
+	// Another wallet whose derivation index collides has a different key
+	other := bytes.Repeat([]byte{2}, wots.PKSize)
+	if err := store.Reserve(other, 7); err != nil {
+		t.Fatalf("Reserve() returned error for another WOTS+ key with the same index: %v", err)
+	}
+}
+
+func TestSingleSeedSleeve_Sign(t *testing.T) {
+	msg := []byte("transfer 10 xx")
+
+	// Without a store, signing isn't tracked
+	sleeve, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	sig, err := sleeve.Sign(msg)
+	if err != nil {
+		t.Fatalf("Sign() returned error: %v", err)
+	}
+	if ok, err := wots.Verify(msg, sig, sleeve.GetWOTSPublicKey()); !ok {
+		t.Fatalf("Sign() produced invalid signature: %v", err)
+	}
+	if _, err := sleeve.Sign(msg); err != nil {
+		t.Fatalf("Sign() without store returned error: %v", err)
+	}
+
+	// Two instances from the same mnemonic sharing a store can only sign once
+	store := NewMemoryWOTSStateStore()
+	first, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	second, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", DefaultGenSpec())
+	first.SetWOTSStateStore(store)
+	second.SetWOTSStateStore(store)
+	if _, err := first.Sign(msg); err != nil {
+		t.Fatalf("Sign() returned error on first use: %v", err)
+	}
+	if _, err := second.Sign([]byte("other message")); !errors.Is(err, ErrWOTSKeyReused) {
+		t.Fatalf("Sign() should return ErrWOTSKeyReused on reuse, got %v", err)
+	}
+
+	// A different wallet has its own key
+	other, _ := NewSingleSeedSleeveFromMnemonic(testVectorMnemonic, "", NewGenSpec(1, wots.DefaultParams))
+	other.SetWOTSStateStore(store)
+	if _, err := other.Sign(msg); err != nil {
+		t.Fatalf("Sign() returned error for a different wallet: %v", err)
+	}
+
+	// A wiped sleeve can't sign, and doesn't use up the key in the store
+	store = NewMemoryWOTSStateStore()
+	first.SetWOTSStateStore(store)
+	second.SetWOTSStateStore(store)
+	first.Wipe()
+	if _, err := first.Sign(msg); !errors.Is(err, ErrWiped) {
+		t.Fatalf("Sign() should return ErrWiped after Wipe(), got %v", err)
+	}
+	if _, err := second.Sign(msg); err != nil {
+		t.Fatalf("Sign() after another instance was wiped returned error: %v", err)
+	}
+}
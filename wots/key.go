@@ -120,6 +120,26 @@ func (k *Key) Generate() {
 	k.generated = true
 }
 
+///////////////////////////////////////////////////////////////////////
+// WIPE
+// Zeroize the secret seed and generated ladders
+// The public key is kept, but the key can't be used for signing afterwards
+func (k *Key) Wipe() {
+	zero(k.seed)
+	for _, chain := range k.chains {
+		zero(chain)
+	}
+	k.chains = nil
+	k.generated = false
+}
+
+// Set all bytes of b to zero
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
 ///////////////////////////////////////////////////////////////////////
 // SIGN
 // Signs an arbitrary length message using the WOTS+ key
@@ -198,6 +198,30 @@ func TestKey_Generate(t *testing.T) {
 	}
 }
 
+func TestKey_Wipe(t *testing.T) {
+	params := NewParams(32, 32, hasher.BLAKE3_256, hasher.BLAKE3_256)
+	key := NewKey(params, rand.Reader)
+	key.Generate()
+	pk := append([]byte{}, key.GetPK()...)
+	seed := key.seed
+	chain := key.chains[0]
+
+	key.Wipe()
+
+	if !bytes.Equal(seed, make([]byte, len(seed))) {
+		t.Fatalf("Key.Wipe didn't zeroize the seed")
+	}
+	if !bytes.Equal(chain, make([]byte, len(chain))) {
+		t.Fatalf("Key.Wipe didn't zeroize the ladders")
+	}
+	if key.chains != nil || key.generated {
+		t.Fatalf("Key.Wipe didn't clear the ladders")
+	}
+	if !bytes.Equal(key.GetPK(), pk) {
+		t.Fatalf("Key.Wipe modified the public key")
+	}
+}
+
 func TestKey_Sign(t *testing.T) {
 	params := NewParams(32, 32, hasher.BLAKE3_256, hasher.BLAKE3_256)
 	key := NewKey(params, rand.Reader)
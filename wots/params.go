@@ -21,10 +21,41 @@ const W = 256
 // The size of WOTS+ Public Keys if fixed to 32 bytes
 const PKSize = 32
 
-// The hash function used to calculate the public key
-// using a tweakable hash function construction is fixed
+// The default hash function used to calculate the public key
+// using a tweakable hash function construction
 const PKHash = hasher.SHA3_256
 
+// HashFunc selects the hash function of the tweakable hash construction that
+// compresses the ladder ends into the public key. Ladder steps use the PRF hash
+// The default, HashSHA3_256, is PKHash, so params without a HashFunc set are unchanged
+type HashFunc uint8
+
+const (
+	HashSHA3_256 HashFunc = iota
+	HashBLAKE2B_256
+)
+const HashFuncLen = HashBLAKE2B_256 + 1 // 2
+
+// Get the hash function, or false for an unknown HashFunc
+func (h HashFunc) hasher() (hasher.Hasher, bool) {
+	switch h {
+	case HashSHA3_256:
+		return PKHash, true
+	case HashBLAKE2B_256:
+		return hasher.BLAKE2B_256, true
+	default:
+		return 0, false
+	}
+}
+
+// Returns the string representation of the hash function
+func (h HashFunc) String() string {
+	if hash, ok := h.hasher(); ok {
+		return hash.String()
+	}
+	return "UNKNOWN HASH FUNC"
+}
+
 // The size of WOTS+ secret and public seeds is fixed to 32 bytes
 const SeedSize = 32
 
@@ -56,6 +87,8 @@ type Params struct {
 	prfHash hasher.Hasher
 	// The hash function to use for the message
 	msgHash hasher.Hasher
+	// The hash function to use for the tweak and public key
+	pkHash HashFunc
 	// The total number of ladders
 	total int
 }
@@ -95,16 +128,39 @@ func NewParams(n, m int, prf, msg hasher.Hasher) *Params {
 	}
 }
 
+// Returns a copy of the params using the given hash function for the tweak and public key
+// Keys of the copy have different public keys, unless h is the default HashSHA3_256
+// Returns nil for an unknown HashFunc
+func (p *Params) WithHashFunc(h HashFunc) *Params {
+	if _, ok := h.hasher(); !ok {
+		return nil
+	}
+	out := *p
+	out.pkHash = h
+	return &out
+}
+
+// Get the hash function used for the tweak and public key
+func (p *Params) HashFunc() HashFunc {
+	return p.pkHash
+}
+
 ///////////////////////////////////////////////////////////////////////
 // Stringer interface
+// The public key hash is only shown when it isn't the default
 func (p *Params) String() string {
-	return fmt.Sprintf("N: %d, M: %d, PRF: %s, MSG: %s", p.n, p.m, p.prfHash, p.msgHash)
+	str := fmt.Sprintf("N: %d, M: %d, PRF: %s, MSG: %s", p.n, p.m, p.prfHash, p.msgHash)
+	if p.pkHash != HashSHA3_256 {
+		str += fmt.Sprintf(", PK: %s", p.pkHash)
+	}
+	return str
 }
 
 ///////////////////////////////////////////////////////////////////////
 // Comparison
 func (p *Params) Equal(other *Params) bool {
-	return p.n == other.n && p.m == other.m && p.prfHash == other.prfHash && p.msgHash == other.msgHash
+	return p.n == other.n && p.m == other.m && p.prfHash == other.prfHash && p.msgHash == other.msgHash &&
+		p.pkHash == other.pkHash
 }
 
 ///////////////////////////////////////////////////////////////////////
@@ -182,7 +238,8 @@ func (p *Params) computeLadders(out, pSeed, msg, points []byte, chains [][]byte,
 	// PRF
 	hPrf := p.prfHash.New()
 	// Tweak and Public Key
-	hTweak := PKHash.New()
+	pkHash, _ := p.pkHash.hasher()
+	hTweak := pkHash.New()
 
 	// Hash buffer
 	prfBuffer := make([]byte, 0, hPrf.Size())
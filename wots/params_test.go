@@ -80,6 +80,13 @@ func TestParams_String(t *testing.T) {
 	if str != expected {
 		t.Errorf("Params.String() returned invalid string! Expected %s, got %s", expected, str)
 	}
+
+	// A hash function other than the default is shown
+	str = params.WithHashFunc(HashBLAKE2B_256).String()
+	expected = "N: 32, M: 32, PRF: BLAKE3_256, MSG: BLAKE3_256, PK: BLAKE2B_256"
+	if str != expected {
+		t.Errorf("Params.String() returned invalid string! Expected %s, got %s", expected, str)
+	}
 }
 
 func TestParams_Equal(t *testing.T) {
@@ -110,6 +117,12 @@ func TestParams_Equal(t *testing.T) {
 		t.Fatalf("Params can't be equal when MSG hash is different")
 	}
 
+	// Different PK Hash
+	other = params.WithHashFunc(HashBLAKE2B_256)
+	if params.Equal(other) {
+		t.Fatalf("Params can't be equal when PK hash is different")
+	}
+
 	// Equal params
 	other = NewParams(32, 32, hasher.BLAKE3_256, hasher.BLAKE3_256)
 	if !params.Equal(other) {
@@ -8,6 +8,7 @@ package wots
 
 import (
 	"errors"
+	"fmt"
 	"github.com/xx-labs/sleeve/hasher"
 )
 
@@ -155,8 +156,17 @@ var consensusParams = NewParams(consensusN, consensusM, consensusPrfH, consensus
 
 ///////////////////////////////////////////////////////////////////////
 // Params encoding
+// The low bits select the parameter set, and the high bits the HashFunc of
+// the public key, so signatures and wallet specs record it. The default
+// HashSHA3_256 is 0, so encodings of the parameter sets are unchanged
 type ParamsEncoding uint8
 
+// Position of the HashFunc in a params encoding
+const (
+	hashFuncShift = 4
+	levelMask     = 1<<hashFuncShift - 1
+)
+
 // Encode the different parameter sets that exist for now
 const (
 	Level0 ParamsEncoding = iota
@@ -170,8 +180,17 @@ const (
 	DefaultParams     = Level0
 )
 
-// Get the parameter set from its encoding
+// Get the parameter set from its encoding, using the HashFunc it records
 func DecodeParams(enc ParamsEncoding) *Params {
+	params := decodeLevel(enc.Level())
+	if params == nil || enc.HashFunc() == HashSHA3_256 {
+		return params
+	}
+	return params.WithHashFunc(enc.HashFunc())
+}
+
+// Get the parameter set of a params encoding without HashFunc
+func decodeLevel(enc ParamsEncoding) *Params {
 	switch enc {
 	case Level0:
 		return level0Params
@@ -188,8 +207,138 @@ func DecodeParams(enc ParamsEncoding) *Params {
 	}
 }
 
-// Encode a parameter set
+// Get the parameter set of the encoding, without its HashFunc
+func (enc ParamsEncoding) Level() ParamsEncoding {
+	return enc & levelMask
+}
+
+// Get the HashFunc recorded in the encoding
+func (enc ParamsEncoding) HashFunc() HashFunc {
+	return HashFunc(enc >> hashFuncShift)
+}
+
+// Get the encoding of the same parameter set using the given HashFunc
+func (enc ParamsEncoding) WithHashFunc(h HashFunc) ParamsEncoding {
+	return enc.Level() | ParamsEncoding(h)<<hashFuncShift
+}
+
+// Returns the string representation of the parameter set encoding
+// A HashFunc other than the default is appended, e.g., "Level0+BLAKE2B_256"
+func (enc ParamsEncoding) String() string {
+	if h := enc.HashFunc(); h != HashSHA3_256 {
+		if _, ok := h.hasher(); !ok || decodeLevel(enc.Level()) == nil {
+			return "UNKNOWN PARAMS ENCODING"
+		}
+		return enc.Level().String() + "+" + h.String()
+	}
+	switch enc {
+	case Level0:
+		return "Level0"
+	case Level1:
+		return "Level1"
+	case Level2:
+		return "Level2"
+	case Level3:
+		return "Level3"
+	case Consensus:
+		return "Consensus"
+	default:
+		return "UNKNOWN PARAMS ENCODING"
+	}
+}
+
+// Classical security bits targeted by each wallet parameter set, in increasing order
+// Values are the floor of the classical security levels documented above
+// Consensus params are left out, since they can't be used for wallets
+var walletSecurityBits = []struct {
+	enc  ParamsEncoding
+	bits int
+}{
+	{Level0, 139},
+	{Level1, 171},
+	{Level2, 203},
+	{Level3, 235},
+}
+
+// Get the smallest wallet parameter set that provides at least
+// the given number of bits of classical security
+// Returns an error if no parameter set reaches the requested security
+func ParamsForSecurityBits(bits int) (ParamsEncoding, error) {
+	if bits <= 0 {
+		return ParamsEncodingLen, fmt.Errorf("invalid security bits: %d", bits)
+	}
+	for _, level := range walletSecurityBits {
+		if bits <= level.bits {
+			return level.enc, nil
+		}
+	}
+	max := walletSecurityBits[len(walletSecurityBits)-1].bits
+	return ParamsEncodingLen, fmt.Errorf("unsupported security bits: %d, maximum classical security is %d bits", bits, max)
+}
+
+// Get the number of WOTS+ chains (ladders) of a parameter set: one per message byte plus the checksum chains
+// Returns 0 for an unknown parameter set encoding
+func ChainCount(params ParamsEncoding) int {
+	p := DecodeParams(params)
+	if p == nil {
+		return 0
+	}
+	return p.total
+}
+
+// Get the number of hash operations needed to generate the public key of a WOTS+ key with a parameter set,
+// to estimate the cost of generating wallets at each security level before choosing one
+// Operations are counted as compression function calls, i.e., hash input blocks, since levels make the same
+// hash calls and only differ in how much they hash: key generation computes one PRF per chain for the secret
+// keys, one per chain depth for the random elements, W-1 chain steps per chain hashing the N byte chain values,
+// and two hashes with the params' HashFunc over the chain ends, for the tweak and the public key itself.
+// The tweak is counted as if all chain ends had odd parity, so the result is an upper bound.
+// Higher levels hash longer chain values, so they cost more. Returns 0 for an unknown parameter set encoding
+func HashOpsPerKeyGen(params ParamsEncoding) int {
+	p := DecodeParams(params)
+	if p == nil {
+		return 0
+	}
+	pkHash, _ := p.pkHash.hasher()
+	// PRF(seed || index) and chain steps H(PSEED || index || masked value)
+	prfOps := (p.total + W - 1) * hashBlocks(p.prfHash, SeedSize+1)
+	chainOps := p.total * (W - 1) * hashBlocks(p.prfHash, SeedSize+1+p.n)
+	// Tweak over the chain ends, and H(PSEED || tweak || chain ends)
+	ends := p.total * p.n
+	pkOps := hashBlocks(pkHash, ends) + hashBlocks(pkHash, SeedSize+pkHash.Size()+ends)
+	return prfOps + chainOps + pkOps
+}
+
+// Get the number of input blocks, i.e., compression function calls, of hashing length bytes
+// with one of the hash functions used by WOTS+: BLAKE2 compresses its last block without
+// padding, while SHA-3 always pads, which takes a block of its own when the input fills the last one
+func hashBlocks(h hasher.Hasher, length int) int {
+	size := h.New().BlockSize()
+	switch h {
+	case hasher.BLAKE2B_256, hasher.BLAKE2B_384, hasher.BLAKE2B_512:
+		if length == 0 {
+			return 1
+		}
+		return (length + size - 1) / size
+	default:
+		return length/size + 1
+	}
+}
+
+// Encode a parameter set, including its HashFunc
 func EncodeParams(p *Params) ParamsEncoding {
+	if p.pkHash == HashSHA3_256 {
+		return encodeLevel(p)
+	}
+	enc := encodeLevel(p.WithHashFunc(HashSHA3_256))
+	if enc == ParamsEncodingLen {
+		return ParamsEncodingLen
+	}
+	return enc.WithHashFunc(p.pkHash)
+}
+
+// Encode a parameter set using the default HashFunc
+func encodeLevel(p *Params) ParamsEncoding {
 	if level0Params.Equal(p) {
 		return Level0
 	}
@@ -249,7 +398,7 @@ func decodeParams(msg, signature []byte, consensusAllowed bool) (*Params, error)
 	}
 	// 2. Don't allow consensus params
 	encoding := ParamsEncoding(signature[0])
-	if encoding == Consensus && !consensusAllowed {
+	if encoding.Level() == Consensus && !consensusAllowed {
 		return nil, errConsensusParams
 	}
 	// 3. Decode params
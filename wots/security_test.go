@@ -14,6 +14,112 @@ import (
 	"testing"
 )
 
+func TestParamsEncoding_String(t *testing.T) {
+	expected := []string{"Level0", "Level1", "Level2", "Level3", "Consensus"}
+	for i := ParamsEncoding(0); i < ParamsEncodingLen; i++ {
+		if i.String() != expected[i] {
+			t.Fatalf("ParamsEncoding.String() returned wrong string. Got %s, expected %s", i, expected[i])
+		}
+	}
+
+	if ParamsEncodingLen.String() != "UNKNOWN PARAMS ENCODING" {
+		t.Fatalf("ParamsEncoding.String() should return unknown for invalid params encoding")
+	}
+}
+
+func TestParamsForSecurityBits(t *testing.T) {
+	tests := []struct {
+		bits     int
+		expected ParamsEncoding
+	}{
+		{1, Level0},
+		{128, Level0},
+		{139, Level0},
+		{140, Level1},
+		{171, Level1},
+		{192, Level2},
+		{203, Level2},
+		{224, Level3},
+		{235, Level3},
+	}
+	for _, tt := range tests {
+		enc, err := ParamsForSecurityBits(tt.bits)
+		if err != nil {
+			t.Fatalf("ParamsForSecurityBits(%d) returned error: %s", tt.bits, err)
+		}
+		if enc != tt.expected {
+			t.Fatalf("ParamsForSecurityBits(%d) returned wrong params. Got %s, expected %s", tt.bits, enc, tt.expected)
+		}
+	}
+
+	// Unsupported values should error
+	for _, bits := range []int{-1, 0, 236, 256} {
+		if _, err := ParamsForSecurityBits(bits); err == nil {
+			t.Fatalf("ParamsForSecurityBits(%d) should return error", bits)
+		}
+	}
+}
+
+func TestChainCountAndHashOpsPerKeyGen(t *testing.T) {
+	tests := []struct {
+		enc     ParamsEncoding
+		chains  int
+		hashOps int
+	}{
+		{Level0, 26, 6920},
+		{Level1, 26, 6922},
+		{Level2, 26, 6923},
+		{Level3, 26, 6925},
+		{Consensus, 34, 8977},
+		{Level0.WithHashFunc(HashBLAKE2B_256), 26, 6921},
+		{Level3.WithHashFunc(HashBLAKE2B_256), 26, 6925},
+	}
+	for _, tt := range tests {
+		if chains := ChainCount(tt.enc); chains != tt.chains {
+			t.Fatalf("ChainCount(%s) returned %d, expected %d", tt.enc, chains, tt.chains)
+		}
+		if ops := HashOpsPerKeyGen(tt.enc); ops != tt.hashOps {
+			t.Fatalf("HashOpsPerKeyGen(%s) returned %d, expected %d", tt.enc, ops, tt.hashOps)
+		}
+	}
+
+	// Higher security levels cost more to generate
+	for _, h := range []HashFunc{HashSHA3_256, HashBLAKE2B_256} {
+		levels := []ParamsEncoding{Level0, Level1, Level2, Level3}
+		for i := 1; i < len(levels); i++ {
+			prev, cur := levels[i-1].WithHashFunc(h), levels[i].WithHashFunc(h)
+			if HashOpsPerKeyGen(prev) >= HashOpsPerKeyGen(cur) {
+				t.Fatalf("HashOpsPerKeyGen(%s) = %d should be less than HashOpsPerKeyGen(%s) = %d",
+					prev, HashOpsPerKeyGen(prev), cur, HashOpsPerKeyGen(cur))
+			}
+		}
+	}
+
+	// Block counts of the hash functions used by WOTS+
+	blocks := []struct {
+		h      hasher.Hasher
+		length int
+		blocks int
+	}{
+		{hasher.BLAKE2B_256, 0, 1},
+		{hasher.BLAKE2B_256, 128, 1},
+		{hasher.BLAKE2B_256, 129, 2},
+		{hasher.SHA3_256, 0, 1},
+		{hasher.SHA3_256, 135, 1},
+		{hasher.SHA3_256, 136, 2},
+	}
+	for _, b := range blocks {
+		if n := hashBlocks(b.h, b.length); n != b.blocks {
+			t.Fatalf("hashBlocks(%s, %d) returned %d, expected %d", b.h, b.length, n, b.blocks)
+		}
+	}
+
+	// Unknown encodings have no chains
+	if ChainCount(ParamsEncodingLen) != 0 || HashOpsPerKeyGen(ParamsEncodingLen) != 0 {
+		t.Fatalf("ChainCount() and HashOpsPerKeyGen() should return 0 for unknown params")
+	}
+}
+
 func TestDecodeParams(t *testing.T) {
 	// Decode level0 params
 	params := DecodeParams(Level0)
@@ -290,3 +396,92 @@ func TestVerify_ConformanceTests(t *testing.T) {
 		}
 	}
 }
+
+func TestParamsEncoding_HashFunc(t *testing.T) {
+	for level := ParamsEncoding(0); level < ParamsEncodingLen; level++ {
+		// The default HashFunc leaves the encoding unchanged
+		if enc := level.WithHashFunc(HashSHA3_256); enc != level || enc.String() != level.String() {
+			t.Fatalf("WithHashFunc(HashSHA3_256) changed encoding %s to %s", level, enc)
+		}
+		for h := HashFunc(0); h < HashFuncLen; h++ {
+			enc := level.WithHashFunc(h)
+			if enc.Level() != level || enc.HashFunc() != h {
+				t.Fatalf("encoding %d has level %s and hash %s, expected %s and %s", enc, enc.Level(), enc.HashFunc(), level, h)
+			}
+			params := DecodeParams(enc)
+			if params == nil {
+				t.Fatalf("DecodeParams() returned nil for %s", enc)
+			}
+			if params.HashFunc() != h {
+				t.Fatalf("DecodeParams(%s) has hash %s, expected %s", enc, params.HashFunc(), h)
+			}
+			if EncodeParams(params) != enc {
+				t.Fatalf("EncodeParams() returned %s, expected %s", EncodeParams(params), enc)
+			}
+		}
+	}
+	if Level0.WithHashFunc(HashBLAKE2B_256).String() != "Level0+BLAKE2B_256" {
+		t.Fatalf("String() returned %s, expected Level0+BLAKE2B_256", Level0.WithHashFunc(HashBLAKE2B_256))
+	}
+
+	// Unknown hash functions and levels don't decode
+	for _, enc := range []ParamsEncoding{Level0.WithHashFunc(HashFuncLen), ParamsEncodingLen.WithHashFunc(HashBLAKE2B_256)} {
+		if DecodeParams(enc) != nil {
+			t.Fatalf("DecodeParams() should return nil for invalid params encoding %d", enc)
+		}
+		if enc.String() != "UNKNOWN PARAMS ENCODING" {
+			t.Fatalf("String() returned %s for invalid params encoding %d", enc, enc)
+		}
+	}
+	if level0Params.WithHashFunc(HashFuncLen) != nil {
+		t.Fatalf("WithHashFunc() should return nil for unknown hash function")
+	}
+	if EncodeParams(NewParams(24, 32, hasher.SHA2_256, hasher.BLAKE2B_256).WithHashFunc(HashBLAKE2B_256)) != ParamsEncodingLen {
+		t.Fatalf("EncodeParams() should return ParamsEncodingLen for unknown params")
+	}
+}
+
+func TestHashFunc_PublicKey(t *testing.T) {
+	seed, _ := hex.DecodeString(testVectorSecretSeedHex)
+	pSeed, _ := hex.DecodeString(testVectorPublicSeedHex)
+	expectedPk, _ := hex.DecodeString(testVectorExpectedPubKey)
+
+	// The default hash function reproduces the test vector exactly
+	for _, params := range []*Params{level0Params.WithHashFunc(HashSHA3_256), DecodeParams(Level0.WithHashFunc(HashSHA3_256))} {
+		if !params.Equal(level0Params) {
+			t.Fatalf("params with the default hash function should equal the parameter set")
+		}
+		if pk := NewKeyFromSeed(params, seed, pSeed).ComputePK(); !bytes.Equal(pk, expectedPk) {
+			t.Fatalf("ComputePK() with HashSHA3_256 returned %x, expected %x", pk, expectedPk)
+		}
+	}
+
+	// Another hash function changes the public key, and signatures record it
+	params := DecodeParams(Level0.WithHashFunc(HashBLAKE2B_256))
+	if params.Equal(level0Params) {
+		t.Fatalf("params with a different hash function can't be equal")
+	}
+	key := NewKeyFromSeed(params, seed, pSeed)
+	pk := key.ComputePK()
+	if bytes.Equal(pk, expectedPk) {
+		t.Fatalf("ComputePK() with HashBLAKE2B_256 should differ from the default")
+	}
+	msg := []byte("hash function selection")
+	sig := key.Sign(msg)
+	if ParamsEncoding(sig[0]) != Level0.WithHashFunc(HashBLAKE2B_256) {
+		t.Fatalf("signature has params encoding %d, expected %d", sig[0], Level0.WithHashFunc(HashBLAKE2B_256))
+	}
+	if ok, err := Verify(msg, sig, pk); !ok || err != nil {
+		t.Fatalf("Verify() failed for HashBLAKE2B_256 signature: %v", err)
+	}
+	if ok, _ := Verify(msg, sig, expectedPk); ok {
+		t.Fatalf("Verify() should fail against the default hash public key")
+	}
+
+	// Consensus params can't be used for transactions, whatever the hash function
+	consensusKey := NewKeyFromSeed(DecodeParams(Consensus.WithHashFunc(HashBLAKE2B_256)), seed, pSeed)
+	out := make([]byte, 0, PKSize)
+	if _, err := DecodeTransactionSignature(out, msg, consensusKey.Sign(msg)); err == nil {
+		t.Fatalf("DecodeTransactionSignature() should return error for consensus params")
+	}
+}